@@ -0,0 +1,204 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// batchPipelinePhase defaults the status of the Workflow into _st, so that
+// the phase and progress can be read before Argo reports them.
+const batchPipelinePhase = `_st: {
+	phase:    *"Pending" | string
+	progress: *"-" | string
+	message:  *"" | string
+	...
+}
+if context.output.status != _|_ {
+	_st: context.output.status
+}
+`
+
+// BatchPipeline creates the batch-pipeline component definition.
+// It describes a DAG of container steps run by Argo Workflows, either as a
+// Workflow run once or as a WorkflowTemplate to submit or schedule from Argo.
+// A step runs once the steps it depends on, and the steps whose artifacts it
+// reads, have succeeded.
+//
+// A Workflow is named after a hash of its spec, so that a change of the
+// pipeline runs a new Workflow, and the Workflow of the previous spec is
+// garbage collected with the previous revision of the application. The same
+// spec is not run again.
+//
+// A Workflow is healthy once it succeeded; a WorkflowTemplate has no status
+// and is always healthy.
+func BatchPipeline() *defkit.ComponentDefinition {
+	kind := defkit.Enum("kind").
+		Values("Workflow", "WorkflowTemplate").
+		Default("Workflow").
+		Description("Run the pipeline once as a Workflow, or store it as a WorkflowTemplate to submit or schedule from Argo")
+	steps := defkit.Array("steps").
+		Description("Steps of the pipeline").
+		WithFields(
+			defkit.String("name").Pattern(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`).Description("Name of the step, unique in the pipeline"),
+			defkit.String("image").Description("Image of the step"),
+			defkit.StringList("command").Optional().Description("Command of the container"),
+			defkit.StringList("args").Optional().Description("Arguments of the command"),
+			defkit.Array("env").Optional().Description("Environment variables of the container").WithFields(
+				defkit.String("name").Description("Name of the variable"),
+				defkit.String("value").Description("Value of the variable"),
+			),
+			defkit.StringList("dependencies").Optional().Description("Names of the steps that must succeed before this one"),
+			defkit.Int("retries").Default(0).Min(0).Description("Number of times the step is retried"),
+			defkit.Enum("retryPolicy").Values("Always", "OnFailure", "OnError", "OnTransientError").Default("OnFailure").
+				Description("Failures the step is retried on: failed containers, Argo errors, or both with Always"),
+			defkit.Array("inputArtifacts").Optional().Description("Artifacts of other steps read by the step").WithFields(
+				defkit.String("name").Description("Name of the artifact in the step"),
+				defkit.String("path").Description("Path the artifact is written to in the container"),
+				defkit.String("from").Pattern(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?\.[-_a-zA-Z0-9]+$`).
+					Description("Output artifact read, as <step>.<artifact>"),
+			),
+			defkit.Array("outputArtifacts").Optional().Description("Artifacts saved by the step to the artifact repository").WithFields(
+				defkit.String("name").Description("Name of the artifact"),
+				defkit.String("path").Description("Path of the file or directory saved in the container"),
+			),
+			defkit.Object("resources").Optional().Description("Resources of the container").WithFields(
+				defkit.Object("requests").Optional().Description("Minimum resources, e.g. `cpu: \"500m\"`"),
+				defkit.Object("limits").Optional().Description("Maximum resources, e.g. `memory: \"1Gi\"`"),
+			),
+		)
+	serviceAccountName := defkit.String("serviceAccountName").
+		Optional().
+		Description("ServiceAccount the steps run as")
+	parallelism := defkit.Int("parallelism").
+		Optional().
+		Min(1).
+		Description("Maximum number of steps running at the same time")
+	activeDeadlineSeconds := defkit.Int("activeDeadlineSeconds").
+		Optional().
+		Min(1).
+		Description("Number of seconds after which the pipeline is failed")
+	podGC := defkit.Enum("podGC").
+		Optional().
+		Values("OnPodCompletion", "OnPodSuccess", "OnWorkflowCompletion", "OnWorkflowSuccess").
+		Description("When the pods of the steps are deleted")
+	artifactRepositoryRef := defkit.Object("artifactRepositoryRef").
+		Optional().
+		Description("Artifact repository of the artifacts, defaults to the repository of the namespace").
+		WithFields(
+			defkit.String("configMap").Default("artifact-repositories").Description("ConfigMap of the artifact repositories"),
+			defkit.String("key").Optional().Description("Key of the repository in the ConfigMap, defaults to its default repository"),
+		)
+
+	return WithRawTemplateBlocks(defkit.NewComponent("batch-pipeline").
+		Description("Describes a DAG of batch steps run by Argo Workflows as a Workflow or a WorkflowTemplate.").
+		AutodetectWorkload().
+		WithImports("crypto/sha256", "encoding/hex", "encoding/json", "strings").
+		CustomStatus(batchPipelinePhase+`message: *"" | string
+if context.output.kind == "WorkflowTemplate" {
+	message: "Steps:\(len(context.output.spec.templates)-1)"
+}
+if context.output.kind == "Workflow" && _st.message == "" {
+	message: "Phase:\(_st.phase), progress:\(_st.progress)"
+}
+if context.output.kind == "Workflow" && _st.message != "" {
+	message: "Phase:\(_st.phase), progress:\(_st.progress), message:\(_st.message)"
+}`).
+		HealthPolicy(batchPipelinePhase+`isHealth: context.output.kind == "WorkflowTemplate" || _st.phase == "Succeeded"`).
+		Params(kind, steps, serviceAccountName, parallelism, activeDeadlineSeconds, podGC, artifactRepositoryRef).
+		Template(batchPipelineTemplate))
+}
+
+// batchPipelineTemplate defines the template function for batch-pipeline.
+// The kind of the output is a parameter, and both the DAG tasks and the step
+// templates come from one comprehension each over the steps.
+func batchPipelineTemplate(tpl *defkit.Template) {
+	tpl.SetRawHeaderBlock(batchPipelineSpec)
+	tpl.SetRawOutputsBlock(`output: {
+	apiVersion: "argoproj.io/v1alpha1"
+	kind:       parameter.kind
+	metadata: name: _name
+	spec: _spec
+}`)
+}
+
+// batchPipelineSpec renders the steps into a DAG template running the template
+// of each step, and names the output. Step names must be unique, and the
+// steps a step depends on or reads artifacts from must exist.
+const batchPipelineSpec = `_stepNames: {for s in parameter.steps {(s.name): _}}
+
+_spec: {
+	entrypoint: "main"
+	if parameter["serviceAccountName"] != _|_ {serviceAccountName: parameter.serviceAccountName}
+	if parameter["parallelism"] != _|_ {parallelism: parameter.parallelism}
+	if parameter["activeDeadlineSeconds"] != _|_ {activeDeadlineSeconds: parameter.activeDeadlineSeconds}
+	if parameter["podGC"] != _|_ {podGC: strategy: parameter.podGC}
+	if parameter["artifactRepositoryRef"] != _|_ {
+		artifactRepositoryRef: {
+			configMap: parameter.artifactRepositoryRef.configMap
+			if parameter.artifactRepositoryRef.key != _|_ {key: parameter.artifactRepositoryRef.key}
+		}
+	}
+	templates: [{
+		name: "main"
+		dag: tasks: [for i, s in parameter.steps {
+			_deps: {
+				if s.dependencies != _|_ for d in s.dependencies {(d): _}
+				if s.inputArtifacts != _|_ for a in s.inputArtifacts {(strings.Split(a.from, ".")[0]): _}
+			}
+			_unknown: [for d, _ in _deps if _stepNames[d] == _|_ {d}]
+			if len([for j, t in parameter.steps if j < i && t.name == s.name {j}]) > 0 {
+				name: error("steps[\(i)].name \(s.name) is used by another step")
+			}
+			if len(_unknown) > 0 {
+				dependencies: error("steps[\(i)] depends on unknown steps \(strings.Join(_unknown, ", "))")
+			}
+			name:     s.name
+			template: "step-\(s.name)"
+			if len(_deps) > 0 {dependencies: [for d, _ in _deps {d}]}
+			if s.inputArtifacts != _|_ {
+				arguments: artifacts: [for a in s.inputArtifacts {
+					_from: strings.Split(a.from, ".")
+					name:  a.name
+					from:  "{{tasks.\(_from[0]).outputs.artifacts.\(_from[1])}}"
+				}]
+			}
+		}]
+	}, for s in parameter.steps {
+		name: "step-\(s.name)"
+		container: {
+			image: s.image
+			if s.command != _|_ {command: s.command}
+			if s.args != _|_ {args: s.args}
+			if s.env != _|_ {env: s.env}
+			if s.resources != _|_ {resources: s.resources}
+		}
+		if s.retries > 0 {retryStrategy: {limit: s.retries, retryPolicy: s.retryPolicy}}
+		if s.inputArtifacts != _|_ {inputs: artifacts: [for a in s.inputArtifacts {name: a.name, path: a.path}]}
+		if s.outputArtifacts != _|_ {outputs: artifacts: s.outputArtifacts}
+	}]
+}
+
+_name: [
+	if parameter.kind == "Workflow" {"\(context.name)-\(strings.SliceRunes(hex.Encode(sha256.Sum256(json.Marshal(_spec))), 0, 10))"},
+	context.name,
+][0]
+`
+
+func init() {
+	defkit.Register(BatchPipeline())
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"cuelang.org/go/cue"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("BatchPipeline Component", func() {
+	Describe("BatchPipeline()", func() {
+		It("should create a batch-pipeline component definition", func() {
+			comp := components.BatchPipeline()
+			Expect(comp.GetName()).To(Equal("batch-pipeline"))
+			Expect(comp.GetDescription()).To(ContainSubstring("Argo Workflows"))
+		})
+
+		It("should have pipeline and step parameters", func() {
+			comp := components.BatchPipeline()
+			for _, name := range []string{"kind", "steps", "serviceAccountName", "parallelism", "activeDeadlineSeconds", "podGC", "artifactRepositoryRef"} {
+				Expect(comp).To(HaveParamNamed(name))
+			}
+		})
+	})
+
+	Describe("BatchPipeline rendering", func() {
+		ctx := `{name: "etl", namespace: "data"}`
+
+		steps := `[
+	{name: "extract", image: "alpine:3.20", command: ["sh", "-c"], args: ["fetch > /out/raw.csv"], outputArtifacts: [{name: "raw", path: "/out/raw.csv"}]},
+	{name: "validate", image: "alpine:3.20", dependencies: ["extract"]},
+	{name: "transform", image: "python:3.12", retries: 3, dependencies: ["validate"], inputArtifacts: [{name: "input", path: "/in/raw.csv", from: "extract.raw"}]},
+]`
+
+		It("should render a DAG running the template of each step", func() {
+			v := renderOutput(components.BatchPipeline(), ctx, `{steps: `+steps+`}`)
+			Expect(lookup(v, "kind")).To(Equal("Workflow"))
+			Expect(lookup(v, "metadata.name")).To(MatchRegexp(`^etl-[0-9a-f]{10}$`))
+			Expect(lookup(v, "spec.entrypoint")).To(Equal("main"))
+			Expect(lookup(v, "spec.templates[0].name")).To(Equal("main"))
+			Expect(lookup(v, "spec.templates[0].dag.tasks[0].template")).To(Equal("step-extract"))
+			Expect(v.LookupPath(cue.ParsePath("spec.templates[0].dag.tasks[0].dependencies")).Exists()).To(BeFalse())
+			Expect(lookup(v, "spec.templates[3].name")).To(Equal("step-transform"))
+			Expect(lookup(v, "spec.templates[3].container.image")).To(Equal("python:3.12"))
+			Expect(lookup(v, "spec.templates[1].container.args[0]")).To(Equal("fetch > /out/raw.csv"))
+		})
+
+		It("should pass artifacts between steps and depend on the steps producing them", func() {
+			v := renderOutput(components.BatchPipeline(), ctx, `{steps: `+steps+`}`)
+			Expect(lookup(v, "spec.templates[1].outputs.artifacts[0].path")).To(Equal("/out/raw.csv"))
+			task := "spec.templates[0].dag.tasks[2]"
+			Expect(lookup(v, task+".dependencies[0]")).To(Equal("validate"))
+			Expect(lookup(v, task+".dependencies[1]")).To(Equal("extract"))
+			Expect(lookup(v, task+".arguments.artifacts[0].from")).To(Equal("{{tasks.extract.outputs.artifacts.raw}}"))
+			Expect(lookup(v, "spec.templates[3].inputs.artifacts[0].path")).To(Equal("/in/raw.csv"))
+		})
+
+		It("should reject repeated step names", func() {
+			err := renderTemplate(components.BatchPipeline(), ctx, `{steps: [{name: "extract", image: "alpine:3.20"}, {name: "extract", image: "alpine:3.21"}]}`).Validate(cue.Concrete(true))
+			Expect(err).To(MatchError(ContainSubstring("steps[1].name extract is used by another step")))
+		})
+
+		It("should reject dependencies and artifacts of unknown steps", func() {
+			err := renderTemplate(components.BatchPipeline(), ctx, `{steps: [{name: "extract", image: "alpine:3.20", dependencies: ["fetch"]}]}`).Validate(cue.Concrete(true))
+			Expect(err).To(MatchError(ContainSubstring("steps[0] depends on unknown steps fetch")))
+
+			err = renderTemplate(components.BatchPipeline(), ctx, `{steps: [{name: "extract", image: "alpine:3.20"}, {name: "load", image: "alpine:3.20", inputArtifacts: [{name: "input", path: "/in", from: "transform.out"}]}]}`).Validate(cue.Concrete(true))
+			Expect(err).To(MatchError(ContainSubstring("steps[1] depends on unknown steps transform")))
+		})
+
+		It("should retry the steps with retries", func() {
+			v := renderOutput(components.BatchPipeline(), ctx, `{steps: `+steps+`}`)
+			limit, err := v.LookupPath(cue.ParsePath("spec.templates[3].retryStrategy.limit")).Int64()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(limit).To(Equal(int64(3)))
+			Expect(lookup(v, "spec.templates[3].retryStrategy.retryPolicy")).To(Equal("OnFailure"))
+			Expect(v.LookupPath(cue.ParsePath("spec.templates[1].retryStrategy")).Exists()).To(BeFalse())
+		})
+
+		It("should name a Workflow after its spec, so that a changed pipeline runs again", func() {
+			name := lookup(renderOutput(components.BatchPipeline(), ctx, `{steps: `+steps+`}`), "metadata.name")
+			Expect(lookup(renderOutput(components.BatchPipeline(), ctx, `{steps: `+steps+`}`), "metadata.name")).To(Equal(name))
+			Expect(lookup(renderOutput(components.BatchPipeline(), ctx, `{steps: `+steps+`, parallelism: 2}`), "metadata.name")).NotTo(Equal(name))
+			Expect(lookup(renderOutput(components.BatchPipeline(), ctx, `{steps: [{name: "extract", image: "alpine:3.21"}]}`), "metadata.name")).NotTo(Equal(name))
+		})
+
+		It("should render a WorkflowTemplate with the pipeline options", func() {
+			v := renderOutput(components.BatchPipeline(), ctx, `{kind: "WorkflowTemplate", steps: `+steps+`, serviceAccountName: "etl", podGC: "OnPodSuccess", artifactRepositoryRef: {key: "s3"}}`)
+			Expect(lookup(v, "kind")).To(Equal("WorkflowTemplate"))
+			Expect(lookup(v, "metadata.name")).To(Equal("etl"))
+			Expect(lookup(v, "spec.serviceAccountName")).To(Equal("etl"))
+			Expect(lookup(v, "spec.podGC.strategy")).To(Equal("OnPodSuccess"))
+			Expect(lookup(v, "spec.artifactRepositoryRef.configMap")).To(Equal("artifact-repositories"))
+			Expect(lookup(v, "spec.artifactRepositoryRef.key")).To(Equal("s3"))
+			Expect(v.LookupPath(cue.ParsePath("spec.ttlStrategy")).Exists()).To(BeFalse())
+		})
+
+		It("should map the phase of the Workflow to health", func() {
+			running := `{kind: "Workflow", status: {phase: "Running", progress: "1/3"}}`
+			Expect(evalStatus(components.BatchPipeline(), "customStatus", "message", "context: output: "+running).String()).To(Equal("Phase:Running, progress:1/3"))
+			Expect(evalStatus(components.BatchPipeline(), "healthPolicy", "isHealth", "context: output: "+running).Bool()).To(BeFalse())
+
+			Expect(evalStatus(components.BatchPipeline(), "customStatus", "message", "context: output: "+`{kind: "Workflow"}`).String()).To(Equal("Phase:Pending, progress:-"))
+
+			succeeded := `{kind: "Workflow", status: {phase: "Succeeded", progress: "3/3"}}`
+			Expect(evalStatus(components.BatchPipeline(), "healthPolicy", "isHealth", "context: output: "+succeeded).Bool()).To(BeTrue())
+
+			failed := `{kind: "Workflow", status: {phase: "Failed", progress: "1/3", message: "child 'transform' failed"}}`
+			Expect(evalStatus(components.BatchPipeline(), "customStatus", "message", "context: output: "+failed).String()).To(Equal("Phase:Failed, progress:1/3, message:child 'transform' failed"))
+			Expect(evalStatus(components.BatchPipeline(), "healthPolicy", "isHealth", "context: output: "+failed).Bool()).To(BeFalse())
+
+			template := `{kind: "WorkflowTemplate", spec: templates: [{name: "main"}, {name: "step-a"}, {name: "step-b"}]}`
+			Expect(evalStatus(components.BatchPipeline(), "customStatus", "message", "context: output: "+template).String()).To(Equal("Steps:2"))
+			Expect(evalStatus(components.BatchPipeline(), "healthPolicy", "isHealth", "context: output: "+template).Bool()).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// ConfigBundle creates the config-bundle component definition.
+// It describes ConfigMaps and Secrets rendered from the data of the
+// parameters. Each one is named after a hash of its content, so that a change
+// creates a new object that can be immutable, and the pods mounting it roll
+// out. The workload is a ConfigMap named after the component, which maps each
+// ConfigMap or Secret to its generated name; the objects themselves are the
+// outputs configmap-<name> and secret-<name>.
+//
+// Values are rendered into strings: strings as they are, numbers and booleans
+// formatted, and maps and lists encoded as YAML under .yaml and .yml keys and
+// as JSON under the others. The {{appName}}, {{appRevision}}, {{name}},
+// {{namespace}} and {{revision}} placeholders are then replaced from context.
+func ConfigBundle() *defkit.ComponentDefinition {
+	data := defkit.Object("data").
+		Optional().
+		WithSchema(`{[string]: string | number | bool | {...} | [...]}`).
+		Description("Data by key, such as a file name. Maps and lists are encoded as YAML under .yaml and .yml keys and as JSON under the others")
+	name := defkit.String("name").
+		Pattern(`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`).
+		Description("Name of the object, suffixed with a hash of its content")
+	immutable := defkit.Bool("immutable").
+		Default(false).
+		Description("Forbid changes to the object, a change of the data creates a new object instead")
+
+	configMaps := defkit.Array("configMaps").
+		Optional().
+		Description("ConfigMaps to render").
+		WithFields(name, data, immutable)
+	secrets := defkit.Array("secrets").
+		Optional().
+		Description("Secrets to render").
+		WithFields(name, data, immutable,
+			defkit.String("type").Default("Opaque").Description("Type of the Secret, e.g. kubernetes.io/tls"),
+		)
+
+	return WithRawTemplateBlocks(defkit.NewComponent("config-bundle").
+		Description("Describes ConfigMaps and Secrets rendered from structured data, named after a hash of their content.").
+		Workload("v1", "ConfigMap").
+		WithImports("crypto/sha256", "encoding/hex", "encoding/json", "encoding/yaml", "strings").
+		CustomStatus(`_configMaps: *0 | int
+if parameter["configMaps"] != _|_ {
+	_configMaps: len(parameter.configMaps)
+}
+_secrets: *0 | int
+if parameter["secrets"] != _|_ {
+	_secrets: len(parameter.secrets)
+}
+message: "ConfigMaps:\(_configMaps), Secrets:\(_secrets)"`).
+		Params(configMaps, secrets).
+		Template(configBundleTemplate))
+}
+
+// configBundleTemplate defines the template function for config-bundle.
+func configBundleTemplate(tpl *defkit.Template) {
+	vela := defkit.VelaCtx()
+
+	// The outputs are keyed by the names given in the parameter, which takes
+	// a comprehension over the bundle.
+	tpl.SetRawHeaderBlock(configBundleContent)
+	tpl.SetRawOutputsBlock(`outputs: {
+	for c in _bundle.configMaps {"configmap-\(c.key)": c.object}
+	for s in _bundle.secrets {"secret-\(s.key)": s.object}
+}`)
+
+	index := defkit.NewResource("v1", "ConfigMap").
+		Set("metadata.name", vela.Name()).
+		Set("data", defkit.Reference(`{for c in _bundle.configMaps {"configmap.\(c.key)": c.generatedName}, for s in _bundle.secrets {"secret.\(s.key)": s.generatedName}}`))
+
+	tpl.Output(index)
+}
+
+// configBundleContent renders the data of each object and names it after the
+// hash of its content. A name used twice in configMaps or in secrets is
+// reported on the key of the object.
+const configBundleContent = `_vars: {
+	appName:     *"" | string
+	appRevision: *"" | string
+	name:        *"" | string
+	namespace:   *"" | string
+	revision:    *"" | string
+	for k in ["appName", "appRevision", "name", "namespace", "revision"] if context[k] != _|_ {
+		(k): "\(context[k])"
+	}
+}
+
+_render: {
+	key:   string
+	value: _
+	_encoded: [
+		if (value & string) != _|_ {value},
+		if (value & number) != _|_ || (value & bool) != _|_ {"\(value)"},
+		if strings.HasSuffix(key, ".yaml") || strings.HasSuffix(key, ".yml") {yaml.Marshal(value)},
+		json.Marshal(value),
+	][0]
+	out: strings.Replace(strings.Replace(strings.Replace(strings.Replace(strings.Replace(_encoded,
+		"{{appName}}", _vars.appName, -1),
+		"{{appRevision}}", _vars.appRevision, -1),
+		"{{name}}", _vars.name, -1),
+		"{{namespace}}", _vars.namespace, -1),
+		"{{revision}}", _vars.revision, -1)
+}
+
+_bundle: {
+	configMaps: [if parameter["configMaps"] != _|_ for i, c in parameter.configMaps {
+		if len([for j, d in parameter.configMaps if j < i && d.name == c.name {j}]) > 0 {
+			key: error("configMaps[\(i)].name \(c.name) is used by another ConfigMap")
+		}
+		key: c.name
+		_data: {if c.data != _|_ for k, v in c.data {(k): (_render & {key: k, value: v}).out}}
+		_hash: hex.Encode(sha256.Sum256(json.Marshal({data: _data, immutable: c.immutable})))
+		generatedName: "\(c.name)-\(strings.SliceRunes(_hash, 0, 10))"
+		object: {
+			apiVersion: "v1"
+			kind:       "ConfigMap"
+			metadata: name: generatedName
+			data: _data
+			if c.immutable {immutable: true}
+		}
+	}]
+	secrets: [if parameter["secrets"] != _|_ for i, s in parameter.secrets {
+		if len([for j, d in parameter.secrets if j < i && d.name == s.name {j}]) > 0 {
+			key: error("secrets[\(i)].name \(s.name) is used by another Secret")
+		}
+		key: s.name
+		_data: {if s.data != _|_ for k, v in s.data {(k): (_render & {key: k, value: v}).out}}
+		_hash: hex.Encode(sha256.Sum256(json.Marshal({data: _data, immutable: s.immutable, type: s.type})))
+		generatedName: "\(s.name)-\(strings.SliceRunes(_hash, 0, 10))"
+		object: {
+			apiVersion: "v1"
+			kind:       "Secret"
+			metadata: name: generatedName
+			type:       s.type
+			stringData: _data
+			if s.immutable {immutable: true}
+		}
+	}]
+}
+`
+
+func init() {
+	defkit.Register(ConfigBundle())
+}
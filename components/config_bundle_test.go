@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"cuelang.org/go/cue"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("ConfigBundle Component", func() {
+	Describe("ConfigBundle()", func() {
+		It("should create a config-bundle component definition", func() {
+			comp := components.ConfigBundle()
+			Expect(comp.GetName()).To(Equal("config-bundle"))
+			Expect(comp.GetDescription()).To(ContainSubstring("ConfigMaps and Secrets"))
+		})
+
+		It("should have v1 ConfigMap workload", func() {
+			workload := components.ConfigBundle().GetWorkload()
+			Expect(workload.APIVersion()).To(Equal("v1"))
+			Expect(workload.Kind()).To(Equal("ConfigMap"))
+		})
+
+		It("should have configMaps and secrets parameters", func() {
+			comp := components.ConfigBundle()
+			Expect(comp).To(HaveParamNamed("configMaps"))
+			Expect(comp).To(HaveParamNamed("secrets"))
+		})
+	})
+
+	Describe("ConfigBundle rendering", func() {
+		ctx := `{name: "settings", appName: "shop", appRevision: "shop-v3", namespace: "prod", revision: "settings-v2"}`
+
+		It("should render structured, file-like and scalar values", func() {
+			v := render(components.ConfigBundle(), ctx, `{configMaps: [{name: "app", data: {
+	"app.yaml": {server: port: 8080, features: ["a", "b"]}
+	"app.json": {debug: true}
+	"nginx.conf": "worker_processes 1;\n"
+	"replicas": 3
+	"debug": false
+}}]}`)
+			Expect(lookup(v, `outputs."configmap-app".data."app.yaml"`)).To(Equal("server:\n  port: 8080\nfeatures:\n  - a\n  - b\n"))
+			Expect(lookup(v, `outputs."configmap-app".data."app.json"`)).To(Equal(`{"debug":true}`))
+			Expect(lookup(v, `outputs."configmap-app".data."nginx.conf"`)).To(Equal("worker_processes 1;\n"))
+			Expect(lookup(v, `outputs."configmap-app".data.replicas`)).To(Equal("3"))
+			Expect(lookup(v, `outputs."configmap-app".data.debug`)).To(Equal("false"))
+		})
+
+		It("should replace the context placeholders", func() {
+			v := render(components.ConfigBundle(), ctx, `{configMaps: [{name: "app", data: {
+	"APP": "{{appName}}/{{name}} in {{namespace}}"
+	"config.json": {release: "{{appRevision}}", component: "{{revision}}"}
+}}]}`)
+			Expect(lookup(v, `outputs."configmap-app".data.APP`)).To(Equal("shop/settings in prod"))
+			Expect(lookup(v, `outputs."configmap-app".data."config.json"`)).To(Equal(`{"release":"shop-v3","component":"settings-v2"}`))
+		})
+
+		It("should name the objects after their content and list the names in the workload", func() {
+			v := render(components.ConfigBundle(), ctx, `{configMaps: [{name: "app", data: {LOG: "debug"}}], secrets: [{name: "creds", data: {password: "s3cret"}, immutable: true}]}`)
+			configMap := lookup(v, `outputs."configmap-app".metadata.name`)
+			Expect(configMap).To(MatchRegexp(`^app-[0-9a-f]{10}$`))
+			secret := lookup(v, `outputs."secret-creds".metadata.name`)
+			Expect(secret).To(MatchRegexp(`^creds-[0-9a-f]{10}$`))
+
+			Expect(lookup(v, "output.metadata.name")).To(Equal("settings"))
+			Expect(lookup(v, `output.data."configmap.app"`)).To(Equal(configMap))
+			Expect(lookup(v, `output.data."secret.creds"`)).To(Equal(secret))
+
+			Expect(lookup(v, `outputs."secret-creds".type`)).To(Equal("Opaque"))
+			Expect(lookup(v, `outputs."secret-creds".stringData.password`)).To(Equal("s3cret"))
+			immutable, _ := v.LookupPath(cue.ParsePath(`outputs."secret-creds".immutable`)).Bool()
+			Expect(immutable).To(BeTrue())
+			Expect(v.LookupPath(cue.ParsePath(`outputs."configmap-app".immutable`)).Exists()).To(BeFalse())
+
+			Expect(lookup(render(components.ConfigBundle(), ctx, `{configMaps: [{name: "app", data: {LOG: "debug"}}]}`), `outputs."configmap-app".metadata.name`)).To(Equal(configMap))
+			Expect(lookup(render(components.ConfigBundle(), ctx, `{configMaps: [{name: "app", data: {LOG: "info"}}]}`), `outputs."configmap-app".metadata.name`)).NotTo(Equal(configMap))
+			Expect(lookup(render(components.ConfigBundle(), ctx, `{configMaps: [{name: "app", data: {LOG: "debug"}, immutable: true}]}`), `outputs."configmap-app".metadata.name`)).NotTo(Equal(configMap))
+		})
+
+		It("should reject a name used twice among the ConfigMaps or the Secrets", func() {
+			v := render(components.ConfigBundle(), ctx, `{configMaps: [{name: "app", data: {LOG: "debug"}}], secrets: [{name: "app", data: {password: "s3cret"}}]}`)
+			Expect(lookup(v, `output.data."configmap.app"`)).To(HavePrefix("app-"))
+			Expect(lookup(v, `output.data."secret.app"`)).To(HavePrefix("app-"))
+
+			err := renderTemplate(components.ConfigBundle(), ctx, `{configMaps: [{name: "app", data: {LOG: "debug"}}, {name: "app", data: {LOG: "info"}}]}`).Validate(cue.Concrete(true))
+			Expect(err).To(MatchError(ContainSubstring("configMaps[1].name app is used by another ConfigMap")))
+
+			err = renderTemplate(components.ConfigBundle(), ctx, `{secrets: [{name: "creds"}, {name: "tls"}, {name: "creds", type: "kubernetes.io/tls"}]}`).Validate(cue.Concrete(true))
+			Expect(err).To(MatchError(ContainSubstring("secrets[2].name creds is used by another Secret")))
+		})
+	})
+})
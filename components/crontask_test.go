@@ -19,6 +19,7 @@ package components_test
 import (
 	"strings"
 
+	"cuelang.org/go/cue"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -55,6 +56,15 @@ var _ = Describe("CronTask Component", func() {
 			Expect(comp).To(HaveParamNamed("suspend"))
 			Expect(comp).To(HaveParamNamed("successfulJobsHistoryLimit"))
 			Expect(comp).To(HaveParamNamed("failedJobsHistoryLimit"))
+			Expect(comp).To(HaveParamNamed("timeZone"))
+		})
+
+		It("should have the task Job parameters", func() {
+			comp := components.CronTask()
+			Expect(comp).To(HaveParamNamed("completions"))
+			Expect(comp).To(HaveParamNamed("completionMode"))
+			Expect(comp).To(HaveParamNamed("backoffLimitPerIndex"))
+			Expect(comp).To(HaveParamNamed("podFailurePolicy"))
 		})
 
 		It("should execute template and produce CronJob output", func() {
@@ -89,35 +99,6 @@ var _ = Describe("CronTask Component", func() {
 		})
 	})
 
-	Describe("CronTaskHealthProbeParam()", func() {
-		It("should not have host and scheme fields in httpGet", func() {
-			probe := components.CronTaskHealthProbeParam()
-			Expect(probe).NotTo(BeNil())
-
-			// Get the httpGet field
-			var httpGetFields []defkit.Param
-			for _, f := range probe.GetFields() {
-				if f.Name() == "httpGet" {
-					if mp, ok := f.(*defkit.MapParam); ok {
-						httpGetFields = mp.GetFields()
-					}
-				}
-			}
-			Expect(httpGetFields).NotTo(BeEmpty())
-
-			// Check that path and port exist
-			fieldNames := make([]string, 0, len(httpGetFields))
-			for _, f := range httpGetFields {
-				fieldNames = append(fieldNames, f.Name())
-			}
-			Expect(fieldNames).To(ContainElement("path"))
-			Expect(fieldNames).To(ContainElement("port"))
-			// host and scheme must NOT be present
-			Expect(fieldNames).NotTo(ContainElement("host"))
-			Expect(fieldNames).NotTo(ContainElement("scheme"))
-		})
-	})
-
 	Describe("CronTask CUE generation", func() {
 		var gen *defkit.CUEGenerator
 
@@ -155,14 +136,12 @@ var _ = Describe("CronTask Component", func() {
 			Expect(cue).To(ContainSubstring("requests:"))
 		})
 
-		It("should generate explicit hostAliases mapping", func() {
+		It("should generate hostAliases passthrough patched by ip", func() {
 			comp := components.CronTask()
 			cue := gen.GenerateFullDefinition(comp)
 
-			// Should use explicit field mapping, not passthrough
-			Expect(cue).To(ContainSubstring("for v in parameter.hostAliases"))
-			Expect(cue).To(ContainSubstring("ip:"))
-			Expect(cue).To(ContainSubstring("hostnames:"))
+			Expect(cue).To(ContainSubstring("// +patchKey=ip"))
+			Expect(cue).To(ContainSubstring("hostAliases: parameter.hostAliases"))
 		})
 
 		It("should generate deprecated volumes parameter with type discriminator", func() {
@@ -196,7 +175,7 @@ var _ = Describe("CronTask Component", func() {
 
 			// New-style volumeMounts (when volumeMounts param is set)
 			Expect(cue).To(ContainSubstring(`if parameter["volumeMounts"] != _|_`))
-			Expect(cue).To(ContainSubstring("mountsArray.pvc"))
+			Expect(cue).To(ContainSubstring("volumeMounts: mountsArray"))
 
 			// Deprecated volumes fallback (when volumes is set but volumeMounts is not)
 			Expect(cue).To(ContainSubstring(`if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_`))
@@ -219,30 +198,75 @@ var _ = Describe("CronTask Component", func() {
 
 			// resources should appear inside if blocks, not unconditionally
 			// Find the cpu condition block and verify it contains resources
-			cpuIdx := strings.Index(cue, `if parameter["cpu"] != _|_ {`)
+			cpuIdx := strings.Index(cue, `if parameter["cpu"] != _|_ &&`)
 			Expect(cpuIdx).To(BeNumerically(">", 0))
 			// After the cpu condition, resources should appear
 			afterCpu := cue[cpuIdx : cpuIdx+200]
 			Expect(afterCpu).To(ContainSubstring("resources:"))
 
 			// Same for memory
-			memIdx := strings.Index(cue, `if parameter["memory"] != _|_ {`)
+			memIdx := strings.Index(cue, `if parameter["memory"] != _|_ &&`)
 			Expect(memIdx).To(BeNumerically(">", 0))
 			afterMem := cue[memIdx : memIdx+200]
 			Expect(afterMem).To(ContainSubstring("resources:"))
 		})
 
-		It("should not have host or scheme in HealthProbe httpGet", func() {
+		It("should share host and scheme in HealthProbe httpGet", func() {
 			comp := components.CronTask()
 			cue := gen.GenerateFullDefinition(comp)
 
-			// The HealthProbe helper should have httpGet with path, port, httpHeaders
-			// but NOT host or scheme
+			// The HealthProbe helper is the one shared by all pod-based components
 			Expect(cue).To(ContainSubstring("httpGet?:"))
 			Expect(cue).To(ContainSubstring("path: string"))
 			Expect(cue).To(ContainSubstring("port: int"))
-			Expect(cue).NotTo(ContainSubstring("host?: string"))
-			Expect(cue).NotTo(ContainSubstring(`scheme:`))
+			Expect(cue).To(ContainSubstring("host?: string"))
+			Expect(cue).To(ContainSubstring(`scheme?: *"HTTP" | string`))
+		})
+
+		It("should generate timeZone as an IANA name", func() {
+			comp := components.CronTask()
+			cue := gen.GenerateFullDefinition(comp)
+			Expect(cue).To(ContainSubstring("timeZone?: string"))
+			Expect(cue).To(ContainSubstring("parameter.timeZone,"))
+		})
+
+		It("should generate the Job parameters into the job template", func() {
+			comp := components.CronTask()
+			cue := gen.GenerateFullDefinition(comp)
+			Expect(cue).To(ContainSubstring("completionMode: parameter.completionMode"))
+			Expect(cue).To(ContainSubstring("completions: parameter.completions"))
+			Expect(cue).To(ContainSubstring("podFailurePolicy: parameter.podFailurePolicy"))
+			Expect(cue).To(ContainSubstring(`if parameter["backoffLimitPerIndex"] == _|_ {`))
+		})
+
+		It("should generate customStatus with last schedule and last job result", func() {
+			comp := components.CronTask()
+			cue := gen.GenerateFullDefinition(comp)
+			Expect(cue).To(ContainSubstring("lastScheduleTime: context.output.status.lastScheduleTime"))
+			Expect(cue).To(ContainSubstring("lastSuccessfulTime: context.output.status.lastSuccessfulTime"))
+			Expect(cue).To(ContainSubstring("_active: len(context.output.status.active)"))
+			Expect(cue).To(ContainSubstring(`status.lastSuccessfulTime >= status.lastScheduleTime`))
+			Expect(cue).To(ContainSubstring(`message: "Last schedule:\(_schedule), last job:\(_lastJob)"`))
+		})
+	})
+
+	Describe("CronTask rendering", func() {
+		ctx := `{name: "report", appName: "shop", namespace: "prod", clusterVersion: minor: 30}`
+
+		It("should render the time zone of the schedule", func() {
+			v := renderOutput(components.CronTask(), ctx, `{image: "report:1", schedule: "0 8 * * *", timeZone: "Europe/Berlin"}`)
+			Expect(lookup(v, "spec.timeZone")).To(Equal("Europe/Berlin"))
+		})
+
+		It("should reject Local and prefixed time zones", func() {
+			for timeZone, msg := range map[string]string{
+				"Local":                 "timeZone Local is not supported",
+				"TZ=Europe/Berlin":      "without a TZ= or CRON_TZ= prefix",
+				"CRON_TZ=Europe/Berlin": "without a TZ= or CRON_TZ= prefix",
+			} {
+				err := renderTemplate(components.CronTask(), ctx, `{image: "report:1", schedule: "0 8 * * *", timeZone: "`+timeZone+`"}`).Validate(cue.Concrete(true))
+				Expect(err).To(MatchError(ContainSubstring(msg)), timeZone)
+			}
 		})
 	})
 })
@@ -22,23 +22,16 @@ import (
 
 // Daemon creates a daemon component definition.
 // It describes a DaemonSet which runs on every node in the cluster.
+//
+// Node agents can share the network and PID namespaces of the node, run
+// privileged and tolerate the taints of every node. In kube-system the pods
+// default to the system-node-critical PriorityClass, which is only allowed
+// there by default.
 func Daemon() *defkit.ComponentDefinition {
 	// Use StringKeyMap for labels and annotations (generates [string]: string)
 	labels := defkit.StringKeyMap("labels").Optional().Description("Specify the labels in the workload")
 	annotations := defkit.StringKeyMap("annotations").Optional().Description("Specify the annotations in the workload")
 
-	image := defkit.String("image").Description("Which image would you like to use for your service").Short("i")
-
-	// Use Enum for imagePullPolicy to generate proper CUE enum type
-	imagePullPolicy := defkit.Enum("imagePullPolicy").
-		Optional().
-		Values("Always", "Never", "IfNotPresent").
-		Description("Specify image pull policy for your service")
-
-	imagePullSecrets := defkit.StringList("imagePullSecrets").
-		Optional().
-		Description("Specify image pull secrets for your service")
-
 	// Structured ports array matching original CUE
 	ports := defkit.Array("ports").
 		Optional().
@@ -68,150 +61,56 @@ func Daemon() *defkit.ComponentDefinition {
 		Ignore().
 		Description("If addRevisionLabel is true, the revision label will be added to the underlying pods")
 
-	cmd := defkit.StringList("cmd").Optional().Description("Commands to run in the container")
-
-	// Structured env array with detailed valueFrom schema
-	env := defkit.List("env").
-		Optional().
-		Description("Define arguments by using environment variables").
-		WithFields(
-			defkit.String("name").Description("Environment variable name"),
-			defkit.String("value").Optional().Description("The value of the environment variable"),
-			defkit.Object("valueFrom").Optional().Description("Specifies a source the value of this var should come from").
-				WithFields(
-					defkit.Object("secretKeyRef").Optional().Description("Selects a key of a secret in the pod's namespace").
-						WithFields(
-							defkit.String("name").Description("The name of the secret in the pod's namespace to select from"),
-							defkit.String("key").Description("The key of the secret to select from. Must be a valid secret key"),
-						),
-					defkit.Object("configMapKeyRef").Optional().Description("Selects a key of a config map in the pod's namespace").
-						WithFields(
-							defkit.String("name").Description("The name of the config map in the pod's namespace to select from"),
-							defkit.String("key").Description("The key of the config map to select from. Must be a valid secret key"),
-						),
-				),
-		)
-
-	cpu := defkit.String("cpu").Optional().Description("Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)")
-	memory := defkit.String("memory").Optional().Description("Specifies the attributes of the memory resource required for the container.")
-
-	// VolumeMounts with detailed schemas using fluent API
-	volumeMounts := defkit.Object("volumeMounts").
+	updateStrategy := defkit.Object("updateStrategy").
 		Optional().
+		Description("Specify how the pods are replaced on updates").
 		WithFields(
-			defkit.List("pvc").Optional().Description("Mount PVC type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.String("claimName").Description("The name of the PVC"),
-			),
-			defkit.List("configMap").Optional().Description("Mount ConfigMap type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.Int("defaultMode").Default(420),
-				defkit.String("cmName"),
-				defkit.List("items").Optional().WithFields(
-					defkit.String("key"),
-					defkit.String("path"),
-					defkit.Int("mode").Default(511),
-				),
-			),
-			defkit.List("secret").Optional().Description("Mount Secret type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.Int("defaultMode").Default(420),
-				defkit.String("secretName"),
-				defkit.List("items").Optional().WithFields(
-					defkit.String("key"),
-					defkit.String("path"),
-					defkit.Int("mode").Default(511),
-				),
-			),
-			defkit.List("emptyDir").Optional().Description("Mount EmptyDir type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.Enum("medium").Values("", "Memory").Default(""),
-			),
-			defkit.List("hostPath").Optional().Description("Mount HostPath type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.Enum("mountPropagation").Optional().Values("None", "HostToContainer", "Bidirectional"),
-				defkit.String("path"),
-				defkit.Bool("readOnly").Optional(),
+			defkit.Enum("type").
+				Values("RollingUpdate", "OnDelete").
+				Default("RollingUpdate").
+				Description(`"RollingUpdate" replaces the pods node by node, "OnDelete" only replaces the pods deleted by hand`),
+			defkit.Object("rollingUpdate").Optional().Description("Tune the rolling update, only valid with type RollingUpdate").WithFields(
+				defkit.Map("maxUnavailable").Optional().WithSchema("int | string").Description("Number or percentage of nodes whose pod is unavailable during the update, e.g. `10%`"),
+				defkit.Map("maxSurge").Optional().WithSchema("int | string").Description("Number or percentage of nodes running the new pod next to the old one during the update, e.g. `1`"),
 			),
 		)
 
-	// Deprecated volumes parameter - discriminated union with type-based conditional fields
-	volumes := defkit.List("volumes").Optional().Description("Deprecated field, use volumeMounts instead.").
-		WithFields(
-			defkit.String("name"),
-			defkit.String("mountPath"),
-			defkit.OneOf("type").
-				Description("Specify volume type, options: \"pvc\",\"configMap\",\"secret\",\"emptyDir\", default to emptyDir").
-				Default("emptyDir").
-				Variants(
-					defkit.Variant("pvc").WithFields(
-						defkit.Field("claimName", defkit.ParamTypeString),
-					),
-					defkit.Variant("configMap").WithFields(
-						defkit.Field("defaultMode", defkit.ParamTypeInt).Default(420),
-						defkit.Field("cmName", defkit.ParamTypeString),
-						defkit.Field("items", defkit.ParamTypeArray).Optional().Nested(
-							defkit.Struct("").WithFields(
-								defkit.Field("key", defkit.ParamTypeString),
-								defkit.Field("path", defkit.ParamTypeString),
-								defkit.Field("mode", defkit.ParamTypeInt).Default(511),
-							),
-						),
-					),
-					defkit.Variant("secret").WithFields(
-						defkit.Field("defaultMode", defkit.ParamTypeInt).Default(420),
-						defkit.Field("secretName", defkit.ParamTypeString),
-						defkit.Field("items", defkit.ParamTypeArray).Optional().Nested(
-							defkit.Struct("").WithFields(
-								defkit.Field("key", defkit.ParamTypeString),
-								defkit.Field("path", defkit.ParamTypeString),
-								defkit.Field("mode", defkit.ParamTypeInt).Default(511),
-							),
-						),
-					),
-					defkit.Variant("emptyDir").WithFields(
-						defkit.Field("medium", defkit.ParamTypeString).Default("").Values("", "Memory"),
-					),
-				),
-		)
+	hostNetwork := defkit.Bool("hostNetwork").
+		Default(false).
+		Description("Use the network namespace of the node, e.g. for agents listening on node ports")
 
-	// Health probes referencing the helper definition
-	livenessProbe := defkit.Object("livenessProbe").
-		Optional().
-		Description("Instructions for assessing whether the container is alive.").
-		WithSchemaRef("HealthProbe")
-	readinessProbe := defkit.Object("readinessProbe").
+	hostPID := defkit.Bool("hostPID").
+		Default(false).
+		Description("Use the PID namespace of the node, e.g. for agents monitoring node processes")
+
+	dnsPolicy := defkit.Enum("dnsPolicy").
 		Optional().
-		Description("Instructions for assessing whether the container is in a suitable state to serve traffic.").
-		WithSchemaRef("HealthProbe")
+		Values("ClusterFirst", "ClusterFirstWithHostNet", "Default", "None").
+		Description(`Specify the DNS policy of the pod, defaults to "ClusterFirstWithHostNet" with hostNetwork so that cluster names still resolve`)
+
+	privileged := defkit.Bool("privileged").
+		Default(false).
+		Description("Run the container privileged, with all the capabilities of the node")
 
-	// Structured hostAliases with required hostnames
-	hostAliases := defkit.List("hostAliases").
+	tolerationPreset := defkit.Enum("tolerationPreset").
 		Optional().
-		Description("Specify the hostAliases to add").
-		WithFields(
-			defkit.String("ip"),
-			defkit.StringList("hostnames"),
-		)
+		Values("All", "NoSchedule", "NoExecute").
+		Description(`Tolerate the taints of every node: "All" tolerates any taint, "NoSchedule" and "NoExecute" the taints of that effect. Added to tolerations`)
 
 	return defkit.NewComponent("daemon").
 		Description("Describes daemonset services in Kubernetes.").
 		Workload("apps/v1", "DaemonSet").
-		CustomStatus(defkit.DaemonSetStatus().Build()).
-		HealthPolicy(defkit.DaemonSetHealth().Build()).
-		Params(
-			labels, annotations,
-			image, imagePullPolicy, imagePullSecrets,
-			port, ports, exposeType, addRevisionLabel,
-			cmd, env,
-			cpu, memory, volumeMounts, volumes,
-			livenessProbe, readinessProbe, hostAliases,
-		).
+		CustomStatus(DaemonSetObjectHealth().BuildStatus()).
+		HealthPolicy(DaemonSetObjectHealth().Build()).
+		Params(labels, annotations).
+		Params(PodContainerParams()...).
+		Params(port, ports, exposeType, addRevisionLabel).
+		Params(PodResourceParams()...).
+		Params(PodVolumeParams()...).
+		Params(PodProbeParams()...).
+		Params(PodSchedulingParams()...).
+		Params(PodPlacementParams()...).
+		Params(updateStrategy, hostNetwork, hostPID, dnsPolicy, privileged, tolerationPreset).
 		Helper("HealthProbe", HealthProbeParam()).
 		Template(daemonTemplate)
 }
@@ -219,24 +118,24 @@ func Daemon() *defkit.ComponentDefinition {
 // daemonTemplate defines the template function for daemon.
 func daemonTemplate(tpl *defkit.Template) {
 	vela := defkit.VelaCtx()
-	image := defkit.String("image")
 	port := defkit.Int("port")
 	ports := defkit.List("ports")
 	exposeType := defkit.String("exposeType")
 	addRevisionLabel := defkit.Bool("addRevisionLabel")
-	cmd := defkit.StringList("cmd")
-	env := defkit.List("env")
-	cpu := defkit.String("cpu")
-	memory := defkit.String("memory")
-	volumeMounts := defkit.Object("volumeMounts")
-	volumes := defkit.List("volumes")
-	livenessProbe := defkit.Object("livenessProbe")
-	readinessProbe := defkit.Object("readinessProbe")
-	hostAliases := defkit.List("hostAliases")
 	labels := defkit.Object("labels")
 	annotations := defkit.Object("annotations")
-	imagePullPolicy := defkit.String("imagePullPolicy")
-	imagePullSecrets := defkit.StringList("imagePullSecrets")
+	updateStrategy := defkit.Object("updateStrategy")
+	hostNetwork := defkit.Bool("hostNetwork")
+	hostPID := defkit.Bool("hostPID")
+	dnsPolicy := defkit.String("dnsPolicy")
+	privileged := defkit.Bool("privileged")
+	tolerationPreset := defkit.String("tolerationPreset")
+	priorityClassName := defkit.String("priorityClassName")
+
+	// A toleration with operator Exists and no key matches every taint, or
+	// every taint of its effect.
+	presetTolerations := `[if parameter["tolerationPreset"] != _|_ {operator: "Exists", if parameter.tolerationPreset != "All" {effect: parameter.tolerationPreset}}]`
+	pod := NewPodTemplate(tpl, "spec.template").WithTolerations(tolerationPreset.IsSet(), presetTolerations)
 
 	// Transform ports to container format using fluent collection API:
 	// {port, name, protocol, expose} -> {containerPort, name, protocol}
@@ -247,60 +146,6 @@ func daemonTemplate(tpl *defkit.Template) {
 			"name":          defkit.FieldRef("name").OrConditional(defkit.Format("port-%v", defkit.FieldRef("port"))),
 		})
 
-	// Transform imagePullSecrets: ["secret1", "secret2"] -> [{name: "secret1"}, ...]
-	pullSecrets := defkit.Each(imagePullSecrets).Wrap("name")
-
-	// Define template-level helpers for volumeMounts
-	mountsArray := tpl.Helper("mountsArray").
-		FromFields(volumeMounts, "pvc", "configMap", "secret", "emptyDir", "hostPath").
-		Pick("name", "mountPath").
-		PickIf(defkit.ItemFieldIsSet("subPath"), "subPath").
-		Build()
-
-	// volumesList: Transform volume sources to Kubernetes volume specs
-	volumesList := tpl.Helper("volumesList").
-		FromFields(volumeMounts, "pvc", "configMap", "secret", "emptyDir", "hostPath").
-		MapBySource(map[string]defkit.FieldMap{
-			"pvc": {
-				"name":                  defkit.FieldRef("name"),
-				"persistentVolumeClaim": defkit.Nested(defkit.FieldMap{"claimName": defkit.FieldRef("claimName")}),
-			},
-			"configMap": {
-				"name": defkit.FieldRef("name"),
-				"configMap": defkit.Nested(defkit.FieldMap{
-					"name":        defkit.FieldRef("cmName"),
-					"defaultMode": defkit.FieldRef("defaultMode"),
-					"items":       defkit.Optional("items"),
-				}),
-			},
-			"secret": {
-				"name": defkit.FieldRef("name"),
-				"secret": defkit.Nested(defkit.FieldMap{
-					"secretName":  defkit.FieldRef("secretName"),
-					"defaultMode": defkit.FieldRef("defaultMode"),
-					"items":       defkit.Optional("items"),
-				}),
-			},
-			"emptyDir": {
-				"name":     defkit.FieldRef("name"),
-				"emptyDir": defkit.Nested(defkit.FieldMap{"medium": defkit.FieldRef("medium")}),
-			},
-			"hostPath": {
-				"name":     defkit.FieldRef("name"),
-				"hostPath": defkit.Nested(defkit.FieldMap{"path": defkit.FieldRef("path")}),
-			},
-		}).
-		Build()
-
-	// deDupVolumesArray: Deduplicated volumes by name
-	deDupVolumesArray := tpl.Helper("deDupVolumesArray").
-		FromHelper(volumesList).
-		Dedupe("name").
-		Build()
-
-	// Suppress unused variable warnings
-	_ = volumesList
-
 	// Primary output: DaemonSet
 	daemonset := defkit.NewResource("apps/v1", "DaemonSet").
 		Set("spec.selector.matchLabels[app.oam.dev/component]", vela.Name()).
@@ -311,73 +156,24 @@ func daemonTemplate(tpl *defkit.Template) {
 		SetIf(addRevisionLabel.IsTrue(), "spec.template.metadata.labels[app.oam.dev/revision]", vela.Revision()).
 		// SpreadIf spreads user labels inside the labels block
 		SpreadIf(labels.IsSet(), "spec.template.metadata.labels", labels).
-		SetIf(annotations.IsSet(), "spec.template.metadata.annotations", annotations).
-		// Container spec
-		Set("spec.template.spec.containers[0].name", vela.Name()).
-		Set("spec.template.spec.containers[0].image", image).
+		SetIf(annotations.IsSet(), "spec.template.metadata.annotations", annotations)
+	pod.Apply(daemonset).
 		// Deprecated port fallback (before modern ports)
 		If(defkit.And(port.IsSet(), ports.NotSet())).
-		Set("spec.template.spec.containers[0].ports", defkit.InlineArray(map[string]defkit.Value{
+		Set(pod.Container("ports"), defkit.InlineArray(map[string]defkit.Value{
 			"containerPort": port,
 		})).
 		EndIf().
-		SetIf(ports.IsSet(), "spec.template.spec.containers[0].ports", containerPorts).
-		SetIf(imagePullPolicy.IsSet(), "spec.template.spec.containers[0].imagePullPolicy", imagePullPolicy).
-		SetIf(cmd.IsSet(), "spec.template.spec.containers[0].command", cmd).
-		SetIf(env.IsSet(), "spec.template.spec.containers[0].env", env).
-		SetIf(defkit.PathExists(`context["config"]`), "spec.template.spec.containers[0].env", defkit.Reference("context.config")).
-		SetIf(cpu.IsSet(), "spec.template.spec.containers[0].resources.limits.cpu", cpu).
-		SetIf(cpu.IsSet(), "spec.template.spec.containers[0].resources.requests.cpu", cpu).
-		SetIf(memory.IsSet(), "spec.template.spec.containers[0].resources.limits.memory", memory).
-		SetIf(memory.IsSet(), "spec.template.spec.containers[0].resources.requests.memory", memory).
-		// Deprecated volumes fallback - container volumeMounts
-		If(defkit.And(volumes.IsSet(), volumeMounts.NotSet())).
-		Set("spec.template.spec.containers[0].volumeMounts",
-			defkit.Each(volumes).Map(defkit.FieldMap{
-				"mountPath": defkit.FieldRef("mountPath"),
-				"name":      defkit.FieldRef("name"),
-			})).
-		EndIf().
-		SetIf(volumeMounts.IsSet(), "spec.template.spec.containers[0].volumeMounts", mountsArray).
-		SetIf(livenessProbe.IsSet(), "spec.template.spec.containers[0].livenessProbe", livenessProbe).
-		SetIf(readinessProbe.IsSet(), "spec.template.spec.containers[0].readinessProbe", readinessProbe).
-		// Pod spec
-		SetIf(hostAliases.IsSet(), "spec.template.spec.hostAliases", hostAliases).
-		Directive("spec.template.spec.hostAliases", "patchKey=ip").
-		SetIf(imagePullSecrets.IsSet(), "spec.template.spec.imagePullSecrets", pullSecrets).
-		// Deprecated volumes fallback - pod spec volumes
-		If(defkit.And(volumes.IsSet(), volumeMounts.NotSet())).
-		Set("spec.template.spec.volumes",
-			defkit.Each(volumes).
-				Map(defkit.FieldMap{
-					"name": defkit.FieldRef("name"),
-				}).
-				MapVariant("type", "pvc", defkit.FieldMap{
-					"persistentVolumeClaim": defkit.NestedFieldMap(defkit.FieldMap{
-						"claimName": defkit.FieldRef("claimName"),
-					}),
-				}).
-				MapVariant("type", "configMap", defkit.FieldMap{
-					"configMap": defkit.NestedFieldMap(defkit.FieldMap{
-						"defaultMode": defkit.FieldRef("defaultMode"),
-						"name":        defkit.FieldRef("cmName"),
-						"items":       defkit.OptionalFieldRef("items"),
-					}),
-				}).
-				MapVariant("type", "secret", defkit.FieldMap{
-					"secret": defkit.NestedFieldMap(defkit.FieldMap{
-						"defaultMode": defkit.FieldRef("defaultMode"),
-						"secretName":  defkit.FieldRef("secretName"),
-						"items":       defkit.OptionalFieldRef("items"),
-					}),
-				}).
-				MapVariant("type", "emptyDir", defkit.FieldMap{
-					"emptyDir": defkit.NestedFieldMap(defkit.FieldMap{
-						"medium": defkit.FieldRef("medium"),
-					}),
-				})).
-		EndIf().
-		SetIf(volumeMounts.IsSet(), "spec.template.spec.volumes", deDupVolumesArray)
+		SetIf(ports.IsSet(), pod.Container("ports"), containerPorts)
+	pod.ApplyPlacement(daemonset).
+		SetIf(defkit.And(priorityClassName.NotSet(), defkit.Eq(vela.Namespace(), defkit.Lit("kube-system"))),
+			pod.PodSpec("priorityClassName"), patchable(`"system-node-critical"`)).
+		SetIf(updateStrategy.IsSet(), "spec.updateStrategy", updateStrategy).
+		Set(pod.PodSpec("hostNetwork"), hostNetwork).
+		Set(pod.PodSpec("hostPID"), hostPID).
+		SetIf(dnsPolicy.IsSet(), pod.PodSpec("dnsPolicy"), dnsPolicy).
+		SetIf(defkit.And(hostNetwork.IsTrue(), dnsPolicy.NotSet()), pod.PodSpec("dnsPolicy"), defkit.Lit("ClusterFirstWithHostNet")).
+		SetIf(privileged.IsTrue(), pod.Container("securityContext.privileged"), privileged)
 
 	tpl.Output(daemonset)
 
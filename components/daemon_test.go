@@ -225,5 +225,44 @@ var _ = Describe("Daemon Component", func() {
 			lastLine := strings.TrimSpace(lines[len(lines)-1])
 			Expect(lastLine).NotTo(HavePrefix("// +usage="))
 		})
+
+		It("should have node agent parameters", func() {
+			comp := components.Daemon()
+			for _, name := range []string{"updateStrategy", "hostNetwork", "hostPID", "dnsPolicy", "privileged", "tolerationPreset", "tolerations", "priorityClassName"} {
+				Expect(comp).To(HaveParamNamed(name))
+			}
+			cue := comp.ToCue()
+			Expect(cue).To(ContainSubstring(`type: *"RollingUpdate" | "OnDelete"`))
+			Expect(cue).To(ContainSubstring("maxSurge?: int | string"))
+			Expect(cue).To(ContainSubstring(`tolerationPreset?: "All" | "NoSchedule" | "NoExecute"`))
+		})
+
+		It("should generate the host and DNS settings", func() {
+			cue := components.Daemon().ToCue()
+			Expect(cue).To(ContainSubstring("hostNetwork: parameter.hostNetwork"))
+			Expect(cue).To(ContainSubstring("hostPID: parameter.hostPID"))
+			Expect(cue).To(ContainSubstring(`if parameter.hostNetwork && parameter["dnsPolicy"] == _|_ {`))
+			Expect(cue).To(ContainSubstring(`dnsPolicy: "ClusterFirstWithHostNet"`))
+			Expect(cue).To(ContainSubstring("privileged: parameter.privileged"))
+			Expect(cue).To(ContainSubstring("updateStrategy: parameter.updateStrategy"))
+		})
+
+		It("should default to the system-node-critical PriorityClass in kube-system", func() {
+			cue := components.Daemon().ToCue()
+			Expect(cue).To(ContainSubstring(`if parameter["priorityClassName"] == _|_ && context.namespace == "kube-system" {`))
+			Expect(cue).To(ContainSubstring(`priorityClassName: *"system-node-critical" | _`))
+		})
+
+		It("should add the toleration presets to the tolerations", func() {
+			cue := components.Daemon().ToCue()
+			Expect(cue).To(ContainSubstring(`if parameter["tolerations"] != _|_ || parameter["tolerationPreset"] != _|_ {`))
+			Expect(cue).To(ContainSubstring(`tolerations: *[if parameter["tolerations"] != _|_ for t in parameter.tolerations {t}, for t in [if parameter["tolerationPreset"] != _|_ {operator: "Exists"`))
+		})
+
+		It("should check numberReady and updatedNumberScheduled against desiredNumberScheduled", func() {
+			cue := components.Daemon().ToCue()
+			Expect(cue).To(ContainSubstring("_st.desiredNumberScheduled == _st.numberReady && (_onDelete || _st.desiredNumberScheduled == _st.updatedNumberScheduled)"))
+			Expect(cue).To(ContainSubstring(`message: "Ready:\(_st.numberReady)/\(_st.desiredNumberScheduled)"`))
+		})
 	})
 })
@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// externalServiceCheck reads the result of the health check Deployment, if
+// any: pending until its pod runs the current probe, then passing while the
+// readiness probe of the pod passes and failing otherwise. The pod stays ready
+// or not ready until the next probe, so the previous result is kept while a
+// probe runs.
+const externalServiceCheck = `_check: *"" | string
+if context.outputs.healthCheck != _|_ {
+	_generation: *0 | int
+	if context.outputs.healthCheck.metadata.generation != _|_ {
+		_generation: context.outputs.healthCheck.metadata.generation
+	}
+	_probe: {
+		observedGeneration: *0 | int
+		updatedReplicas:    *0 | int
+		readyReplicas:      *0 | int
+		...
+	}
+	if context.outputs.healthCheck.status != _|_ {
+		_probe: context.outputs.healthCheck.status
+	}
+	_check: [
+		if _probe.observedGeneration < _generation || _probe.updatedReplicas == 0 {"pending"},
+		if _probe.readyReplicas > 0 {"passing"},
+		"failing",
+	][0]
+}
+`
+
+// externalServiceStatus reports the in-cluster host of the Service, what it
+// routes to, and the result of the health check.
+const externalServiceStatus = externalServiceCheck + `_target: *"no endpoints" | string
+if context.output.spec.type == "ExternalName" {
+	_target: context.output.spec.externalName
+}
+if context.outputs.endpoints != _|_ {
+	_target: "\(len(context.outputs.endpoints.endpoints)) addresses"
+}
+_suffix: *"" | string
+if _check != "" {
+	_suffix: ", check:\(_check)"
+}
+message: "Host:\(context.output.metadata.name).\(context.output.metadata.namespace).svc, target:\(_target)\(_suffix)"`
+
+// externalServicePortName is the name of the port p, shared by the Service and
+// the EndpointSlice so that the Service routes to the port of the same name.
+const externalServicePortName = `[if p.name != _|_ {p.name}, if p.protocol != "TCP" {"port-\(p.port)-\(strings.ToLower(p.protocol))"}, "port-\(p.port)"][0]`
+
+// ExternalService creates the external-service component definition.
+// It describes a dependency running outside the cluster, such as a managed
+// database or a SaaS endpoint, as a Service of the application: an
+// ExternalName Service for a DNS name, or a Service without selector and an
+// EndpointSlice for IP addresses. Workflow steps such as
+// collect-service-endpoints then discover it like an in-cluster Service.
+func ExternalService() *defkit.ComponentDefinition {
+	externalName := defkit.String("externalName").
+		Optional().
+		Description("DNS name of the external service, e.g. `mydb.abc123.eu-west-1.rds.amazonaws.com`. Takes precedence over addresses")
+	addresses := defkit.StringList("addresses").
+		Optional().
+		MinItems(1).
+		Description("IP addresses of the external service, all of the same family. Either externalName or addresses is required")
+	ports := defkit.Array("ports").
+		Description("Ports of the external service").
+		WithFields(
+			defkit.Int("port").Description("Port of the Service"),
+			defkit.String("name").Optional().Description("Name of the port, defaults to port-<port>"),
+			defkit.String("protocol").Default("TCP").Values("TCP", "UDP", "SCTP").Description("Protocol of the port"),
+			defkit.Int("targetPort").Optional().Description("Port of the addresses, defaults to port. Ignored with externalName"),
+			defkit.String("appProtocol").Optional().Description("Application protocol of the port, e.g. http, https or postgresql"),
+		)
+	healthCheck := defkit.Object("healthCheck").
+		Optional().
+		Description("Probe the external service through its Service periodically, from the readiness probe of a pod. The component is healthy while the last probe passed").
+		WithFields(
+			defkit.Enum("type").Values("tcp", "http").Default("tcp").Description("Open a TCP connection, or send an HTTP GET request expecting a successful response"),
+			defkit.Int("port").Optional().Description("Port of the Service to probe, defaults to the first port"),
+			defkit.String("path").Default("/").Description("Path of the HTTP request"),
+			defkit.Int("periodSeconds").Default(60).Min(1).Description("Number of seconds between the probes"),
+			defkit.Int("timeoutSeconds").Default(5).Min(1).Description("Number of seconds after which the probe fails"),
+			defkit.String("image").Default("busybox:1.36").Description("Image running the probe, with the nc and wget commands"),
+		)
+
+	return defkit.NewComponent("external-service").
+		Description("Describes a service running outside the cluster, reachable through an ExternalName Service or a Service with IP endpoints.").
+		Workload("v1", "Service").
+		WithImports("list", "strings").
+		CustomStatus(externalServiceStatus).
+		HealthPolicy(externalServiceCheck+`isHealth: _check == "" || _check == "passing"`).
+		Params(externalName, addresses, ports, healthCheck).
+		Template(externalServiceTemplate)
+}
+
+// externalServiceTemplate defines the template function for external-service.
+func externalServiceTemplate(tpl *defkit.Template) {
+	vela := defkit.VelaCtx()
+	externalName := defkit.String("externalName")
+	addresses := defkit.StringList("addresses")
+	healthCheck := defkit.Object("healthCheck")
+
+	service := defkit.NewResource("v1", "Service").
+		SetIf(externalName.IsSet(), "spec.type", defkit.Lit("ExternalName")).
+		SetIf(externalName.IsSet(), "spec.externalName", externalName).
+		SetIf(defkit.And(externalName.NotSet(), addresses.IsSet()), "spec.type", defkit.Lit("ClusterIP")).
+		SetIf(defkit.And(externalName.NotSet(), addresses.NotSet()), "spec.type",
+			defkit.Reference(`error("externalName or addresses is required")`)).
+		Set("spec.ports", defkit.Reference(fmt.Sprintf(`[for p in parameter.ports {
+	name:       %s
+	port:       p.port
+	targetPort: [if p.targetPort != _|_ {p.targetPort}, p.port][0]
+	protocol:   p.protocol
+	if p.appProtocol != _|_ {appProtocol: p.appProtocol}
+}]`, externalServicePortName)))
+
+	tpl.Output(service)
+
+	// The EndpointSlice is labelled with a manager of its own so that the
+	// EndpointSlice controller leaves it alone.
+	endpoints := defkit.NewResource("discovery.k8s.io/v1", "EndpointSlice").
+		Set("metadata.name", vela.Name()).
+		Set("metadata.labels[kubernetes.io/service-name]", vela.Name()).
+		Set("metadata.labels[endpointslice.kubernetes.io/managed-by]", defkit.Lit("external-service.oam.dev")).
+		Set("addressType", defkit.Reference(`[if strings.Contains(parameter.addresses[0], ":") {"IPv6"}, "IPv4"][0]`)).
+		Set("endpoints", defkit.Reference(`[for a in parameter.addresses {addresses: [a], conditions: ready: true}]`)).
+		Set("ports", defkit.Reference(fmt.Sprintf(`[for p in parameter.ports {
+	name:     %s
+	port:     [if p.targetPort != _|_ {p.targetPort}, p.port][0]
+	protocol: p.protocol
+	if p.appProtocol != _|_ {appProtocol: p.appProtocol}
+}]`, externalServicePortName)))
+
+	tpl.OutputsIf(defkit.And(externalName.NotSet(), addresses.IsSet()), "endpoints", endpoints)
+
+	// The probe goes through the Service, so it also checks the DNS name or the
+	// endpoints the Service resolves to. It is the readiness probe of an idle
+	// pod, whose Ready condition holds the result of the last probe.
+	probe := defkit.NewResource("apps/v1", "Deployment").
+		Set("metadata.name", defkit.Interpolation(vela.Name(), defkit.Lit("-health-check"))).
+		Set("spec.replicas", defkit.Lit(1)).
+		Set("spec.strategy.type", defkit.Lit("Recreate")).
+		Set("spec.selector.matchLabels[external-service.oam.dev/health-check]", vela.Name()).
+		Set("spec.template.metadata.labels[external-service.oam.dev/health-check]", vela.Name()).
+		Set("spec.template.spec.containers", defkit.Reference(`[{
+	_check: parameter.healthCheck
+	_host:  "\(context.name).\(context.namespace).svc"
+	_port:  [if _check.port != _|_ {_check.port}, parameter.ports[0].port][0]
+	_wait:  "\(_check.timeoutSeconds)"
+	name:   "probe"
+	image:  _check.image
+	command: ["sleep", "2147483647"]
+	readinessProbe: {
+		if _check.type == "tcp" {exec: command: ["nc", "-z", "-w", _wait, _host, "\(_port)"]}
+		if _check.type == "http" {exec: command: ["wget", "-q", "-T", _wait, "-O", "/dev/null", "http://\(_host):\(_port)\(_check.path)"]}
+		periodSeconds:    _check.periodSeconds
+		timeoutSeconds:   _check.timeoutSeconds + 1
+		failureThreshold: 1
+	}
+}]`))
+
+	tpl.OutputsIf(healthCheck.IsSet(), "healthCheck", probe)
+}
+
+func init() {
+	defkit.Register(ExternalService())
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"cuelang.org/go/cue"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("ExternalService Component", func() {
+	Describe("ExternalService()", func() {
+		It("should create an external-service component definition", func() {
+			comp := components.ExternalService()
+			Expect(comp.GetName()).To(Equal("external-service"))
+			Expect(comp.GetDescription()).To(ContainSubstring("outside the cluster"))
+		})
+
+		It("should have v1 Service workload", func() {
+			workload := components.ExternalService().GetWorkload()
+			Expect(workload.APIVersion()).To(Equal("v1"))
+			Expect(workload.Kind()).To(Equal("Service"))
+		})
+
+		It("should have endpoint, port and health check parameters", func() {
+			comp := components.ExternalService()
+			for _, name := range []string{"externalName", "addresses", "ports", "healthCheck"} {
+				Expect(comp).To(HaveParamNamed(name))
+			}
+		})
+
+		It("should output the Service, its EndpointSlice and its health check Deployment", func() {
+			tpl := defkit.NewTemplate()
+			components.ExternalService().GetTemplate()(tpl)
+			Expect(tpl.GetOutput()).To(BeResourceOfKind("Service"))
+			outputs := tpl.GetOutputs()
+			Expect(outputs["endpoints"]).To(BeResourceOfKind("EndpointSlice"))
+			Expect(outputs["healthCheck"]).To(BeResourceOfKind("Deployment"))
+		})
+	})
+
+	Describe("ExternalService rendering", func() {
+		ctx := `{name: "db", namespace: "prod"}`
+
+		It("should render an ExternalName Service for a DNS name", func() {
+			v := render(components.ExternalService(), ctx, `{externalName: "db.example.com", ports: [{port: 5432, appProtocol: "postgresql"}]}`)
+			Expect(lookup(v, "output.spec.type")).To(Equal("ExternalName"))
+			Expect(lookup(v, "output.spec.externalName")).To(Equal("db.example.com"))
+			Expect(lookup(v, "output.spec.ports[0].name")).To(Equal("port-5432"))
+			Expect(lookup(v, "output.spec.ports[0].appProtocol")).To(Equal("postgresql"))
+			Expect(v.LookupPath(cue.ParsePath("output.spec.selector")).Exists()).To(BeFalse())
+			Expect(v.LookupPath(cue.ParsePath("outputs.endpoints")).Exists()).To(BeFalse())
+		})
+
+		It("should render a Service without selector and an EndpointSlice for IP addresses", func() {
+			v := render(components.ExternalService(), ctx, `{addresses: ["10.0.0.10", "10.0.0.11"], ports: [{port: 80, targetPort: 8080}, {port: 53, protocol: "UDP"}]}`)
+			Expect(lookup(v, "output.spec.type")).To(Equal("ClusterIP"))
+			Expect(v.LookupPath(cue.ParsePath("output.spec.selector")).Exists()).To(BeFalse())
+			Expect(lookupInt(v, "output.spec.ports[0].targetPort")).To(Equal(int64(8080)))
+			Expect(lookup(v, "output.spec.ports[1].name")).To(Equal("port-53-udp"))
+
+			Expect(v.LookupPath(cue.ParsePath("outputs.endpoints")).Validate(cue.Concrete(true))).To(Succeed())
+			Expect(lookup(v, `outputs.endpoints.metadata.labels."kubernetes.io/service-name"`)).To(Equal("db"))
+			Expect(lookup(v, "outputs.endpoints.addressType")).To(Equal("IPv4"))
+			Expect(lookup(v, "outputs.endpoints.endpoints[1].addresses[0]")).To(Equal("10.0.0.11"))
+			Expect(lookup(v, "outputs.endpoints.ports[0].name")).To(Equal("port-80"))
+			Expect(lookupInt(v, "outputs.endpoints.ports[0].port")).To(Equal(int64(8080)))
+			Expect(lookup(v, "outputs.endpoints.ports[1].name")).To(Equal("port-53-udp"))
+
+			v = render(components.ExternalService(), ctx, `{addresses: ["fd00::10"], ports: [{port: 80}]}`)
+			Expect(lookup(v, "outputs.endpoints.addressType")).To(Equal("IPv6"))
+		})
+
+		It("should require externalName or addresses", func() {
+			v := renderTemplate(components.ExternalService(), ctx, `{ports: [{port: 5432}]}`)
+			Expect(v.Validate(cue.Concrete(true))).To(MatchError(ContainSubstring("externalName or addresses is required")))
+		})
+
+		It("should probe the Service from the readiness probe of a pod", func() {
+			v := render(components.ExternalService(), ctx, `{externalName: "api.example.com", ports: [{port: 443}, {port: 80}], healthCheck: {type: "http", port: 80, path: "/healthz"}}`)
+			Expect(v.LookupPath(cue.ParsePath("outputs.healthCheck")).Validate(cue.Concrete(true))).To(Succeed())
+			Expect(lookup(v, "outputs.healthCheck.kind")).To(Equal("Deployment"))
+			Expect(lookup(v, "outputs.healthCheck.metadata.name")).To(Equal("db-health-check"))
+			Expect(lookup(v, `outputs.healthCheck.spec.selector.matchLabels."external-service.oam.dev/health-check"`)).To(Equal("db"))
+			probe := "outputs.healthCheck.spec.template.spec.containers[0].readinessProbe"
+			Expect(lookupInt(v, probe+".periodSeconds")).To(Equal(int64(60)))
+			Expect(lookupInt(v, probe+".failureThreshold")).To(Equal(int64(1)))
+			Expect(lookup(v, probe+".exec.command[0]")).To(Equal("wget"))
+			Expect(lookup(v, probe+".exec.command[6]")).To(Equal("http://db.prod.svc:80/healthz"))
+
+			v = render(components.ExternalService(), ctx, `{externalName: "db.example.com", ports: [{port: 5432}], healthCheck: {periodSeconds: 30}}`)
+			Expect(lookupInt(v, probe+".periodSeconds")).To(Equal(int64(30)))
+			Expect(lookup(v, probe+".exec.command[0]")).To(Equal("nc"))
+			Expect(lookup(v, probe+".exec.command[4]")).To(Equal("db.prod.svc"))
+			Expect(lookup(v, probe+".exec.command[5]")).To(Equal("5432"))
+		})
+
+		It("should report the target and be healthy while the health check passes", func() {
+			service := `output: {metadata: {name: "db", namespace: "prod"}, spec: {type: "ExternalName", externalName: "db.example.com"}}`
+			Expect(evalStatus(components.ExternalService(), "customStatus", "message", "context: "+"{"+service+"}").String()).To(Equal("Host:db.prod.svc, target:db.example.com"))
+			Expect(evalStatus(components.ExternalService(), "healthPolicy", "isHealth", "context: "+"{"+service+"}").Bool()).To(BeTrue())
+
+			pending := `outputs: healthCheck: {}`
+			Expect(evalStatus(components.ExternalService(), "customStatus", "message", "context: "+"{"+service+", "+pending+"}").String()).To(Equal("Host:db.prod.svc, target:db.example.com, check:pending"))
+			Expect(evalStatus(components.ExternalService(), "healthPolicy", "isHealth", "context: "+"{"+service+", "+pending+"}").Bool()).To(BeFalse())
+
+			passing := `outputs: healthCheck: {metadata: generation: 2, status: {observedGeneration: 2, updatedReplicas: 1, readyReplicas: 1}}`
+			Expect(evalStatus(components.ExternalService(), "customStatus", "message", "context: "+"{"+service+", "+passing+"}").String()).To(ContainSubstring("check:passing"))
+			Expect(evalStatus(components.ExternalService(), "healthPolicy", "isHealth", "context: "+"{"+service+", "+passing+"}").Bool()).To(BeTrue())
+
+			failing := `outputs: healthCheck: {metadata: generation: 2, status: {observedGeneration: 2, updatedReplicas: 1, unavailableReplicas: 1}}`
+			Expect(evalStatus(components.ExternalService(), "customStatus", "message", "context: "+"{"+service+", "+failing+"}").String()).To(ContainSubstring("check:failing"))
+			Expect(evalStatus(components.ExternalService(), "healthPolicy", "isHealth", "context: "+"{"+service+", "+failing+"}").Bool()).To(BeFalse())
+
+			updating := `outputs: healthCheck: {metadata: generation: 3, status: {observedGeneration: 2, updatedReplicas: 1, readyReplicas: 1}}`
+			Expect(evalStatus(components.ExternalService(), "customStatus", "message", "context: "+"{"+service+", "+updating+"}").String()).To(ContainSubstring("check:pending"))
+
+			ips := `output: {metadata: {name: "db", namespace: "prod"}, spec: type: "ClusterIP"}, outputs: endpoints: endpoints: [{addresses: ["10.0.0.10"]}, {addresses: ["10.0.0.11"]}]`
+			Expect(evalStatus(components.ExternalService(), "customStatus", "message", "context: "+"{"+ips+"}").String()).To(Equal("Host:db.prod.svc, target:2 addresses"))
+		})
+	})
+})
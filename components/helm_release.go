@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// HelmRelease creates the helm-release component definition.
+// It describes a Helm chart installed by Flux, fetched from a HelmRepository,
+// or from an OCIRepository when repoURL is an oci:// artifact.
+func HelmRelease() *defkit.ComponentDefinition {
+	repoURL := defkit.String("repoURL").
+		Description("URL of the Helm repository, e.g. https://stefanprodan.github.io/podinfo, or of the OCI chart artifact, e.g. oci://ghcr.io/stefanprodan/charts/podinfo")
+	chart := defkit.String("chart").
+		Optional().
+		Description("Name of the chart in the Helm repository, required unless repoURL is an OCI chart artifact")
+	version := defkit.String("version").
+		Optional().
+		Description("Version or semver range of the chart, e.g. 6.5.x, defaults to the latest version")
+	interval := defkit.String("interval").
+		Default("10m").
+		Description("Interval at which the chart source and the release are reconciled")
+	values := defkit.Object("values").
+		Optional().
+		Description("Values of the chart")
+	valuesFrom := defkit.Array("valuesFrom").
+		Optional().
+		Description("Read values from ConfigMaps and Secrets, merged in order before values").
+		WithFields(
+			defkit.Enum("kind").Values("ConfigMap", "Secret").Description("Kind of the values source"),
+			defkit.String("name").Description("Name of the ConfigMap or Secret in the namespace of the application"),
+			defkit.String("valuesKey").Default("values.yaml").Description("Key holding the values"),
+			defkit.String("targetPath").Optional().Description("Set the value of the key at this path of the values instead of merging it, e.g. image.tag"),
+			defkit.Bool("optional").Default(false).Description("Ignore the source when it does not exist"),
+		)
+	targetNamespace := defkit.String("targetNamespace").
+		Optional().
+		Description("Namespace the chart is installed into, defaults to the namespace of the application")
+	install := defkit.Object("install").
+		Description("Install settings").
+		WithFields(
+			defkit.Bool("createNamespace").Default(false).Description("Create the targetNamespace if it does not exist"),
+			defkit.Int("retries").Default(3).Description("Number of retries after a failed install, -1 retries forever"),
+		)
+	upgrade := defkit.Object("upgrade").
+		Description("Upgrade settings").
+		WithFields(
+			defkit.Int("retries").Default(3).Description("Number of retries after a failed upgrade, -1 retries forever"),
+			defkit.Enum("strategy").Values("rollback", "uninstall").Default("rollback").Description("Remediate a failed upgrade by rolling back or by uninstalling the release"),
+			defkit.Bool("cleanupOnFail").Default(false).Description("Delete the new resources created by a failed upgrade"),
+		)
+
+	return defkit.NewComponent("helm-release").
+		Description("Describes a Helm chart installed by Flux from a Helm repository or an OCI registry.").
+		Workload("helm.toolkit.fluxcd.io/v2", "HelmRelease").
+		WithImports("strings").
+		CustomStatus(ReadyConditionStatus(
+			defkit.Status().StringField("status.lastAttemptedRevision", "status.lastAttemptedRevision", "none"),
+			`Ready:\(_readyStatus), revision:\(status.lastAttemptedRevision)\(_readyMessage)`)).
+		HealthPolicy(ReadyConditionHealth()).
+		Params(repoURL, chart, version, interval, values, valuesFrom, targetNamespace, install, upgrade).
+		Template(helmReleaseTemplate)
+}
+
+// helmReleaseTemplate defines the template function for helm-release.
+func helmReleaseTemplate(tpl *defkit.Template) {
+	vela := defkit.VelaCtx()
+	repoURL := defkit.String("repoURL")
+	chart := defkit.String("chart")
+	version := defkit.String("version")
+	interval := defkit.String("interval")
+	values := defkit.Object("values")
+	valuesFrom := defkit.List("valuesFrom")
+	targetNamespace := defkit.String("targetNamespace")
+
+	isOCI := repoURL.StartsWith("oci://")
+
+	release := defkit.NewResource("helm.toolkit.fluxcd.io/v2", "HelmRelease").
+		Set("spec.interval", interval).
+		SetIf(defkit.And(defkit.Not(isOCI), chart.IsSet()), "spec.chart.spec.chart", chart).
+		SetIf(defkit.And(defkit.Not(isOCI), chart.NotSet()), "spec.chart.spec.chart",
+			defkit.Reference(`error("chart is required when repoURL is not an oci:// chart artifact")`)).
+		SetIf(defkit.And(defkit.Not(isOCI), version.IsSet()), "spec.chart.spec.version", version).
+		SetIf(defkit.Not(isOCI), "spec.chart.spec.sourceRef.kind", defkit.Lit("HelmRepository")).
+		SetIf(defkit.Not(isOCI), "spec.chart.spec.sourceRef.name", vela.Name()).
+		SetIf(isOCI, "spec.chartRef.kind", defkit.Lit("OCIRepository")).
+		SetIf(isOCI, "spec.chartRef.name", vela.Name()).
+		SetIf(values.IsSet(), "spec.values", values).
+		SetIf(valuesFrom.IsSet(), "spec.valuesFrom", valuesFrom).
+		SetIf(targetNamespace.IsSet(), "spec.targetNamespace", targetNamespace).
+		Set("spec.install.createNamespace", defkit.Reference("parameter.install.createNamespace")).
+		Set("spec.install.remediation.retries", defkit.Reference("parameter.install.retries")).
+		Set("spec.upgrade.remediation.retries", defkit.Reference("parameter.upgrade.retries")).
+		Set("spec.upgrade.remediation.strategy", defkit.Reference("parameter.upgrade.strategy")).
+		Set("spec.upgrade.cleanupOnFail", defkit.Reference("parameter.upgrade.cleanupOnFail"))
+	tpl.Output(release)
+
+	helmRepository := defkit.NewResource("source.toolkit.fluxcd.io/v1", "HelmRepository").
+		Set("metadata.name", vela.Name()).
+		Set("spec.url", repoURL).
+		Set("spec.interval", interval)
+	tpl.OutputsIf(defkit.Not(isOCI), "helmRepository", helmRepository)
+
+	// The OCI artifact is the chart itself, selected by version
+	ociRepository := defkit.NewResource("source.toolkit.fluxcd.io/v1beta2", "OCIRepository").
+		Set("metadata.name", vela.Name()).
+		Set("spec.url", repoURL).
+		Set("spec.interval", interval).
+		Set("spec.layerSelector.mediaType", defkit.Lit("application/vnd.cncf.helm.chart.content.v1.tar+gzip")).
+		Set("spec.layerSelector.operation", defkit.Lit("copy")).
+		SetIf(version.IsSet(), "spec.ref.semver", version)
+	tpl.OutputsIf(isOCI, "ociRepository", ociRepository)
+}
+
+func init() {
+	defkit.Register(HelmRelease())
+}
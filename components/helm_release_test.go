@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("HelmRelease Component", func() {
+	Describe("HelmRelease()", func() {
+		It("should create a helm-release component definition", func() {
+			comp := components.HelmRelease()
+			Expect(comp.GetName()).To(Equal("helm-release"))
+			Expect(comp.GetDescription()).To(ContainSubstring("Flux"))
+		})
+
+		It("should have helm.toolkit.fluxcd.io/v2 HelmRelease workload", func() {
+			comp := components.HelmRelease()
+			workload := comp.GetWorkload()
+			Expect(workload.APIVersion()).To(Equal("helm.toolkit.fluxcd.io/v2"))
+			Expect(workload.Kind()).To(Equal("HelmRelease"))
+		})
+
+		It("should have chart and release parameters", func() {
+			comp := components.HelmRelease()
+			Expect(comp).To(HaveParamNamed("repoURL"))
+			Expect(comp).To(HaveParamNamed("chart"))
+			Expect(comp).To(HaveParamNamed("version"))
+			Expect(comp).To(HaveParamNamed("values"))
+			Expect(comp).To(HaveParamNamed("valuesFrom"))
+			Expect(comp).To(HaveParamNamed("targetNamespace"))
+			Expect(comp).To(HaveParamNamed("install"))
+			Expect(comp).To(HaveParamNamed("upgrade"))
+		})
+
+		It("should output the HelmRelease and its chart source", func() {
+			comp := components.HelmRelease()
+			tpl := defkit.NewTemplate()
+			comp.GetTemplate()(tpl)
+			Expect(tpl.GetOutput()).To(BeResourceOfKind("HelmRelease"))
+			outputs := tpl.GetOutputs()
+			Expect(outputs).To(HaveKey("helmRepository"))
+			Expect(outputs).To(HaveKey("ociRepository"))
+			Expect(outputs["helmRepository"]).To(BeResourceOfKind("HelmRepository"))
+			Expect(outputs["ociRepository"]).To(BeResourceOfKind("OCIRepository"))
+		})
+	})
+
+	Describe("HelmRelease CUE generation", func() {
+		var cue string
+
+		BeforeEach(func() {
+			cue = defkit.NewCUEGenerator().GenerateFullDefinition(components.HelmRelease())
+		})
+
+		It("should take the chart from a HelmRepository", func() {
+			Expect(cue).To(ContainSubstring(`if !(strings.HasPrefix(parameter.repoURL, "oci://")) {`))
+			Expect(cue).To(ContainSubstring("chart: parameter.chart"))
+			Expect(cue).To(ContainSubstring(`kind: "HelmRepository"`))
+		})
+
+		It("should require chart unless repoURL is an OCI chart artifact", func() {
+			Expect(cue).To(ContainSubstring(`if !(strings.HasPrefix(parameter.repoURL, "oci://")) && parameter["chart"] == _|_ {`))
+			Expect(cue).To(ContainSubstring(`chart: error("chart is required when repoURL is not an oci:// chart artifact")`))
+		})
+
+		It("should take the chart from an OCIRepository for oci:// URLs", func() {
+			Expect(cue).To(ContainSubstring(`if strings.HasPrefix(parameter.repoURL, "oci://") {`))
+			Expect(cue).To(ContainSubstring(`kind: "OCIRepository"`))
+			Expect(cue).To(ContainSubstring("semver: parameter.version"))
+		})
+
+		It("should take free-form values and values sources", func() {
+			Expect(cue).To(ContainSubstring("values?: {...}"))
+			Expect(cue).To(ContainSubstring(`kind: "ConfigMap" | "Secret"`))
+			Expect(cue).To(ContainSubstring("valuesFrom: parameter.valuesFrom"))
+		})
+
+		It("should render install and upgrade remediation", func() {
+			Expect(cue).To(ContainSubstring("retries: parameter.install.retries"))
+			Expect(cue).To(ContainSubstring("retries: parameter.upgrade.retries"))
+			Expect(cue).To(ContainSubstring(`strategy: *"rollback" | "uninstall"`))
+		})
+
+		It("should derive status and health from the Ready condition", func() {
+			Expect(cue).To(ContainSubstring(`if c.type == "Ready"`))
+			Expect(cue).To(ContainSubstring(`message: "Ready:\(_readyStatus), revision:\(status.lastAttemptedRevision)\(_readyMessage)"`))
+			Expect(cue).To(ContainSubstring(`isHealth: _readyStatus == "True" && ready.observedGeneration == context.output.metadata.generation`))
+		})
+	})
+})
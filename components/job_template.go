@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// Job template library shared by task and cron-task.
+//
+// The Job parameters are declared once and rendered by JobTemplate into a Job
+// spec, or into the job template of a CronJob, so both components run the same
+// kind of Job:
+//
+//	defkit.NewComponent("task").
+//		Params(JobParams()...).
+//		Template(func(tpl *defkit.Template) {
+//			job := defkit.NewResource("batch/v1", "Job")
+//			NewJobTemplate("spec").Apply(job)
+//			NewPodTemplate(tpl, "spec.template").Apply(job)
+//			tpl.Output(job)
+//		})
+
+// JobParams returns count, restart, completions, completionMode, the backoff
+// limits, podFailurePolicy, activeDeadlineSeconds and ttlSecondsAfterFinished.
+func JobParams() []defkit.Param {
+	return []defkit.Param{
+		defkit.Int("count").Default(1).Description("Specify number of tasks to run in parallel").Short("c"),
+		defkit.String("restart").Default("Never").
+			Description("Define the job restart policy, the value can only be Never or OnFailure. By default, it's Never."),
+		defkit.Int("completions").Optional().Description("Specify number of tasks that must succeed, defaults to count"),
+		defkit.Enum("completionMode").Values("NonIndexed", "Indexed").Default("NonIndexed").
+			Description("Specify how completions are tracked. Indexed tasks get a completion index from 0 to completions-1 in the JOB_COMPLETION_INDEX env"),
+		defkit.Int("backoffLimit").Default(6).
+			Description("The number of retries before marking this job failed, not used when backoffLimitPerIndex is set"),
+		defkit.Int("backoffLimitPerIndex").Optional().
+			Description("Specify number of retries of each index before it is marked failed. Only valid when completionMode is Indexed"),
+		defkit.Int("maxFailedIndexes").Optional().
+			Description("Specify number of failed indexes after which the task fails. Requires backoffLimitPerIndex"),
+		defkit.Object("podFailurePolicy").Optional().
+			Description("Specify how pod failures are handled, requires restart to be Never").
+			WithFields(
+				defkit.Array("rules").Description("Rules evaluated in order, the first matching rule applies").WithFields(
+					defkit.Enum("action").Values("FailJob", "FailIndex", "Ignore", "Count").Description("Action taken when the rule matches"),
+					defkit.Object("onExitCodes").Optional().Description("Match pods by the exit codes of their containers").WithFields(
+						defkit.String("containerName").Optional().Description("Only match the exit code of this container"),
+						defkit.Enum("operator").Values("In", "NotIn").Description("Relation between the exit code and values"),
+						defkit.IntList("values").Description("Exit codes to match"),
+					),
+					defkit.Array("onPodConditions").Optional().Description("Match pods by their conditions").WithFields(
+						defkit.String("type").Description("Pod condition type, e.g. DisruptionTarget"),
+						defkit.String("status").Default("True").Description("Pod condition status"),
+					),
+				),
+			),
+		defkit.Int("activeDeadlineSeconds").Optional().
+			Description("The duration in seconds relative to the startTime that the job may be continuously active before the system tries to terminate it"),
+		defkit.Int("ttlSecondsAfterFinished").Optional().Description("Limits the lifetime of a Job that has finished"),
+	}
+}
+
+// JobTemplate renders JobParams into the Job spec at path, e.g. "spec" for a
+// Job or "spec.jobTemplate.spec" for a CronJob.
+type JobTemplate struct {
+	path string
+}
+
+// NewJobTemplate creates a JobTemplate writing to the Job spec at path.
+func NewJobTemplate(path string) *JobTemplate {
+	return &JobTemplate{path: path}
+}
+
+// Spec returns the path of a Job spec field, e.g. Spec("completions").
+func (j *JobTemplate) Spec(field string) string {
+	return j.path + "." + field
+}
+
+// Apply renders JobParams into r. completions defaults to count, the number of
+// pods run in parallel. backoffLimitPerIndex is rejected unless completionMode
+// is Indexed, and maxFailedIndexes without backoffLimitPerIndex.
+func (j *JobTemplate) Apply(r *defkit.Resource) *defkit.Resource {
+	count := defkit.Int("count")
+	restart := defkit.String("restart")
+	completions := defkit.Int("completions")
+	completionMode := defkit.String("completionMode")
+	backoffLimit := defkit.Int("backoffLimit")
+	backoffLimitPerIndex := defkit.Int("backoffLimitPerIndex")
+	maxFailedIndexes := defkit.Int("maxFailedIndexes")
+	podFailurePolicy := defkit.Object("podFailurePolicy")
+	activeDeadlineSeconds := defkit.Int("activeDeadlineSeconds")
+	ttlSecondsAfterFinished := defkit.Int("ttlSecondsAfterFinished")
+
+	return r.
+		Set(j.Spec("parallelism"), count).
+		SetIf(completions.IsSet(), j.Spec("completions"), completions).
+		SetIf(completions.NotSet(), j.Spec("completions"), count).
+		Set(j.Spec("completionMode"), completionMode).
+		// backoffLimit would cap the retries of all indexes together
+		SetIf(backoffLimitPerIndex.NotSet(), j.Spec("backoffLimit"), backoffLimit).
+		SetIf(backoffLimitPerIndex.IsSet(), j.Spec("backoffLimitPerIndex"), backoffLimitPerIndex).
+		SetIf(defkit.And(backoffLimitPerIndex.IsSet(), defkit.Ne(completionMode, defkit.Lit("Indexed"))), j.Spec("backoffLimitPerIndex"),
+			defkit.Reference(`error("backoffLimitPerIndex is only valid when completionMode is Indexed")`)).
+		SetIf(maxFailedIndexes.IsSet(), j.Spec("maxFailedIndexes"), maxFailedIndexes).
+		SetIf(defkit.And(maxFailedIndexes.IsSet(), backoffLimitPerIndex.NotSet()), j.Spec("maxFailedIndexes"),
+			defkit.Reference(`error("maxFailedIndexes requires backoffLimitPerIndex")`)).
+		SetIf(podFailurePolicy.IsSet(), j.Spec("podFailurePolicy"), podFailurePolicy).
+		SetIf(activeDeadlineSeconds.IsSet(), j.Spec("activeDeadlineSeconds"), activeDeadlineSeconds).
+		SetIf(ttlSecondsAfterFinished.IsSet(), j.Spec("ttlSecondsAfterFinished"), ttlSecondsAfterFinished).
+		Set(j.Spec("template.spec.restartPolicy"), restart)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"cuelang.org/go/cue"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	"github.com/oam-dev/vela-go-definitions/components"
+)
+
+var _ = Describe("Job template library", func() {
+	parameterSchema := func(params ...defkit.Param) string {
+		return defkit.NewCUEGenerator().GenerateParameterSchema(defkit.NewComponent("schema").Params(params...))
+	}
+
+	for _, newComponent := range []func() *defkit.ComponentDefinition{components.Task, components.CronTask} {
+		comp := newComponent()
+
+		Describe(comp.GetName(), func() {
+			It("should expose the shared Job parameters", func() {
+				byName := map[string]defkit.Param{}
+				for _, p := range comp.GetParams() {
+					byName[p.Name()] = p
+				}
+				var params []defkit.Param
+				for _, p := range components.JobParams() {
+					if found, ok := byName[p.Name()]; ok {
+						params = append(params, found)
+					}
+				}
+				Expect(parameterSchema(params...)).To(Equal(parameterSchema(components.JobParams()...)))
+			})
+
+			It("should render the shared Job parameters", func() {
+				cue := comp.ToCue()
+				Expect(cue).To(ContainSubstring("parallelism: parameter.count"))
+				Expect(cue).To(ContainSubstring("completions: parameter.count"))
+				Expect(cue).To(ContainSubstring("completionMode: parameter.completionMode"))
+				Expect(cue).To(ContainSubstring("backoffLimit: parameter.backoffLimit"))
+				Expect(cue).To(ContainSubstring("restartPolicy: parameter.restart"))
+			})
+		})
+	}
+
+	Describe("NewJobTemplate", func() {
+		It("should address the Job spec at the given path", func() {
+			Expect(components.NewJobTemplate("spec.jobTemplate.spec").Spec("completions")).To(Equal("spec.jobTemplate.spec.completions"))
+		})
+
+		It("should only set backoffLimit without backoffLimitPerIndex", func() {
+			cue := defkit.NewComponent("job").
+				Workload("batch/v1", "Job").
+				Params(components.JobParams()...).
+				Template(func(tpl *defkit.Template) {
+					tpl.Output(components.NewJobTemplate("spec").Apply(defkit.NewResource("batch/v1", "Job")))
+				}).
+				ToCue()
+			Expect(cue).To(ContainSubstring(`if parameter["backoffLimitPerIndex"] == _|_ {`))
+			Expect(cue).To(ContainSubstring("backoffLimitPerIndex: parameter.backoffLimitPerIndex"))
+		})
+	})
+
+	Describe("Job rendering", func() {
+		ctx := `{name: "migrate", appName: "shop", namespace: "prod"}`
+
+		It("should render the per-index backoff of Indexed tasks", func() {
+			v := renderOutput(components.Task(), ctx, `{image: "migrate:1", completionMode: "Indexed", backoffLimitPerIndex: 2, maxFailedIndexes: 1}`)
+			Expect(lookupInt(v, "spec.backoffLimitPerIndex")).To(Equal(int64(2)))
+			Expect(lookupInt(v, "spec.maxFailedIndexes")).To(Equal(int64(1)))
+			Expect(v.LookupPath(cue.ParsePath("spec.backoffLimit")).Exists()).To(BeFalse())
+		})
+
+		It("should reject backoffLimitPerIndex unless completionMode is Indexed", func() {
+			err := renderTemplate(components.Task(), ctx, `{image: "migrate:1", backoffLimitPerIndex: 2}`).Validate(cue.Concrete(true))
+			Expect(err).To(MatchError(ContainSubstring("backoffLimitPerIndex is only valid when completionMode is Indexed")))
+		})
+
+		It("should reject maxFailedIndexes without backoffLimitPerIndex", func() {
+			err := renderTemplate(components.CronTask(), ctx, `{image: "migrate:1", schedule: "0 * * * *", completionMode: "Indexed", maxFailedIndexes: 1}`).Validate(cue.Concrete(true))
+			Expect(err).To(MatchError(ContainSubstring("maxFailedIndexes requires backoffLimitPerIndex")))
+		})
+	})
+})
@@ -22,39 +22,94 @@ import (
 
 // K8sObjects creates the k8s-objects component definition.
 // K8s-objects allow users to specify raw K8s objects in properties.
+// The first object is the workload, the others are keyed by their outputName
+// or, without one, by their index.
+//
+// Each object can select a health rule; the component is healthy once all of
+// these objects are, and reports how many of them are.
+//
+// The template is written as raw CUE blocks rather than with the resource
+// builders, which need the kind of each output up front.
 func K8sObjects() *defkit.ComponentDefinition {
-	return defkit.NewComponent("k8s-objects").
+	objects := defkit.Array("objects").
+		Description("Specify the Kubernetes objects to apply, the first one is the workload").
+		WithFields(
+			defkit.Enum("healthPolicy").
+				Optional().
+				Values(ObjectHealthRuleNames()...).
+				Description(`Check the health of the object with the rule of its kind, or with the "Ready" condition of any object`),
+			defkit.String("outputName").
+				Optional().
+				Pattern(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`).
+				Description("Name the output of the object instead of by its index, so that reordering the objects keeps their outputs. Must be unique and not of the form objects-<index>. Ignored for the first object"),
+		)
+
+	return WithRawTemplateBlocks(defkit.NewComponent("k8s-objects").
 		Description("K8s-objects allow users to specify raw K8s objects in properties").
 		AutodetectWorkload().
-		RawCUE(`"k8s-objects": {
-	type: "component"
-	annotations: {}
-	labels: {}
-	description: "K8s-objects allow users to specify raw K8s objects in properties"
-	attributes: workload: type: "autodetects.core.oam.dev"
+		CustomStatus(k8sObjectsHealth + `
+_healthy: len([for c in _objectHealth if c.healthy {c}])
+message: *"" | string
+if len(_objectHealth) > 0 {
+	message: "Healthy objects:\(_healthy)/\(len(_objectHealth))"
+}`).
+		HealthPolicy(k8sObjectsHealth + `
+isHealth: len([for c in _objectHealth if !c.healthy {c}]) == 0`).
+		Params(objects).
+		Template(k8sObjectsTemplate))
 }
-template: {
-	output: {
-		if len(parameter.objects) > 0 {
-			parameter.objects[0]
-		}
-		...
+
+// k8sObjectsTemplate defines the template function for k8s-objects. The kinds
+// of the objects are only known from the parameter, which the resource
+// builders cannot express, so the outputs are raw blocks.
+func k8sObjectsTemplate(tpl *defkit.Template) {
+	// objectsList holds the objects without their k8s-objects fields.
+	tpl.SetRawHeaderBlock(`objectsList: [for v in parameter.objects {
+	{for k, x in v if k != "healthPolicy" && k != "outputName" {(k): x}}
+}]`)
+
+	// An outputName of the form objects-<n> would take the output of the
+	// object at that index, and a repeated one would merge two objects.
+	tpl.SetRawOutputsBlock(`output: {
+	if len(objectsList) > 0 {
+		objectsList[0]
 	}
+	...
+}
 
-	outputs: {
-		for i, v in parameter.objects {
-			if i > 0 {
-				"objects-\(i)": v
-			}
+outputs: {
+	for i, v in parameter.objects if i > 0 {
+		if v.outputName != _|_ {
+			(v.outputName): [
+				if v.outputName =~ "^objects-[0-9]+$" {error("objects[\(i)].outputName \(v.outputName) is reserved for the object at that index")},
+				if len([for j, w in parameter.objects if j > 0 && j < i if w.outputName != _|_ if w.outputName == v.outputName {j}]) > 0 {error("objects[\(i)].outputName \(v.outputName) is used by another object")},
+				objectsList[i],
+			][0]
+		}
+		if v.outputName == _|_ {
+			"objects-\(i)": objectsList[i]
 		}
 	}
-	parameter: {
-		objects: [...{}]
-	}
-}
-`)
+}`)
 }
 
+// k8sObjectsHealth checks the live objects with a healthPolicy, the first one
+// in context.output and the others in context.outputs.
+var k8sObjectsHealth = `_objectHealth: [for i, o in parameter.objects if o.healthPolicy != _|_ {
+	_key: *"objects-\(i)" | string
+	if o.outputName != _|_ {
+		_key: o.outputName
+	}
+	_live: {}
+	if i == 0 {
+		_live: context.output
+	}
+	if i > 0 && context.outputs[_key] != _|_ {
+		_live: context.outputs[_key]
+	}
+` + indentCUE(objectHealthCheck("o.healthPolicy", "_live"), "\t") + `
+}]`
+
 func init() {
 	defkit.Register(K8sObjects())
 }
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"cuelang.org/go/cue"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("K8sObjects Component", func() {
+	Describe("K8sObjects()", func() {
+		It("should create a k8s-objects component definition", func() {
+			comp := components.K8sObjects()
+			Expect(comp.GetName()).To(Equal("k8s-objects"))
+			Expect(comp.GetWorkload().IsAutodetect()).To(BeTrue())
+			Expect(comp).To(HaveParamNamed("objects"))
+		})
+
+		It("should offer a health rule per kind of object", func() {
+			Expect(components.ObjectHealthRuleNames()).To(Equal([]string{
+				"Deployment", "StatefulSet", "DaemonSet", "Job", "CronJob", "PersistentVolumeClaim", "Service", "Ready",
+			}))
+		})
+	})
+
+	Describe("K8sObjects CUE generation", func() {
+		var cue string
+
+		BeforeEach(func() {
+			cue = components.K8sObjects().ToCue()
+		})
+
+		It("should generate the objects parameter with healthPolicy and outputName", func() {
+			Expect(cue).To(ContainSubstring(`healthPolicy?: "Deployment" | "StatefulSet" | "DaemonSet" | "Job" | "CronJob" | "PersistentVolumeClaim" | "Service" | "Ready"`))
+			Expect(cue).To(ContainSubstring(`outputName?: string & =~"^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"`))
+		})
+
+		It("should output the objects without their k8s-objects fields", func() {
+			Expect(cue).To(ContainSubstring(`{for k, x in v if k != "healthPolicy" && k != "outputName" {(k): x}}`))
+			Expect(cue).To(ContainSubstring("objectsList[0]"))
+		})
+
+		It("should key the outputs by outputName or by index", func() {
+			Expect(cue).To(ContainSubstring("(v.outputName): ["))
+			Expect(cue).To(ContainSubstring("objectsList[i],\n"))
+			Expect(cue).To(ContainSubstring(`"objects-\(i)": objectsList[i]`))
+		})
+
+		It("should reject outputName values reserved for the index keys", func() {
+			Expect(cue).To(ContainSubstring(`if v.outputName =~ "^objects-[0-9]+$" {error("objects[\(i)].outputName \(v.outputName) is reserved for the object at that index")}`))
+		})
+
+		It("should be built from the raw blocks of its template", func() {
+			tpl := defkit.NewTemplate()
+			components.K8sObjects().GetTemplate()(tpl)
+			Expect(tpl.GetRawHeaderBlock()).To(HavePrefix("objectsList: "))
+			Expect(tpl.GetRawOutputsBlock()).To(HavePrefix("output: {"))
+			Expect(cue).To(ContainSubstring("template: {\n\tobjectsList: ["))
+		})
+
+		It("should check each object with its health rule", func() {
+			Expect(cue).To(ContainSubstring("_live: context.output\n"))
+			Expect(cue).To(ContainSubstring("_live: context.outputs[_key]"))
+			Expect(cue).To(ContainSubstring(`if o.healthPolicy == "DaemonSet" {`))
+			Expect(cue).To(ContainSubstring(`healthy: _st.succeeded >= _completions`))
+			Expect(cue).To(ContainSubstring(`healthy: _ready == "True"`))
+		})
+
+		It("should report how many objects are healthy", func() {
+			Expect(cue).To(ContainSubstring(`message: "Healthy objects:\(_healthy)/\(len(_objectHealth))"`))
+			Expect(cue).To(ContainSubstring("isHealth: len([for c in _objectHealth if !c.healthy {c}]) == 0"))
+		})
+	})
+
+	Describe("K8sObjects rendering", func() {
+		ctx := `{name: "objs", namespace: "prod"}`
+		configMap := func(name, outputName string) string {
+			return `{apiVersion: "v1", kind: "ConfigMap", metadata: name: "` + name + `", outputName: "` + outputName + `"}`
+		}
+
+		It("should key the outputs by outputName", func() {
+			v := render(components.K8sObjects(), ctx, `{objects: [`+configMap("a", "first")+`, `+configMap("b", "config")+`, {apiVersion: "v1", kind: "ConfigMap", metadata: name: "c"}]}`)
+			Expect(lookup(v, "output.metadata.name")).To(Equal("a"))
+			Expect(lookup(v, "outputs.config.metadata.name")).To(Equal("b"))
+			Expect(lookup(v, `outputs."objects-2".metadata.name`)).To(Equal("c"))
+		})
+
+		It("should reject a repeated outputName", func() {
+			err := renderTemplate(components.K8sObjects(), ctx, `{objects: [`+configMap("a", "first")+`, `+configMap("b", "config")+`, `+configMap("c", "config")+`]}`).Validate(cue.Concrete(true))
+			Expect(err).To(MatchError(ContainSubstring("objects[2].outputName config is used by another object")))
+		})
+	})
+})
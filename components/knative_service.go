@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// KnativeService creates the knative-service component definition.
+// It describes serverless services run by Knative Serving, scaled on requests
+// down to zero, with traffic split between their revisions.
+func KnativeService() *defkit.ComponentDefinition {
+	labels := defkit.StringKeyMap("labels").Optional().Description("Specify the labels of the revisions")
+	annotations := defkit.StringKeyMap("annotations").Optional().Description("Specify the annotations of the revisions")
+
+	// Knative routes requests to a single container port
+	port := defkit.Int("port").
+		Default(8080).
+		Short("p").
+		Description("Number of the container port requests are sent to")
+	portName := defkit.Enum("portName").
+		Values("http1", "h2c").
+		Default("http1").
+		Description(`Protocol of the port: "http1" for HTTP/1.1, "h2c" for HTTP/2 over cleartext, e.g. gRPC`)
+
+	minScale := defkit.Int("minScale").
+		Optional().
+		Min(0).
+		Description("Minimum number of replicas of each revision, 0 allows scaling to zero")
+	maxScale := defkit.Int("maxScale").
+		Optional().
+		Min(0).
+		Description("Maximum number of replicas of each revision, 0 means unlimited")
+	containerConcurrency := defkit.Int("containerConcurrency").
+		Optional().
+		Min(0).
+		Description("Maximum number of concurrent requests per replica, 0 means unlimited")
+
+	revisionSuffix := defkit.String("revisionSuffix").
+		Optional().
+		Description("Name the revision `<component>-<revisionSuffix>`, e.g. `v2`. It must change on every update and can be referenced by traffic")
+	traffic := defkit.Array("traffic").
+		Optional().
+		Description("Specify how requests are split between revisions, defaults to all requests to the latest ready revision").
+		WithFields(
+			defkit.String("revisionName").Optional().Description("Name of the revision receiving the traffic"),
+			defkit.Bool("latestRevision").Optional().Description("Send the traffic to the latest ready revision, instead of revisionName"),
+			defkit.Int("percent").Min(0).Max(100).Description("Percentage of the requests sent to the target"),
+			defkit.String("tag").Optional().Description("Expose the target at its own URL prefixed by the tag"),
+		)
+
+	return defkit.NewComponent("knative-service").
+		Description("Describes serverless services run by Knative Serving, scaled on requests and split between revisions.").
+		Workload("serving.knative.dev/v1", "Service").
+		// Knative sets Ready once the latest revision is ready and routed
+		CustomStatus(ReadyConditionStatus(
+			defkit.Status().StringField("status.url", "status.url", ""),
+			`Ready:\(_readyStatus), url:\(status.url)\(_readyMessage)`)).
+		HealthPolicy(ReadyConditionHealth()).
+		Params(labels, annotations).
+		Params(PodContainerParams()...).
+		Params(port, portName).
+		Params(PodResourceParams()...).
+		Params(PodProbeParams()...).
+		Params(minScale, maxScale, containerConcurrency, revisionSuffix, traffic).
+		Helper("HealthProbe", HealthProbeParam()).
+		Template(knativeServiceTemplate)
+}
+
+// knativeServiceTemplate defines the template function for knative-service.
+func knativeServiceTemplate(tpl *defkit.Template) {
+	vela := defkit.VelaCtx()
+	labels := defkit.Object("labels")
+	annotations := defkit.Object("annotations")
+	port := defkit.Int("port")
+	portName := defkit.String("portName")
+	minScale := defkit.Int("minScale")
+	maxScale := defkit.Int("maxScale")
+	containerConcurrency := defkit.Int("containerConcurrency")
+	revisionSuffix := defkit.String("revisionSuffix")
+	traffic := defkit.List("traffic")
+	pod := NewPodTemplate(tpl, "spec.template")
+
+	service := defkit.NewResource("serving.knative.dev/v1", "Service").
+		SpreadIf(labels.IsSet(), "spec.template.metadata.labels", labels).
+		Set("spec.template.metadata.labels[app.oam.dev/name]", vela.AppName()).
+		Set("spec.template.metadata.labels[app.oam.dev/component]", vela.Name()).
+		SpreadIf(annotations.IsSet(), "spec.template.metadata.annotations", annotations).
+		// Knative annotation values are strings
+		SetIf(minScale.IsSet(), "spec.template.metadata.annotations[autoscaling.knative.dev/min-scale]", defkit.Interpolation(minScale)).
+		SetIf(maxScale.IsSet(), "spec.template.metadata.annotations[autoscaling.knative.dev/max-scale]", defkit.Interpolation(maxScale)).
+		// Knative requires revision names prefixed by the service name
+		SetIf(revisionSuffix.IsSet(), "spec.template.metadata.name", defkit.Interpolation(vela.Name(), defkit.Lit("-"), revisionSuffix)).
+		SetIf(containerConcurrency.IsSet(), "spec.template.spec.containerConcurrency", containerConcurrency)
+	pod.ApplyContainer(service)
+	pod.ApplyResources(service)
+	pod.ApplyProbes(service)
+	service.
+		Set(pod.Container("ports[0].containerPort"), port).
+		Set(pod.Container("ports[0].name"), portName).
+		SetIf(traffic.IsSet(), "spec.traffic", traffic)
+
+	tpl.Output(service)
+}
+
+func init() {
+	defkit.Register(KnativeService())
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("KnativeService Component", func() {
+	Describe("KnativeService()", func() {
+		It("should create a knative-service component definition", func() {
+			comp := components.KnativeService()
+			Expect(comp.GetName()).To(Equal("knative-service"))
+			Expect(comp.GetDescription()).To(ContainSubstring("Knative"))
+		})
+
+		It("should have serving.knative.dev/v1 Service workload", func() {
+			comp := components.KnativeService()
+			workload := comp.GetWorkload()
+			Expect(workload.APIVersion()).To(Equal("serving.knative.dev/v1"))
+			Expect(workload.Kind()).To(Equal("Service"))
+		})
+
+		It("should reuse the webservice container parameters", func() {
+			comp := components.KnativeService()
+			Expect(comp).To(HaveParamNamed("image"))
+			Expect(comp).To(HaveParamNamed("env"))
+			Expect(comp).To(HaveParamNamed("cpu"))
+			Expect(comp).To(HaveParamNamed("memory"))
+			Expect(comp).To(HaveParamNamed("livenessProbe"))
+			Expect(comp).To(HaveParamNamed("port"))
+		})
+
+		It("should have serving parameters", func() {
+			comp := components.KnativeService()
+			Expect(comp).To(HaveParamNamed("minScale"))
+			Expect(comp).To(HaveParamNamed("maxScale"))
+			Expect(comp).To(HaveParamNamed("containerConcurrency"))
+			Expect(comp).To(HaveParamNamed("revisionSuffix"))
+			Expect(comp).To(HaveParamNamed("traffic"))
+		})
+
+		It("should execute template and produce Knative Service output", func() {
+			comp := components.KnativeService()
+			tpl := defkit.NewTemplate()
+			comp.GetTemplate()(tpl)
+			Expect(tpl.GetOutput()).To(BeResourceOfKind("Service"))
+			Expect(tpl.GetOutput().APIVersion()).To(Equal("serving.knative.dev/v1"))
+		})
+	})
+
+	Describe("KnativeService CUE generation", func() {
+		var cue string
+
+		BeforeEach(func() {
+			cue = defkit.NewCUEGenerator().GenerateFullDefinition(components.KnativeService())
+		})
+
+		It("should render scale bounds as string annotations", func() {
+			Expect(cue).To(ContainSubstring(`"autoscaling.knative.dev/min-scale": "\(parameter.minScale)"`))
+			Expect(cue).To(ContainSubstring(`"autoscaling.knative.dev/max-scale": "\(parameter.maxScale)"`))
+		})
+
+		It("should prefix the revision name with the component name", func() {
+			Expect(cue).To(ContainSubstring(`name: "\(context.name)-\(parameter.revisionSuffix)"`))
+		})
+
+		It("should render the container port and traffic", func() {
+			Expect(cue).To(ContainSubstring("containerPort: parameter.port"))
+			Expect(cue).To(ContainSubstring(`portName: *"http1" | "h2c"`))
+			Expect(cue).To(ContainSubstring("percent: int & >=0 & <=100"))
+			Expect(cue).To(ContainSubstring("traffic: parameter.traffic"))
+			Expect(cue).To(ContainSubstring("containerConcurrency: parameter.containerConcurrency"))
+		})
+
+		It("should derive status and health from the Ready condition", func() {
+			Expect(cue).To(ContainSubstring(`if c.type == "Ready"`))
+			Expect(cue).To(ContainSubstring(`message: "Ready:\(_readyStatus), url:\(status.url)\(_readyMessage)"`))
+			Expect(cue).To(ContainSubstring(`isHealth: _readyStatus == "True" && ready.observedGeneration == context.output.metadata.generation`))
+		})
+	})
+})
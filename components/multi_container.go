@@ -0,0 +1,202 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// MultiContainer creates the multi-container component definition.
+// It describes a Deployment whose pods run several named containers, init
+// containers and native sidecars sharing the pod volumes.
+//
+// Native sidecars are init containers with restartPolicy Always: they start
+// before the init containers and keep running next to the containers, which
+// requires Kubernetes 1.29 or later.
+func MultiContainer() *defkit.ComponentDefinition {
+	labels := defkit.StringKeyMap("labels").Optional().Description("Specify the labels in the workload")
+	annotations := defkit.StringKeyMap("annotations").Optional().Description("Specify the annotations in the workload")
+	imagePullSecrets := defkit.StringList("imagePullSecrets").Optional().Description("Specify image pull secrets for your service")
+
+	containers := defkit.Array("containers").
+		MinItems(1).
+		Description("Specify the containers of the pod").
+		WithFields(multiContainerFields(true)...)
+	sidecars := defkit.Array("sidecars").
+		Optional().
+		Description("Specify the native sidecars, started before the init containers and running next to the containers").
+		WithFields(multiContainerFields(true)...)
+	initContainers := defkit.Array("initContainers").
+		Optional().
+		Description("Specify the init containers, run to completion in order before the containers start").
+		WithFields(multiContainerFields(false)...)
+
+	return defkit.NewComponent("multi-container").
+		Description("Describes long-running services whose pods run several containers, init containers and native sidecars.").
+		Workload("apps/v1", "Deployment").
+		CustomStatus(defkit.DeploymentStatus().Build()).
+		HealthPolicy(defkit.DeploymentHealth().Build()).
+		Params(labels, annotations, imagePullSecrets, containers, sidecars, initContainers).
+		Params(multiContainerVolumesParam()).
+		Helper("HealthProbe", HealthProbeParam()).
+		Template(multiContainerTemplate)
+}
+
+// multiContainerFields returns the fields of a container. Probes are only
+// allowed on containers and sidecars, not on init containers.
+func multiContainerFields(probes bool) []defkit.Param {
+	fields := []defkit.Param{
+		defkit.String("name").Description("Name of the container, unique in the pod"),
+		defkit.String("image").Description("Image of the container"),
+		defkit.Enum("imagePullPolicy").Optional().Values("Always", "Never", "IfNotPresent").Description("Specify image pull policy of the container"),
+		defkit.StringList("cmd").Optional().Description("Commands to run in the container"),
+		defkit.StringList("args").Optional().Description("Arguments to the entrypoint"),
+		podEnvParam(),
+		defkit.Array("ports").Optional().Description("Ports the container listens on").WithFields(
+			defkit.Int("containerPort").Description("Number of port to expose on the pod's IP address"),
+			defkit.String("name").Optional().Description("Name of the port"),
+			defkit.Enum("protocol").Values("TCP", "UDP", "SCTP").Default("TCP").Description("Protocol for port. Must be UDP, TCP, or SCTP"),
+		),
+		defkit.String("cpu").Optional().Description("Number of CPU units for the container, like `0.5` (0.5 CPU core), `1` (1 CPU core)"),
+		defkit.String("memory").Optional().Description("Specifies the attributes of the memory resource required for the container."),
+		defkit.Array("volumeMounts").Optional().Description("Mount the shared volumes into the container").WithFields(
+			defkit.String("name").Description("Name of a volume in volumes"),
+			defkit.String("mountPath").Description("Path the volume is mounted at"),
+			defkit.String("subPath").Optional().Description("Path within the volume to mount"),
+			defkit.Bool("readOnly").Optional().Description("Mount the volume read-only"),
+		),
+	}
+	if probes {
+		fields = append(fields,
+			defkit.Map("livenessProbe").Optional().Description("Instructions for assessing whether the container is alive.").WithSchemaRef("HealthProbe"),
+			defkit.Map("readinessProbe").Optional().Description("Instructions for assessing whether the container is in a suitable state to serve traffic.").WithSchemaRef("HealthProbe"),
+		)
+	}
+	return fields
+}
+
+// multiContainerVolumesParam returns the pod volumes shared by all containers,
+// typed like the volumeMounts sources of the other pod-based components.
+func multiContainerVolumesParam() defkit.Param {
+	return defkit.Object("volumes").
+		Optional().
+		Description("Specify the volumes of the pod, mounted by name in the containers").
+		WithFields(
+			defkit.List("pvc").Optional().Description("PVC type volumes").WithFields(
+				defkit.String("name"),
+				defkit.String("claimName").Description("The name of the PVC"),
+			),
+			defkit.List("configMap").Optional().Description("ConfigMap type volumes").WithFields(
+				defkit.String("name"),
+				defkit.Int("defaultMode").Default(420),
+				defkit.String("cmName"),
+				defkit.List("items").Optional().WithFields(
+					defkit.String("key"),
+					defkit.String("path"),
+					defkit.Int("mode").Default(511),
+				),
+			),
+			defkit.List("secret").Optional().Description("Secret type volumes").WithFields(
+				defkit.String("name"),
+				defkit.Int("defaultMode").Default(420),
+				defkit.String("secretName"),
+				defkit.List("items").Optional().WithFields(
+					defkit.String("key"),
+					defkit.String("path"),
+					defkit.Int("mode").Default(511),
+				),
+			),
+			defkit.List("emptyDir").Optional().Description("EmptyDir type volumes").WithFields(
+				defkit.String("name"),
+				defkit.Enum("medium").Values("", "Memory").Default(""),
+			),
+			defkit.List("hostPath").Optional().Description("HostPath type volumes").WithFields(
+				defkit.String("name"),
+				defkit.String("path"),
+			),
+		)
+}
+
+// multiContainerItem maps a container parameter to a Kubernetes container.
+// cpu and memory set both requests and limits, and resources is only rendered
+// when one of them is set.
+func multiContainerItem(probes, sidecar bool) func(item *defkit.ItemBuilder) {
+	optional := []string{"imagePullPolicy", "cmd", "args", "env", "ports", "volumeMounts"}
+	if probes {
+		optional = append(optional, "livenessProbe", "readinessProbe")
+	}
+	return func(item *defkit.ItemBuilder) {
+		v := item.Var()
+		item.Set("name", v.Field("name"))
+		item.Set("image", v.Field("image"))
+		for _, field := range optional {
+			name := field
+			if field == "cmd" {
+				name = "command"
+			}
+			item.IfSet(field, func() {
+				item.Set(name, v.Field(field))
+			})
+		}
+		item.If(defkit.Or(item.FieldExists("cpu"), item.FieldExists("memory")), func() {
+			item.Let("_resources", defkit.Reference(`{if v.cpu != _|_ {cpu: v.cpu}, if v.memory != _|_ {memory: v.memory}}`))
+			item.Set("resources", defkit.Reference(`{requests: _resources, limits: _resources}`))
+		})
+		if sidecar {
+			item.Set("restartPolicy", defkit.Lit("Always"))
+		}
+	}
+}
+
+// multiContainerTemplate defines the template function for multi-container.
+func multiContainerTemplate(tpl *defkit.Template) {
+	vela := defkit.VelaCtx()
+	labels := defkit.Object("labels")
+	annotations := defkit.Object("annotations")
+	imagePullSecrets := defkit.StringList("imagePullSecrets")
+	containers := defkit.List("containers")
+	sidecars := defkit.List("sidecars")
+	initContainers := defkit.List("initContainers")
+	volumes := defkit.Object("volumes")
+
+	sidecarsArray := tpl.Helper("sidecarsArray").
+		FromArray(defkit.NewArray().ForEachWithGuardedFiltered(sidecars.IsSet(), nil, sidecars, multiContainerItem(true, true))).
+		Build()
+	initContainersArray := tpl.Helper("initContainersArray").
+		FromArray(defkit.NewArray().ForEachWithGuardedFiltered(initContainers.IsSet(), nil, initContainers, multiContainerItem(false, false))).
+		Build()
+	podVolumes := PodVolumesDedupedHelper(tpl, volumes)
+
+	deployment := defkit.NewResource("apps/v1", "Deployment").
+		Set("spec.selector.matchLabels[app.oam.dev/component]", vela.Name()).
+		SpreadIf(labels.IsSet(), "spec.template.metadata.labels", labels).
+		Set("spec.template.metadata.labels[app.oam.dev/name]", vela.AppName()).
+		Set("spec.template.metadata.labels[app.oam.dev/component]", vela.Name()).
+		SetIf(annotations.IsSet(), "spec.template.metadata.annotations", annotations).
+		Set("spec.template.spec.containers", defkit.NewArray().ForEachWith(containers, multiContainerItem(true, false))).
+		// Sidecars come first so they already run during the init containers
+		SetIf(defkit.Or(sidecars.IsSet(), initContainers.IsSet()), "spec.template.spec.initContainers",
+			defkit.ListConcat(defkit.Reference("["+sidecarsArray.Name()+", "+initContainersArray.Name()+"]"))).
+		SetIf(volumes.IsSet(), "spec.template.spec.volumes", podVolumes).
+		SetIf(imagePullSecrets.IsSet(), "spec.template.spec.imagePullSecrets", ImagePullSecretsTransform(imagePullSecrets))
+
+	tpl.Output(deployment)
+}
+
+func init() {
+	defkit.Register(MultiContainer())
+}
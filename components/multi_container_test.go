@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("MultiContainer Component", func() {
+	Describe("MultiContainer()", func() {
+		It("should create a multi-container component definition", func() {
+			comp := components.MultiContainer()
+			Expect(comp.GetName()).To(Equal("multi-container"))
+			Expect(comp.GetDescription()).To(ContainSubstring("several containers"))
+		})
+
+		It("should have apps/v1 Deployment workload", func() {
+			comp := components.MultiContainer()
+			workload := comp.GetWorkload()
+			Expect(workload.APIVersion()).To(Equal("apps/v1"))
+			Expect(workload.Kind()).To(Equal("Deployment"))
+		})
+
+		It("should have container list parameters", func() {
+			comp := components.MultiContainer()
+			Expect(comp).To(HaveParamNamed("containers"))
+			Expect(comp).To(HaveParamNamed("sidecars"))
+			Expect(comp).To(HaveParamNamed("initContainers"))
+			Expect(comp).To(HaveParamNamed("volumes"))
+			Expect(comp).NotTo(HaveParamNamed("image"))
+		})
+
+		It("should execute template and produce Deployment output", func() {
+			comp := components.MultiContainer()
+			tpl := defkit.NewTemplate()
+			comp.GetTemplate()(tpl)
+			Expect(tpl.GetOutput()).To(BeResourceOfKind("Deployment"))
+		})
+
+		It("should have HealthProbe helper", func() {
+			comp := components.MultiContainer()
+			helperNames := make([]string, 0)
+			for _, h := range comp.GetHelperDefinitions() {
+				helperNames = append(helperNames, h.GetName())
+			}
+			Expect(helperNames).To(ContainElement("HealthProbe"))
+		})
+	})
+
+	Describe("MultiContainer CUE generation", func() {
+		var cue string
+
+		BeforeEach(func() {
+			cue = defkit.NewCUEGenerator().GenerateFullDefinition(components.MultiContainer())
+		})
+
+		It("should require at least one container", func() {
+			Expect(cue).To(ContainSubstring("containers: list.MinItems(1) & [...{"))
+		})
+
+		It("should map every container into the pod", func() {
+			Expect(cue).To(MatchRegexp(`containers: \[\s*for v in parameter.containers \{`))
+			Expect(cue).To(ContainSubstring("command: v.cmd"))
+			Expect(cue).To(ContainSubstring("volumeMounts: v.volumeMounts"))
+		})
+
+		It("should only render resources when cpu or memory is set", func() {
+			Expect(cue).To(ContainSubstring("if v.cpu != _|_ || v.memory != _|_ {"))
+			Expect(cue).To(ContainSubstring("resources: {requests: _resources, limits: _resources}"))
+			Expect(cue).NotTo(MatchRegexp(`\n\s*resources: \{\n`))
+		})
+
+		It("should run sidecars as restartable init containers ahead of the init containers", func() {
+			Expect(cue).To(ContainSubstring(`restartPolicy: "Always"`))
+			Expect(cue).To(ContainSubstring("initContainers: list.Concat([sidecarsArray, initContainersArray])"))
+			// Only the sidecars are restartable
+			initIdx := strings.Index(cue, "initContainersArray: [")
+			Expect(initIdx).To(BeNumerically(">", 0))
+			Expect(cue[initIdx : strings.Index(cue[initIdx:], "]\n")+initIdx]).NotTo(ContainSubstring("restartPolicy"))
+		})
+
+		It("should not allow probes on init containers", func() {
+			initIdx := strings.Index(cue, "initContainers?: [...{")
+			Expect(initIdx).To(BeNumerically(">", 0))
+			volumesIdx := strings.Index(cue[initIdx:], "volumes?:")
+			Expect(volumesIdx).To(BeNumerically(">", 0))
+			Expect(cue[initIdx : initIdx+volumesIdx]).NotTo(ContainSubstring("livenessProbe"))
+		})
+
+		It("should deduplicate shared pod volumes", func() {
+			Expect(cue).To(ContainSubstring("deDupVolumesList"))
+			Expect(cue).To(ContainSubstring("volumes: deDupVolumesList"))
+		})
+	})
+})
@@ -0,0 +1,291 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ObjectHealth is the health and status rule of one kind of Kubernetes
+// object. Unlike the defkit health builders, which read context.output, a
+// rule reads the live object at any CUE path, so it can check each object of
+// a component that renders or references several of them.
+type ObjectHealth struct {
+	name       string
+	apiVersion string
+	kind       string
+	// fields is the CUE defaulting the status of the object at %[1]s into
+	// _st, so that fields not reported yet can be read
+	fields  string
+	healthy string
+	message string
+}
+
+// Name returns the name the rule is selected by, e.g. in the healthPolicy of
+// a k8s-objects object.
+func (h *ObjectHealth) Name() string { return h.name }
+
+// Build generates the healthPolicy of a component whose output is checked
+// by the rule. The output is unhealthy until it reports a status.
+func (h *ObjectHealth) Build() string {
+	return "healthy: *false | bool\nif context.output.status != _|_ {\n" +
+		indentCUE(h.check("context.output"), "\t") + "\n}\nisHealth: healthy"
+}
+
+// BuildStatus generates the customStatus of a component whose output is
+// checked by the rule.
+func (h *ObjectHealth) BuildStatus() string {
+	return "message: *\"\" | string\nif context.output.status != _|_ {\n" +
+		indentCUE(h.status("context.output"), "\t") + "\n}"
+}
+
+// check returns the CUE setting healthy for the object at obj.
+func (h *ObjectHealth) check(obj string) string {
+	return fmt.Sprintf(h.fields+"\nhealthy: "+h.healthy, obj)
+}
+
+// status returns the CUE setting message for the object at obj.
+func (h *ObjectHealth) status(obj string) string {
+	return fmt.Sprintf(h.fields+"\nmessage: \""+h.message+"\"", obj)
+}
+
+// DeploymentObjectHealth checks that all replicas of a Deployment are
+// updated and ready.
+func DeploymentObjectHealth() *ObjectHealth {
+	return &ObjectHealth{
+		name:       "Deployment",
+		apiVersion: "apps/v1",
+		kind:       "Deployment",
+		fields: `_st: {
+	replicas:           *0 | int
+	readyReplicas:      *0 | int
+	updatedReplicas:    *0 | int
+	observedGeneration: *0 | int
+	...
+} & %[1]s.status`,
+		healthy: `%[1]s.spec.replicas == _st.readyReplicas && %[1]s.spec.replicas == _st.updatedReplicas && %[1]s.spec.replicas == _st.replicas && _st.observedGeneration >= %[1]s.metadata.generation`,
+		message: `Ready:\(_st.readyReplicas)/\(%[1]s.spec.replicas)`,
+	}
+}
+
+// StatefulSetObjectHealth checks that all replicas of a StatefulSet are
+// updated and ready.
+func StatefulSetObjectHealth() *ObjectHealth {
+	return &ObjectHealth{
+		name:       "StatefulSet",
+		apiVersion: "apps/v1",
+		kind:       "StatefulSet",
+		fields: `_st: {
+	replicas:           *0 | int
+	readyReplicas:      *0 | int
+	updatedReplicas:    *0 | int
+	observedGeneration: *0 | int
+	...
+} & %[1]s.status`,
+		healthy: `%[1]s.spec.replicas == _st.readyReplicas && %[1]s.spec.replicas == _st.updatedReplicas && _st.observedGeneration >= %[1]s.metadata.generation`,
+		message: `Ready:\(_st.readyReplicas)/\(%[1]s.spec.replicas)`,
+	}
+}
+
+// DaemonSetObjectHealth checks that the pods of a DaemonSet are updated and
+// ready on all the nodes they are scheduled on. With the OnDelete update
+// strategy, old pods are only replaced when deleted, so they are not waited
+// for.
+func DaemonSetObjectHealth() *ObjectHealth {
+	return &ObjectHealth{
+		name:       "DaemonSet",
+		apiVersion: "apps/v1",
+		kind:       "DaemonSet",
+		fields: `_st: {
+	desiredNumberScheduled: *0 | int
+	numberReady:            *0 | int
+	updatedNumberScheduled: *0 | int
+	observedGeneration:     *0 | int
+	...
+} & %[1]s.status
+_onDelete: [if %[1]s.spec.updateStrategy != _|_ if %[1]s.spec.updateStrategy.type == "OnDelete" {true}, false][0]`,
+		healthy: `_st.desiredNumberScheduled == _st.numberReady && (_onDelete || _st.desiredNumberScheduled == _st.updatedNumberScheduled) && _st.observedGeneration >= %[1]s.metadata.generation`,
+		message: `Ready:\(_st.numberReady)/\(_st.desiredNumberScheduled)`,
+	}
+}
+
+// JobObjectHealth checks that a Job ran all its completions.
+func JobObjectHealth() *ObjectHealth {
+	return &ObjectHealth{
+		name:       "Job",
+		apiVersion: "batch/v1",
+		kind:       "Job",
+		fields: `_st: {
+	succeeded: *0 | int
+	...
+} & %[1]s.status
+_completions: *1 | int
+if %[1]s.spec.completions != _|_ {
+	_completions: %[1]s.spec.completions
+}`,
+		healthy: `_st.succeeded >= _completions`,
+		message: `Succeeded:\(_st.succeeded)/\(_completions)`,
+	}
+}
+
+// CronJobObjectHealth reports the jobs of a CronJob, which is healthy as
+// soon as it exists.
+func CronJobObjectHealth() *ObjectHealth {
+	return &ObjectHealth{
+		name:       "CronJob",
+		apiVersion: "batch/v1",
+		kind:       "CronJob",
+		fields: `_st: {
+	active:           *[] | [...]
+	lastScheduleTime: *"never" | string
+	...
+} & %[1]s.status`,
+		healthy: `true`,
+		message: `Active:\(len(_st.active)), last schedule:\(_st.lastScheduleTime)`,
+	}
+}
+
+// PersistentVolumeClaimObjectHealth checks that a PVC is bound to a volume.
+func PersistentVolumeClaimObjectHealth() *ObjectHealth {
+	return &ObjectHealth{
+		name:       "PersistentVolumeClaim",
+		apiVersion: "v1",
+		kind:       "PersistentVolumeClaim",
+		fields: `_st: {
+	phase: *"Pending" | string
+	...
+} & %[1]s.status`,
+		healthy: `_st.phase == "Bound"`,
+		message: `Phase:\(_st.phase)`,
+	}
+}
+
+// ServiceObjectHealth checks that a LoadBalancer Service got an ingress
+// address. Services of the other types are healthy as soon as they exist.
+func ServiceObjectHealth() *ObjectHealth {
+	return &ObjectHealth{
+		name:       "Service",
+		apiVersion: "v1",
+		kind:       "Service",
+		fields: `_st: {
+	loadBalancer: {
+		ingress: *[] | [...]
+		...
+	}
+	...
+} & %[1]s.status
+_addresses: [
+	for i in _st.loadBalancer.ingress if i.ip != _|_ {i.ip},
+	for i in _st.loadBalancer.ingress if i.ip == _|_ && i.hostname != _|_ {i.hostname},
+	"pending",
+]
+_ingress: [if %[1]s.spec.type == "LoadBalancer" {", ingress:\(_addresses[0])"}, ""][0]`,
+		healthy: `%[1]s.spec.type != "LoadBalancer" || len(_st.loadBalancer.ingress) > 0`,
+		message: `Type:\(%[1]s.spec.type)\(_ingress)`,
+	}
+}
+
+// ReadyConditionObjectHealth checks the Ready condition reported by an
+// object of any kind, like most custom resources.
+func ReadyConditionObjectHealth() *ObjectHealth {
+	return &ObjectHealth{
+		name: "Ready",
+		fields: `_st: {
+	conditions: *[] | [...]
+	...
+} & %[1]s.status
+_ready: [for c in _st.conditions if c.type == "Ready" {c.status}, "Unknown"][0]`,
+		healthy: `_ready == "True"`,
+		message: `Ready:\(_ready)`,
+	}
+}
+
+// objectHealthRules are the rules users can select, in the order listed.
+// Rules of a kind come before the Ready condition, which applies to any.
+var objectHealthRules = []*ObjectHealth{
+	DeploymentObjectHealth(),
+	StatefulSetObjectHealth(),
+	DaemonSetObjectHealth(),
+	JobObjectHealth(),
+	CronJobObjectHealth(),
+	PersistentVolumeClaimObjectHealth(),
+	ServiceObjectHealth(),
+	ReadyConditionObjectHealth(),
+}
+
+// ObjectHealthRuleNames returns the names of the object health rules.
+func ObjectHealthRuleNames() []string {
+	names := make([]string, 0, len(objectHealthRules))
+	for _, r := range objectHealthRules {
+		names = append(names, r.name)
+	}
+	return names
+}
+
+// objectHealthCheck returns the CUE setting healthy for the object at obj,
+// with the rule named by the CUE expression policy. Objects are unhealthy
+// until they report a status.
+func objectHealthCheck(policy, obj string) string {
+	var sb strings.Builder
+	sb.WriteString("healthy: *false | bool\n")
+	fmt.Fprintf(&sb, "if %s.status != _|_ {\n", obj)
+	for _, r := range objectHealthRules {
+		fmt.Fprintf(&sb, "\tif %s == %q {\n", policy, r.name)
+		sb.WriteString(indentCUE(r.check(obj), "\t\t") + "\n")
+		sb.WriteString("\t}\n")
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// objectStatusMessage returns the CUE setting message for the object at obj,
+// with the rule named by the CUE expression policy.
+func objectStatusMessage(policy, obj string) string {
+	var sb strings.Builder
+	sb.WriteString("message: *\"\" | string\n")
+	fmt.Fprintf(&sb, "if %s.status != _|_ {\n", obj)
+	for _, r := range objectHealthRules {
+		fmt.Fprintf(&sb, "\tif %s == %q {\n", policy, r.name)
+		sb.WriteString(indentCUE(r.status(obj), "\t\t") + "\n")
+		sb.WriteString("\t}\n")
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// objectHealthRuleOf returns the CUE setting _rule to the name of the rule
+// of the object at obj: the rule of its kind, else the Ready condition if it
+// reports one, else "" when no rule applies.
+func objectHealthRuleOf(obj string) string {
+	var sb strings.Builder
+	sb.WriteString("_rules: [\n")
+	for _, r := range objectHealthRules {
+		if r.kind == "" {
+			continue
+		}
+		fmt.Fprintf(&sb, "\tif %[1]s.apiVersion == %[2]q && %[1]s.kind == %[3]q {%[4]q},\n", obj, r.apiVersion, r.kind, r.name)
+	}
+	fmt.Fprintf(&sb, "\tif %[1]s.status.conditions != _|_ for c in %[1]s.status.conditions if c.type == \"Ready\" {%[2]q},\n", obj, ReadyConditionObjectHealth().name)
+	sb.WriteString("\t\"\",\n]\n_rule: _rules[0]")
+	return sb.String()
+}
+
+// indentCUE indents each line of the CUE by prefix.
+func indentCUE(cue, prefix string) string {
+	return prefix + strings.ReplaceAll(cue, "\n", "\n"+prefix)
+}
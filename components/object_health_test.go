@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+)
+
+var _ = Describe("Object health rules", func() {
+	It("should check the output of a component", func() {
+		health := components.DaemonSetObjectHealth().Build()
+		Expect(health).To(HavePrefix("healthy: *false | bool\nif context.output.status != _|_ {"))
+		Expect(health).To(ContainSubstring("} & context.output.status"))
+		Expect(health).To(ContainSubstring("_st.observedGeneration >= context.output.metadata.generation"))
+		Expect(health).To(HaveSuffix("isHealth: healthy"))
+	})
+
+	It("should report the status of the output of a component", func() {
+		status := components.JobObjectHealth().BuildStatus()
+		Expect(status).To(HavePrefix(`message: *"" | string`))
+		Expect(status).To(ContainSubstring("if context.output.spec.completions != _|_ {"))
+		Expect(status).To(ContainSubstring(`message: "Succeeded:\(_st.succeeded)/\(_completions)"`))
+	})
+
+	It("should not wait for DaemonSet pods replaced on delete", func() {
+		health := components.DaemonSetObjectHealth().Build()
+		Expect(health).To(ContainSubstring(`if context.output.spec.updateStrategy.type == "OnDelete" {true}, false][0]`))
+		Expect(health).To(ContainSubstring("(_onDelete || _st.desiredNumberScheduled == _st.updatedNumberScheduled)"))
+	})
+
+	It("should check PVCs are bound", func() {
+		Expect(components.PersistentVolumeClaimObjectHealth().Build()).To(ContainSubstring(`healthy: _st.phase == "Bound"`))
+	})
+
+	It("should only wait for LoadBalancer Services to get an ingress", func() {
+		Expect(components.ServiceObjectHealth().Build()).To(ContainSubstring(
+			`healthy: context.output.spec.type != "LoadBalancer" || len(_st.loadBalancer.ingress) > 0`))
+	})
+
+	It("should read the Ready condition of any object", func() {
+		rule := components.ReadyConditionObjectHealth()
+		Expect(rule.Name()).To(Equal("Ready"))
+		Expect(rule.Build()).To(ContainSubstring(`_ready: [for c in _st.conditions if c.type == "Ready" {c.status}, "Unknown"][0]`))
+	})
+
+	Describe("evaluated on live objects", func() {
+		// eval evaluates the policy built by a rule on the live output.
+		eval := func(policy, live string) cue.Value {
+			v := cuecontext.New().CompileString(policy + "\ncontext: output: " + live)
+			Expect(v.Err()).NotTo(HaveOccurred())
+			return v
+		}
+
+		for _, c := range []struct {
+			rule               *components.ObjectHealth
+			healthy, unhealthy string
+			message            string
+		}{{
+			rule:      components.DeploymentObjectHealth(),
+			healthy:   `{metadata: generation: 2, spec: replicas: 3, status: {replicas: 3, readyReplicas: 3, updatedReplicas: 3, observedGeneration: 2}}`,
+			unhealthy: `{metadata: generation: 2, spec: replicas: 3, status: {replicas: 3, readyReplicas: 3, updatedReplicas: 3, observedGeneration: 1}}`,
+			message:   "Ready:3/3",
+		}, {
+			rule:      components.StatefulSetObjectHealth(),
+			healthy:   `{metadata: generation: 1, spec: replicas: 2, status: {replicas: 2, readyReplicas: 2, updatedReplicas: 2, observedGeneration: 1}}`,
+			unhealthy: `{metadata: generation: 1, spec: replicas: 2, status: {replicas: 2, readyReplicas: 2, updatedReplicas: 1, observedGeneration: 1}}`,
+			message:   "Ready:2/2",
+		}, {
+			rule:      components.DaemonSetObjectHealth(),
+			healthy:   `{metadata: generation: 1, spec: updateStrategy: type: "OnDelete", status: {desiredNumberScheduled: 3, numberReady: 3, updatedNumberScheduled: 1, observedGeneration: 1}}`,
+			unhealthy: `{metadata: generation: 1, spec: {}, status: {desiredNumberScheduled: 3, numberReady: 3, updatedNumberScheduled: 1, observedGeneration: 1}}`,
+			message:   "Ready:3/3",
+		}, {
+			rule:      components.JobObjectHealth(),
+			healthy:   `{spec: completions: 2, status: succeeded: 2}`,
+			unhealthy: `{spec: completions: 2, status: succeeded: 1}`,
+			message:   "Succeeded:2/2",
+		}, {
+			rule:      components.CronJobObjectHealth(),
+			healthy:   `{spec: {}, status: {}}`,
+			unhealthy: `{spec: {}}`,
+			message:   "Active:0, last schedule:never",
+		}, {
+			rule:      components.PersistentVolumeClaimObjectHealth(),
+			healthy:   `{spec: {}, status: phase: "Bound"}`,
+			unhealthy: `{spec: {}, status: phase: "Pending"}`,
+			message:   "Phase:Bound",
+		}, {
+			rule:      components.ServiceObjectHealth(),
+			healthy:   `{spec: type: "LoadBalancer", status: loadBalancer: ingress: [{hostname: "lb.example.com"}]}`,
+			unhealthy: `{spec: type: "LoadBalancer", status: loadBalancer: {}}`,
+			message:   "Type:LoadBalancer, ingress:lb.example.com",
+		}, {
+			rule:      components.ReadyConditionObjectHealth(),
+			healthy:   `{status: conditions: [{type: "Synced", status: "False"}, {type: "Ready", status: "True"}]}`,
+			unhealthy: `{status: conditions: [{type: "Ready", status: "False"}]}`,
+			message:   "Ready:True",
+		}} {
+			It("should check the "+c.rule.Name()+" rule", func() {
+				Expect(field(eval(c.rule.Build(), c.healthy), "isHealth").Bool()).To(BeTrue())
+				Expect(field(eval(c.rule.Build(), c.unhealthy), "isHealth").Bool()).To(BeFalse())
+				Expect(lookup(eval(c.rule.BuildStatus(), c.healthy), "message")).To(Equal(c.message))
+			})
+		}
+
+		It("should only wait for LoadBalancer Services", func() {
+			v := eval(components.ServiceObjectHealth().BuildStatus(), `{spec: type: "ClusterIP", status: {}}`)
+			Expect(lookup(v, "message")).To(Equal("Type:ClusterIP"))
+			Expect(field(eval(components.ServiceObjectHealth().Build(), `{spec: type: "ClusterIP", status: {}}`), "isHealth").Bool()).To(BeTrue())
+		})
+	})
+})
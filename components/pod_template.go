@@ -0,0 +1,602 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// Pod template library shared by the pod-based components (webservice, worker,
+// statefulset, daemon, task, cron-task).
+//
+// Each parameter group is declared once and rendered into a workload's pod
+// template by PodTemplate, so a pod-level feature is added in one place:
+//
+//	defkit.NewComponent("worker").
+//		Params(PodContainerParams()...).
+//		Params(PodResourceParams()...).
+//		Params(PodVolumeParams()...).
+//		Params(PodProbeParams()...).
+//		Params(PodSchedulingParams()...).
+//		Helper("HealthProbe", HealthProbeParam()).
+//		Template(func(tpl *defkit.Template) {
+//			deployment := defkit.NewResource("apps/v1", "Deployment")
+//			NewPodTemplate(tpl, "spec.template").Apply(deployment)
+//			tpl.Output(deployment)
+//		})
+//
+// The lifecycle, security and placement groups are opt-in: a component
+// declaring them renders them with ApplyLifecycle, ApplySecurity and
+// ApplyPlacement. Their fields are rendered as CUE defaults, so a trait
+// patching the same field, e.g. securitycontext or affinity, replaces the
+// value of the parameter instead of conflicting with it.
+
+// PodParamGroup names a group of shared pod template parameters.
+type PodParamGroup string
+
+const (
+	// PodContainerGroup is the main container: image, pull policy and secrets, command, args and env.
+	PodContainerGroup PodParamGroup = "container"
+	// PodResourcesGroup is the main container's cpu and memory requests and limits.
+	PodResourcesGroup PodParamGroup = "resources"
+	// PodVolumesGroup is volumeMounts and the deprecated volumes parameter.
+	PodVolumesGroup PodParamGroup = "volumes"
+	// PodProbesGroup is the liveness and readiness probes, typed by the #HealthProbe helper.
+	PodProbesGroup PodParamGroup = "probes"
+	// PodSchedulingGroup is pod-level placement and host settings.
+	PodSchedulingGroup PodParamGroup = "scheduling"
+)
+
+// PodParamGroups returns the parameters of every shared group.
+func PodParamGroups() map[PodParamGroup][]defkit.Param {
+	return map[PodParamGroup][]defkit.Param{
+		PodContainerGroup:  PodContainerParams(),
+		PodResourcesGroup:  PodResourceParams(),
+		PodVolumesGroup:    PodVolumeParams(),
+		PodProbesGroup:     PodProbeParams(),
+		PodSchedulingGroup: PodSchedulingParams(),
+	}
+}
+
+// --- Parameter groups ---
+
+// PodContainerParams returns image, imagePullPolicy, imagePullSecrets, cmd, args and env.
+func PodContainerParams() []defkit.Param {
+	return []defkit.Param{
+		defkit.String("image").Description("Which image would you like to use for your service").Short("i"),
+		defkit.Enum("imagePullPolicy").
+			Optional().
+			Values("Always", "Never", "IfNotPresent").
+			Description("Specify image pull policy for your service"),
+		defkit.StringList("imagePullSecrets").
+			Optional().
+			Description("Specify image pull secrets for your service"),
+		defkit.StringList("cmd").Optional().Description("Commands to run in the container"),
+		defkit.StringList("args").Optional().Description("Arguments to the entrypoint"),
+		podEnvParam(),
+	}
+}
+
+// podEnvParam returns the env parameter of a container.
+func podEnvParam() *defkit.ArrayParam {
+	return defkit.List("env").
+		Optional().
+		Description("Define arguments by using environment variables").
+		WithFields(
+			defkit.String("name").Description("Environment variable name"),
+			defkit.String("value").Optional().Description("The value of the environment variable"),
+			defkit.Object("valueFrom").Optional().Description("Specifies a source the value of this var should come from").
+				WithFields(
+					defkit.Object("secretKeyRef").Optional().Description("Selects a key of a secret in the pod's namespace").
+						WithFields(
+							defkit.String("name").Description("The name of the secret in the pod's namespace to select from"),
+							defkit.String("key").Description("The key of the secret to select from. Must be a valid secret key"),
+						),
+					defkit.Object("configMapKeyRef").Optional().Description("Selects a key of a config map in the pod's namespace").
+						WithFields(
+							defkit.String("name").Description("The name of the config map in the pod's namespace to select from"),
+							defkit.String("key").Description("The key of the config map to select from. Must be a valid secret key"),
+						),
+				),
+		)
+}
+
+// PodResourceParams returns cpu, memory and limit.
+// cpu and memory set both requests and limits unless limit overrides the limit.
+func PodResourceParams() []defkit.Param {
+	return []defkit.Param{
+		defkit.String("cpu").Optional().Description("Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)"),
+		defkit.String("memory").Optional().Description("Specifies the attributes of the memory resource required for the container."),
+		defkit.Object("limit").Optional().WithFields(
+			defkit.String("cpu").Optional(),
+			defkit.String("memory").Optional(),
+		),
+	}
+}
+
+// PodVolumeParams returns volumeMounts and the deprecated volumes parameter.
+func PodVolumeParams() []defkit.Param {
+	return []defkit.Param{
+		defkit.Object("volumeMounts").
+			Optional().
+			WithFields(
+				defkit.List("pvc").Optional().Description("Mount PVC type volume").WithFields(
+					defkit.String("name"),
+					defkit.String("mountPath"),
+					defkit.String("subPath").Optional(),
+					defkit.String("claimName").Description("The name of the PVC"),
+				),
+				defkit.List("configMap").Optional().Description("Mount ConfigMap type volume").WithFields(
+					defkit.String("name"),
+					defkit.String("mountPath"),
+					defkit.String("subPath").Optional(),
+					defkit.Int("defaultMode").Default(420),
+					defkit.String("cmName"),
+					defkit.List("items").Optional().WithFields(
+						defkit.String("key"),
+						defkit.String("path"),
+						defkit.Int("mode").Default(511),
+					),
+				),
+				defkit.List("secret").Optional().Description("Mount Secret type volume").WithFields(
+					defkit.String("name"),
+					defkit.String("mountPath"),
+					defkit.String("subPath").Optional(),
+					defkit.Int("defaultMode").Default(420),
+					defkit.String("secretName"),
+					defkit.List("items").Optional().WithFields(
+						defkit.String("key"),
+						defkit.String("path"),
+						defkit.Int("mode").Default(511),
+					),
+				),
+				defkit.List("emptyDir").Optional().Description("Mount EmptyDir type volume").WithFields(
+					defkit.String("name"),
+					defkit.String("mountPath"),
+					defkit.String("subPath").Optional(),
+					defkit.Enum("medium").Values("", "Memory").Default(""),
+				),
+				defkit.List("hostPath").Optional().Description("Mount HostPath type volume").WithFields(
+					defkit.String("name"),
+					defkit.String("mountPath"),
+					defkit.String("subPath").Optional(),
+					defkit.Enum("mountPropagation").Optional().Values("None", "HostToContainer", "Bidirectional"),
+					defkit.String("path"),
+					defkit.Bool("readOnly").Optional(),
+				),
+			),
+		// Deprecated volumes parameter - discriminated union with type-based conditional fields
+		defkit.List("volumes").Optional().Description("Deprecated field, use volumeMounts instead.").
+			WithFields(
+				defkit.String("name"),
+				defkit.String("mountPath"),
+				defkit.OneOf("type").
+					Description(`Specify volume type, options: "pvc","configMap","secret","emptyDir", default to emptyDir`).
+					Default("emptyDir").
+					Variants(
+						defkit.Variant("pvc").WithFields(
+							defkit.Field("claimName", defkit.ParamTypeString),
+						),
+						defkit.Variant("configMap").WithFields(
+							defkit.Field("defaultMode", defkit.ParamTypeInt).Default(420),
+							defkit.Field("cmName", defkit.ParamTypeString),
+							defkit.Field("items", defkit.ParamTypeArray).Optional().Nested(
+								defkit.Struct("").WithFields(
+									defkit.Field("key", defkit.ParamTypeString),
+									defkit.Field("path", defkit.ParamTypeString),
+									defkit.Field("mode", defkit.ParamTypeInt).Default(511),
+								),
+							),
+						),
+						defkit.Variant("secret").WithFields(
+							defkit.Field("defaultMode", defkit.ParamTypeInt).Default(420),
+							defkit.Field("secretName", defkit.ParamTypeString),
+							defkit.Field("items", defkit.ParamTypeArray).Optional().Nested(
+								defkit.Struct("").WithFields(
+									defkit.Field("key", defkit.ParamTypeString),
+									defkit.Field("path", defkit.ParamTypeString),
+									defkit.Field("mode", defkit.ParamTypeInt).Default(511),
+								),
+							),
+						),
+						defkit.Variant("emptyDir").WithFields(
+							defkit.Field("medium", defkit.ParamTypeString).Default("").Values("", "Memory"),
+						),
+					),
+			),
+	}
+}
+
+// PodProbeParams returns livenessProbe and readinessProbe.
+// Components using them must register Helper("HealthProbe", HealthProbeParam()).
+func PodProbeParams() []defkit.Param {
+	return []defkit.Param{
+		defkit.Object("livenessProbe").
+			Optional().
+			Description("Instructions for assessing whether the container is alive.").
+			WithSchemaRef("HealthProbe"),
+		defkit.Object("readinessProbe").
+			Optional().
+			Description("Instructions for assessing whether the container is in a suitable state to serve traffic.").
+			WithSchemaRef("HealthProbe"),
+	}
+}
+
+// PodSchedulingParams returns the pod-level placement and host parameters.
+func PodSchedulingParams() []defkit.Param {
+	return []defkit.Param{
+		defkit.List("hostAliases").
+			Optional().
+			Description("Specify the hostAliases to add").
+			WithFields(
+				defkit.String("ip"),
+				defkit.StringList("hostnames"),
+			),
+	}
+}
+
+// PodLifecycleParams returns startupProbe and terminationGracePeriodSeconds.
+// Components using them must register Helper("HealthProbe", HealthProbeParam()).
+func PodLifecycleParams() []defkit.Param {
+	return []defkit.Param{
+		defkit.Object("startupProbe").
+			Optional().
+			Description("Instructions for assessing whether the container has started, the other probes wait for it to succeed.").
+			WithSchemaRef("HealthProbe"),
+		defkit.Int("terminationGracePeriodSeconds").
+			Optional().
+			Min(0).
+			Description("Number of seconds the pod is given to terminate gracefully before it is killed"),
+	}
+}
+
+// PodSecurityParams returns the container and pod security contexts and
+// serviceAccountName.
+func PodSecurityParams() []defkit.Param {
+	return []defkit.Param{
+		defkit.Object("securityContext").
+			Optional().
+			Description("Specify the security context of the container").
+			WithFields(
+				defkit.Bool("allowPrivilegeEscalation").Optional().Description("Whether a process can gain more privileges than its parent process"),
+				defkit.Bool("readOnlyRootFilesystem").Optional().Description("Whether the container has a read-only root filesystem"),
+				defkit.Bool("privileged").Optional().Description("Run the container in privileged mode"),
+				defkit.Bool("runAsNonRoot").Optional().Description("Specify if the container must run as a non-root user"),
+				defkit.Int("runAsUser").Optional().Description("Specify the UID to run the entrypoint of the container process"),
+				defkit.Int("runAsGroup").Optional().Description("Specify the GID to run the entrypoint of the container process"),
+				defkit.Object("capabilities").Optional().Description("Specify the capabilities to add and drop").WithFields(
+					defkit.StringList("add").Optional().Description("Capabilities to add"),
+					defkit.StringList("drop").Optional().Description("Capabilities to drop, e.g. ALL"),
+				),
+			),
+		defkit.Object("podSecurityContext").
+			Optional().
+			Description("Specify the security context of the pod, applied to all its containers").
+			WithFields(
+				defkit.Bool("runAsNonRoot").Optional().Description("Specify if the containers must run as a non-root user"),
+				defkit.Int("runAsUser").Optional().Description("Specify the UID to run the entrypoint of the container processes"),
+				defkit.Int("runAsGroup").Optional().Description("Specify the GID to run the entrypoint of the container processes"),
+				defkit.Int("fsGroup").Optional().Description("Specify the group owning the volumes mounted in the pod"),
+				defkit.Object("seccompProfile").Optional().Description("Specify the seccomp profile of the containers").WithFields(
+					defkit.Enum("type").Values("RuntimeDefault", "Unconfined", "Localhost"),
+					defkit.String("localhostProfile").Optional().Description("localhostProfile is required when type is 'Localhost'"),
+				),
+			),
+		defkit.String("serviceAccountName").
+			Optional().
+			Description("Specify the name of the ServiceAccount the pod runs as"),
+	}
+}
+
+// PodPlacementParams returns nodeSelector, tolerations,
+// topologySpreadConstraints and priorityClassName.
+func PodPlacementParams() []defkit.Param {
+	return []defkit.Param{
+		defkit.StringKeyMap("nodeSelector").
+			Optional().
+			Description("Schedule the pod on the nodes having these labels"),
+		defkit.Array("tolerations").
+			Optional().
+			Description("Specify the taints the pod tolerates").
+			WithFields(
+				defkit.String("key").Optional().Description("Key of the taint, empty with operator Exists to tolerate everything"),
+				defkit.Enum("operator").Values("Equal", "Exists").Default("Equal"),
+				defkit.String("value").Optional().Description("Value of the taint, for operator Equal"),
+				defkit.Enum("effect").Optional().Values("NoSchedule", "PreferNoSchedule", "NoExecute").Description("Effect of the taint to tolerate, empty for all the effects"),
+				defkit.Int("tolerationSeconds").Optional().Description("Number of seconds the pod stays bound to a node tainted NoExecute"),
+			),
+		defkit.Array("topologySpreadConstraints").
+			Optional().
+			Description("Specify how the pods are spread across the topology domains, e.g. zones or nodes").
+			WithFields(
+				defkit.Int("maxSkew").Min(1).Description("Maximum difference of the number of pods between two domains"),
+				defkit.String("topologyKey").Description("Node label whose values are the domains, e.g. topology.kubernetes.io/zone"),
+				defkit.Enum("whenUnsatisfiable").Values("DoNotSchedule", "ScheduleAnyway").Default("DoNotSchedule").
+					Description("Indicate how to deal with a pod if it doesn't satisfy the spread constraint"),
+				defkit.Object("labelSelector").Optional().Description("Select the pods to spread, defaults to the pods of the component"),
+				defkit.Int("minDomains").Optional().Description("Indicate a minimum number of eligible domains"),
+				defkit.StringList("matchLabelKeys").Optional().Description("Pod label keys whose values select the pods to spread, next to labelSelector"),
+			),
+		defkit.String("priorityClassName").
+			Optional().
+			Description("Specify the PriorityClass of the pod"),
+	}
+}
+
+// --- Template rendering ---
+
+// PodTemplate renders the shared parameter groups into the pod template at
+// path of a workload, e.g. "spec.template" for a Deployment or
+// "spec.jobTemplate.spec.template" for a CronJob. The parameters are rendered
+// into the first container, named after the component.
+type PodTemplate struct {
+	tpl  *defkit.Template
+	path string
+
+	extraMounts     *defkit.HelperVar
+	extraMountsCond defkit.Condition
+
+	extraTolerations     string
+	extraTolerationsCond defkit.Condition
+}
+
+// NewPodTemplate creates a PodTemplate writing to the pod template at path.
+func NewPodTemplate(tpl *defkit.Template, path string) *PodTemplate {
+	return &PodTemplate{tpl: tpl, path: path}
+}
+
+// WithVolumeMounts adds the mounts helper, guarded by cond, to the main
+// container volumeMounts next to the volumes group. The statefulset uses it to
+// mount its volume claim templates.
+func (p *PodTemplate) WithVolumeMounts(cond defkit.Condition, mounts *defkit.HelperVar) *PodTemplate {
+	p.extraMounts = mounts
+	p.extraMountsCond = cond
+	return p
+}
+
+// WithTolerations adds the CUE list of tolerations, guarded by cond, to the
+// tolerations of the placement group. The daemon uses it for its toleration
+// presets.
+func (p *PodTemplate) WithTolerations(cond defkit.Condition, tolerations string) *PodTemplate {
+	p.extraTolerations = tolerations
+	p.extraTolerationsCond = cond
+	return p
+}
+
+// PodSpec returns the path of a pod spec field, e.g. PodSpec("hostAliases").
+func (p *PodTemplate) PodSpec(field string) string {
+	return p.path + ".spec." + field
+}
+
+// Container returns the path of a main container field, e.g. Container("ports").
+func (p *PodTemplate) Container(field string) string {
+	return p.path + ".spec.containers[0]." + field
+}
+
+// Apply renders every shared group into r.
+func (p *PodTemplate) Apply(r *defkit.Resource) *defkit.Resource {
+	p.ApplyContainer(r)
+	p.ApplyResources(r)
+	p.ApplyVolumes(r)
+	p.ApplyProbes(r)
+	p.ApplyScheduling(r)
+	return r
+}
+
+// ApplyContainer renders the container group. Env from context.config, when
+// present, replaces parameter.env.
+func (p *PodTemplate) ApplyContainer(r *defkit.Resource) *defkit.Resource {
+	vela := defkit.VelaCtx()
+	image := defkit.String("image")
+	imagePullPolicy := defkit.String("imagePullPolicy")
+	imagePullSecrets := defkit.StringList("imagePullSecrets")
+	cmd := defkit.StringList("cmd")
+	args := defkit.StringList("args")
+	env := defkit.List("env")
+
+	return r.
+		Set(p.Container("name"), vela.Name()).
+		Set(p.Container("image"), image).
+		SetIf(imagePullPolicy.IsSet(), p.Container("imagePullPolicy"), imagePullPolicy).
+		SetIf(cmd.IsSet(), p.Container("command"), cmd).
+		SetIf(args.IsSet(), p.Container("args"), args).
+		SetIf(env.IsSet(), p.Container("env"), env).
+		SetIf(defkit.PathExists(`context["config"]`), p.Container("env"), defkit.Reference("context.config")).
+		SetIf(imagePullSecrets.IsSet(), p.PodSpec("imagePullSecrets"), ImagePullSecretsTransform(imagePullSecrets))
+}
+
+// ApplyResources renders the resources group. cpu and memory are used for
+// both requests and limits unless limit.cpu or limit.memory is set.
+func (p *PodTemplate) ApplyResources(r *defkit.Resource) *defkit.Resource {
+	cpu := defkit.String("cpu")
+	memory := defkit.String("memory")
+
+	cpuLimit := defkit.PathExists("parameter.limit.cpu")
+	memoryLimit := defkit.PathExists("parameter.limit.memory")
+
+	return r.
+		SetIf(defkit.And(cpu.IsSet(), cpuLimit), p.Container("resources.requests.cpu"), cpu).
+		SetIf(defkit.And(cpu.IsSet(), cpuLimit), p.Container("resources.limits.cpu"), defkit.Reference("parameter.limit.cpu")).
+		SetIf(defkit.And(cpu.IsSet(), defkit.Not(cpuLimit)), p.Container("resources.limits.cpu"), cpu).
+		SetIf(defkit.And(cpu.IsSet(), defkit.Not(cpuLimit)), p.Container("resources.requests.cpu"), cpu).
+		SetIf(defkit.And(memory.IsSet(), memoryLimit), p.Container("resources.limits.memory"), defkit.Reference("parameter.limit.memory")).
+		SetIf(defkit.And(memory.IsSet(), memoryLimit), p.Container("resources.requests.memory"), memory).
+		SetIf(defkit.And(memory.IsSet(), defkit.Not(memoryLimit)), p.Container("resources.limits.memory"), memory).
+		SetIf(defkit.And(memory.IsSet(), defkit.Not(memoryLimit)), p.Container("resources.requests.memory"), memory)
+}
+
+// ApplyVolumes renders the volumes group: volumeMounts when set, otherwise the
+// deprecated volumes parameter. Pod volumes are deduplicated by name because
+// the same volume may be mounted at several paths.
+func (p *PodTemplate) ApplyVolumes(r *defkit.Resource) *defkit.Resource {
+	volumeMounts := defkit.Object("volumeMounts")
+	volumes := defkit.List("volumes")
+
+	mountsArray := p.tpl.Helper("mountsArray").
+		FromFields(volumeMounts, volumeMountSources...).
+		Pick("name", "mountPath").
+		PickIf(defkit.ItemFieldIsSet("subPath"), "subPath").
+		PickIf(defkit.ItemFieldIsSet("mountPropagation"), "mountPropagation").
+		PickIf(defkit.ItemFieldIsSet("readOnly"), "readOnly").
+		Build()
+
+	volumesList := p.tpl.Helper("volumesList").
+		FromFields(volumeMounts, volumeMountSources...).
+		MapBySource(podVolumeMappings()).
+		Build()
+
+	deDupVolumesArray := p.tpl.Helper("deDupVolumesArray").
+		FromHelper(volumesList).
+		Dedupe("name").
+		Build()
+
+	if p.extraMounts != nil {
+		// The container mounts come from several sources, so they are
+		// concatenated into one list instead of set per source.
+		legacyMountsArray := p.tpl.Helper("legacyMountsArray").
+			From(volumes).
+			Guard(defkit.And(volumes.IsSet(), volumeMounts.NotSet())).
+			Map(defkit.FieldMap{
+				"mountPath": defkit.FieldRef("mountPath"),
+				"name":      defkit.FieldRef("name"),
+			}).
+			Build()
+
+		return r.
+			SetIf(defkit.Or(volumeMounts.IsSet(), volumes.IsSet(), p.extraMountsCond), p.Container("volumeMounts"),
+				defkit.ListConcat(defkit.Reference("["+mountsArray.Name()+", "+legacyMountsArray.Name()+", "+p.extraMounts.Name()+"]"))).
+			SetIf(volumeMounts.IsSet(), p.PodSpec("volumes"), deDupVolumesArray).
+			SetIf(defkit.And(volumes.IsSet(), volumeMounts.NotSet()), p.PodSpec("volumes"), legacyPodVolumes(volumes))
+	}
+
+	return r.
+		SetIf(volumeMounts.IsSet(), p.Container("volumeMounts"), mountsArray).
+		SetIf(volumeMounts.IsSet(), p.PodSpec("volumes"), deDupVolumesArray).
+		// Deprecated volumes fallback
+		If(defkit.And(volumes.IsSet(), volumeMounts.NotSet())).
+		Set(p.Container("volumeMounts"),
+			defkit.Each(volumes).Map(defkit.FieldMap{
+				"mountPath": defkit.FieldRef("mountPath"),
+				"name":      defkit.FieldRef("name"),
+			})).
+		Set(p.PodSpec("volumes"), legacyPodVolumes(volumes)).
+		EndIf()
+}
+
+// ApplyProbes renders the probes group.
+func (p *PodTemplate) ApplyProbes(r *defkit.Resource) *defkit.Resource {
+	livenessProbe := defkit.Object("livenessProbe")
+	readinessProbe := defkit.Object("readinessProbe")
+
+	return r.
+		SetIf(livenessProbe.IsSet(), p.Container("livenessProbe"), livenessProbe).
+		SetIf(readinessProbe.IsSet(), p.Container("readinessProbe"), readinessProbe)
+}
+
+// ApplyScheduling renders the scheduling group.
+func (p *PodTemplate) ApplyScheduling(r *defkit.Resource) *defkit.Resource {
+	hostAliases := defkit.List("hostAliases")
+
+	return r.
+		SetIf(hostAliases.IsSet(), p.PodSpec("hostAliases"), hostAliases).
+		Directive(p.PodSpec("hostAliases"), "patchKey=ip")
+}
+
+// ApplyLifecycle renders the lifecycle group.
+func (p *PodTemplate) ApplyLifecycle(r *defkit.Resource) *defkit.Resource {
+	startupProbe := defkit.Object("startupProbe")
+	terminationGracePeriodSeconds := defkit.Int("terminationGracePeriodSeconds")
+
+	return r.
+		SetIf(startupProbe.IsSet(), p.Container("startupProbe"), patchable("parameter.startupProbe")).
+		SetIf(terminationGracePeriodSeconds.IsSet(), p.PodSpec("terminationGracePeriodSeconds"), patchable("parameter.terminationGracePeriodSeconds"))
+}
+
+// ApplySecurity renders the security group.
+func (p *PodTemplate) ApplySecurity(r *defkit.Resource) *defkit.Resource {
+	securityContext := defkit.Object("securityContext")
+	podSecurityContext := defkit.Object("podSecurityContext")
+	serviceAccountName := defkit.String("serviceAccountName")
+
+	return r.
+		SetIf(securityContext.IsSet(), p.Container("securityContext"), patchable("parameter.securityContext")).
+		SetIf(podSecurityContext.IsSet(), p.PodSpec("securityContext"), patchable("parameter.podSecurityContext")).
+		SetIf(serviceAccountName.IsSet(), p.PodSpec("serviceAccountName"), patchable("parameter.serviceAccountName"))
+}
+
+// ApplyPlacement renders the placement group. Spread constraints without a
+// labelSelector spread the pods of the component.
+func (p *PodTemplate) ApplyPlacement(r *defkit.Resource) *defkit.Resource {
+	nodeSelector := defkit.Object("nodeSelector")
+	tolerations := defkit.List("tolerations")
+	topologySpreadConstraints := defkit.List("topologySpreadConstraints")
+	priorityClassName := defkit.String("priorityClassName")
+
+	r.SetIf(nodeSelector.IsSet(), p.PodSpec("nodeSelector"), patchable("parameter.nodeSelector"))
+	if p.extraTolerations != "" {
+		r.SetIf(defkit.Or(tolerations.IsSet(), p.extraTolerationsCond), p.PodSpec("tolerations"),
+			patchable(`[if parameter["tolerations"] != _|_ for t in parameter.tolerations {t}, for t in `+p.extraTolerations+` {t}]`))
+	} else {
+		r.SetIf(tolerations.IsSet(), p.PodSpec("tolerations"), patchable("parameter.tolerations"))
+	}
+	return r.
+		Directive(p.PodSpec("tolerations"), "patchKey=key").
+		SetIf(topologySpreadConstraints.IsSet(), p.PodSpec("topologySpreadConstraints"), patchable(`[for c in parameter.topologySpreadConstraints {
+	c
+	if c.labelSelector == _|_ {labelSelector: matchLabels: "app.oam.dev/component": context.name}
+}]`)).
+		Directive(p.PodSpec("topologySpreadConstraints"), "patchKey=topologyKey").
+		SetIf(priorityClassName.IsSet(), p.PodSpec("priorityClassName"), patchable("parameter.priorityClassName"))
+}
+
+// patchable renders the CUE expression v as the default of a field, which a
+// trait patching the field overrides.
+func patchable(v string) defkit.Value {
+	return defkit.Reference("*" + v + " | _")
+}
+
+// legacyPodVolumes maps the deprecated volumes parameter to pod volumes by type.
+func legacyPodVolumes(volumes defkit.Value) *defkit.CollectionOp {
+	return defkit.Each(volumes).
+		Map(defkit.FieldMap{
+			"name": defkit.FieldRef("name"),
+		}).
+		MapVariant("type", "pvc", defkit.FieldMap{
+			"persistentVolumeClaim": defkit.NestedFieldMap(defkit.FieldMap{
+				"claimName": defkit.FieldRef("claimName"),
+			}),
+		}).
+		MapVariant("type", "configMap", defkit.FieldMap{
+			"configMap": defkit.NestedFieldMap(defkit.FieldMap{
+				"defaultMode": defkit.FieldRef("defaultMode"),
+				"name":        defkit.FieldRef("cmName"),
+				"items":       defkit.OptionalFieldRef("items"),
+			}),
+		}).
+		MapVariant("type", "secret", defkit.FieldMap{
+			"secret": defkit.NestedFieldMap(defkit.FieldMap{
+				"defaultMode": defkit.FieldRef("defaultMode"),
+				"secretName":  defkit.FieldRef("secretName"),
+				"items":       defkit.OptionalFieldRef("items"),
+			}),
+		}).
+		MapVariant("type", "emptyDir", defkit.FieldMap{
+			"emptyDir": defkit.NestedFieldMap(defkit.FieldMap{
+				"medium": defkit.FieldRef("medium"),
+			}),
+		})
+}
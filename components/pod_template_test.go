@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"strings"
+
+	"cuelang.org/go/cue"
+	"github.com/kubevela/workflow/pkg/cue/model/sets"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	"github.com/oam-dev/vela-go-definitions/components"
+)
+
+var _ = Describe("Pod template library", func() {
+	podComponents := []func() *defkit.ComponentDefinition{
+		components.Webservice,
+		components.Worker,
+		components.StatefulSet,
+		components.Daemon,
+		components.Task,
+		components.CronTask,
+	}
+
+	// parameterSchema renders params as the parameter block of a component.
+	parameterSchema := func(params ...defkit.Param) string {
+		return defkit.NewCUEGenerator().GenerateParameterSchema(defkit.NewComponent("schema").Params(params...))
+	}
+
+	// groupParams returns the parameters of comp named like the parameters of group, in group order.
+	groupParams := func(comp *defkit.ComponentDefinition, group []defkit.Param) []defkit.Param {
+		byName := map[string]defkit.Param{}
+		for _, p := range comp.GetParams() {
+			byName[p.Name()] = p
+		}
+		params := make([]defkit.Param, 0, len(group))
+		for _, p := range group {
+			if found, ok := byName[p.Name()]; ok {
+				params = append(params, found)
+			}
+		}
+		return params
+	}
+
+	healthProbeSchema := func(comp *defkit.ComponentDefinition) string {
+		for _, h := range comp.GetHelperDefinitions() {
+			if h.GetName() == "HealthProbe" {
+				var sb strings.Builder
+				defkit.NewCUEGenerator().WriteHelperDefinition(&sb, h, 0)
+				return sb.String()
+			}
+		}
+		return ""
+	}
+
+	for _, newComponent := range podComponents {
+		comp := newComponent()
+
+		Describe(comp.GetName(), func() {
+			for group, params := range components.PodParamGroups() {
+				It("should expose the shared "+string(group)+" parameters", func() {
+					Expect(parameterSchema(groupParams(comp, params)...)).To(Equal(parameterSchema(params...)))
+				})
+			}
+
+			It("should use the shared HealthProbe helper", func() {
+				var want strings.Builder
+				defkit.NewCUEGenerator().WriteHelperDefinition(&want,
+					defkit.NewComponent("schema").Helper("HealthProbe", components.HealthProbeParam()).GetHelperDefinitions()[0], 0)
+				Expect(healthProbeSchema(comp)).To(Equal(want.String()))
+			})
+
+			It("should render the shared groups into the pod template", func() {
+				cue := comp.ToCue()
+				Expect(cue).To(ContainSubstring("name: context.name"))
+				Expect(cue).To(ContainSubstring("args: parameter.args"))
+				Expect(cue).To(ContainSubstring("env: context.config"))
+				Expect(cue).To(ContainSubstring("parameter.limit.cpu"))
+				Expect(cue).To(MatchRegexp(`volumeMounts: (list\.Concat\(\[)?mountsArray`))
+				Expect(cue).To(ContainSubstring("volumes: deDupVolumesArray"))
+				Expect(cue).To(ContainSubstring("livenessProbe: parameter.livenessProbe"))
+				Expect(cue).To(ContainSubstring("hostAliases: parameter.hostAliases"))
+			})
+		})
+	}
+
+	Describe("NewPodTemplate", func() {
+		It("should address the pod template at the given path", func() {
+			pod := components.NewPodTemplate(defkit.NewTemplate(), "spec.jobTemplate.spec.template")
+			Expect(pod.PodSpec("hostAliases")).To(Equal("spec.jobTemplate.spec.template.spec.hostAliases"))
+			Expect(pod.Container("ports")).To(Equal("spec.jobTemplate.spec.template.spec.containers[0].ports"))
+		})
+
+		It("should concatenate extra volume mounts with the volumes group", func() {
+			cue := defkit.NewComponent("mounts").
+				Workload("apps/v1", "Deployment").
+				Params(components.PodVolumeParams()...).
+				Template(func(tpl *defkit.Template) {
+					extra := defkit.List("extra")
+					extraMounts := tpl.Helper("extraMounts").From(extra).Guard(extra.IsSet()).Build()
+					pod := components.NewPodTemplate(tpl, "spec.template").WithVolumeMounts(extra.IsSet(), extraMounts)
+					tpl.Output(pod.ApplyVolumes(defkit.NewResource("apps/v1", "Deployment")))
+				}).
+				ToCue()
+			Expect(cue).To(ContainSubstring("legacyMountsArray: ["))
+			Expect(cue).To(ContainSubstring(`parameter["volumeMounts"] != _|_ || parameter["volumes"] != _|_ || parameter["extra"] != _|_`))
+			Expect(cue).To(ContainSubstring("volumeMounts: list.Concat([mountsArray, legacyMountsArray, extraMounts])"))
+			Expect(cue).To(ContainSubstring("volumes: deDupVolumesArray"))
+		})
+	})
+
+	Describe("trait patches", func() {
+		ctx := `{name: "api", appName: "shop", namespace: "prod"}`
+		params := `{
+	image: "api:1"
+	securityContext: {privileged: true, runAsNonRoot: true}
+	priorityClassName: "low"
+	tolerations: [{key: "dedicated", operator: "Equal", value: "api", effect: "NoSchedule"}]
+}`
+
+		// export returns the workload applied by KubeVela, with the defaults
+		// chosen.
+		export := func(workload cue.Value) cue.Value {
+			b, err := workload.MarshalJSON()
+			Expect(err).NotTo(HaveOccurred())
+			return workload.Context().CompileBytes(b)
+		}
+
+		// patch applies the patch of a trait to the workload like KubeVela does.
+		patch := func(workload cue.Value, patch string) cue.Value {
+			v, err := sets.StrategyUnify(workload, workload.Context().CompileString(patch))
+			Expect(err).NotTo(HaveOccurred())
+			return export(v)
+		}
+
+		It("should render the parameters without a trait", func() {
+			v := export(renderOutput(components.Webservice(), ctx, params))
+			Expect(field(v, "spec.template.spec.containers[0].securityContext.privileged").Bool()).To(BeTrue())
+			Expect(lookup(v, "spec.template.spec.priorityClassName")).To(Equal("low"))
+			Expect(lookup(v, "spec.template.spec.tolerations[0].operator")).To(Equal("Equal"))
+		})
+
+		It("should let traits override the parameters", func() {
+			v := patch(renderOutput(components.Webservice(), ctx, params), `spec: template: spec: {
+	// +patchKey=name
+	containers: [{name: "api", securityContext: {privileged: false, readOnlyRootFilesystem: true}}]
+	priorityClassName: "high"
+	tolerations: [{key: "dedicated", operator: "Exists"}]
+}`)
+			Expect(field(v, "spec.template.spec.containers[0].securityContext.privileged").Bool()).To(BeFalse())
+			Expect(field(v, "spec.template.spec.containers[0].securityContext.readOnlyRootFilesystem").Bool()).To(BeTrue())
+			Expect(lookup(v, "spec.template.spec.containers[0].image")).To(Equal("api:1"))
+			Expect(lookup(v, "spec.template.spec.priorityClassName")).To(Equal("high"))
+			Expect(lookup(v, "spec.template.spec.tolerations[0].operator")).To(Equal("Exists"))
+		})
+
+		It("should merge trait patches compatible with the parameters", func() {
+			v := patch(renderOutput(components.Webservice(), ctx, params), `spec: template: spec: {
+	// +patchKey=name
+	containers: [{name: "api", securityContext: readOnlyRootFilesystem: true}]
+}`)
+			Expect(field(v, "spec.template.spec.containers[0].securityContext.privileged").Bool()).To(BeTrue())
+			Expect(field(v, "spec.template.spec.containers[0].securityContext.readOnlyRootFilesystem").Bool()).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// PostgresCluster creates the postgres-cluster component definition.
+// It describes a PostgreSQL cluster run by CloudNativePG, bootstrapped with a
+// database owned by a user. The credentials of the owner are either read from
+// an existing basic-auth Secret, or written with the given password to the
+// <name>-connection Secret, which also holds the DB_HOST, DB_PORT, DB_NAME,
+// DB_USER and DB_PASSWORD keys read by the generate-jdbc-connection workflow
+// step. The password of an existing Secret is not known to the template, so
+// no connection Secret is written with it and that step is not supported.
+func PostgresCluster() *defkit.ComponentDefinition {
+	instances := defkit.Int("instances").
+		Default(1).
+		Min(1).
+		Description("Number of PostgreSQL instances, one primary and the others replicas")
+	version := defkit.String("version").
+		Default("16").
+		Description("PostgreSQL version, the tag of the ghcr.io/cloudnative-pg/postgresql image, e.g. 16.4")
+	storage := defkit.Object("storage").
+		Description("Storage of each instance").
+		WithFields(
+			defkit.String("size").Default("1Gi").Description("Size of the volume of each instance"),
+			defkit.String("storageClass").Optional().Description("Storage class of the volumes, defaults to the cluster default"),
+		)
+	database := defkit.String("database").
+		Default("app").
+		Description("Name of the database created on bootstrap")
+	owner := defkit.String("owner").
+		Default("app").
+		Description("Name of the user owning the database")
+	password := defkit.String("password").
+		Optional().
+		Description("Password of the owner, kept in plain text in the application and written to the <name>-connection Secret read by the generate-jdbc-connection step. Prefer credentialsSecret unless that step is needed. Either password or credentialsSecret is required")
+	credentialsSecret := defkit.String("credentialsSecret").
+		Optional().
+		Description("Existing kubernetes.io/basic-auth Secret with the username of the owner and its password, used instead of password. No <name>-connection Secret is written, so the generate-jdbc-connection step is not supported")
+	resources := defkit.Object("resources").
+		Optional().
+		Description("Specify the resources of each instance").
+		WithFields(
+			defkit.Object("requests").Optional().Description("Minimum resources, e.g. `cpu: \"500m\"`"),
+			defkit.Object("limits").Optional().Description("Maximum resources, e.g. `memory: \"1Gi\"`"),
+		)
+	backup := defkit.Object("backup").
+		Optional().
+		Description("Back the cluster up on a schedule").
+		WithFields(
+			defkit.String("schedule").Description("Schedule of the backups, a cron expression with seconds, e.g. `0 0 2 * * *`"),
+			defkit.Enum("method").Values("barmanObjectStore", "volumeSnapshot").Default("barmanObjectStore").
+				Description(`Back up to an object store, or take snapshots of the volumes`),
+			defkit.Object("barmanObjectStore").Optional().
+				Description("Object store the backups are sent to, e.g. `destinationPath` and `s3Credentials`, required with method barmanObjectStore"),
+			defkit.String("volumeSnapshotClass").Optional().Description("VolumeSnapshotClass of the snapshots, with method volumeSnapshot"),
+			defkit.String("retentionPolicy").Optional().Description("How long backups are kept, e.g. `30d`"),
+		)
+
+	return defkit.NewComponent("postgres-cluster").
+		Description("Describes a PostgreSQL cluster run by CloudNativePG, with a bootstrap database and scheduled backups.").
+		Workload("postgresql.cnpg.io/v1", "Cluster").
+		CustomStatus(postgresClusterHealth.BuildStatus()).
+		HealthPolicy(postgresClusterHealth.Build()).
+		Params(instances, version, storage, database, owner, password, credentialsSecret, resources, backup).
+		Template(postgresClusterTemplate)
+}
+
+// postgresClusterHealth checks that all instances of a CloudNativePG Cluster
+// are ready, and reports the Secret to connect with.
+var postgresClusterHealth = &ObjectHealth{
+	name:       "PostgresCluster",
+	apiVersion: "postgresql.cnpg.io/v1",
+	kind:       "Cluster",
+	fields: `_st: {
+	readyInstances: *0 | int
+	phase:          *"Pending" | string
+	conditions:     *[] | [...]
+	...
+} & %[1]s.status
+_ready: [for c in _st.conditions if c.type == "Ready" {c.status}, "Unknown"][0]`,
+	healthy: `_ready == "True" && _st.readyInstances == %[1]s.spec.instances`,
+	message: `Ready:\(_st.readyInstances)/\(%[1]s.spec.instances), phase:\(_st.phase), secret:\(%[1]s.spec.bootstrap.initdb.secret.name)`,
+}
+
+// postgresClusterTemplate defines the template function for postgres-cluster.
+func postgresClusterTemplate(tpl *defkit.Template) {
+	vela := defkit.VelaCtx()
+	instances := defkit.Int("instances")
+	version := defkit.String("version")
+	database := defkit.String("database")
+	owner := defkit.String("owner")
+	password := defkit.String("password")
+	credentialsSecret := defkit.String("credentialsSecret")
+	resources := defkit.Object("resources")
+	backup := defkit.Object("backup")
+	secretName := defkit.Interpolation(vela.Name(), defkit.Lit("-connection"))
+
+	cluster := defkit.NewResource("postgresql.cnpg.io/v1", "Cluster").
+		Set("spec.instances", instances).
+		Set("spec.imageName", defkit.Interpolation(defkit.Lit("ghcr.io/cloudnative-pg/postgresql:"), version)).
+		Set("spec.storage.size", defkit.Reference("parameter.storage.size")).
+		SetIf(defkit.PathExists("parameter.storage.storageClass"), "spec.storage.storageClass", defkit.Reference("parameter.storage.storageClass")).
+		Set("spec.bootstrap.initdb.database", database).
+		Set("spec.bootstrap.initdb.owner", owner).
+		SetIf(defkit.And(credentialsSecret.IsSet(), password.NotSet()), "spec.bootstrap.initdb.secret.name", credentialsSecret).
+		SetIf(defkit.And(credentialsSecret.NotSet(), password.IsSet()), "spec.bootstrap.initdb.secret.name", secretName).
+		SetIf(defkit.And(credentialsSecret.IsSet(), password.IsSet()), "spec.bootstrap.initdb.secret.name",
+			defkit.Reference(`error("set either password or credentialsSecret, not both")`)).
+		SetIf(defkit.And(credentialsSecret.NotSet(), password.NotSet()), "spec.bootstrap.initdb.secret.name",
+			defkit.Reference(`error("password or credentialsSecret is required")`)).
+		SetIf(resources.IsSet(), "spec.resources", resources).
+		// The backup fields depend on the method, and are only read with a backup
+		SetIf(backup.IsSet(), "spec.backup", defkit.Reference(`{
+	if parameter.backup.method == "barmanObjectStore" && parameter.backup.barmanObjectStore != _|_ {barmanObjectStore: parameter.backup.barmanObjectStore}
+	if parameter.backup.method == "barmanObjectStore" && parameter.backup.barmanObjectStore == _|_ {barmanObjectStore: error("backup.barmanObjectStore is required with method barmanObjectStore")}
+	if parameter.backup.method == "volumeSnapshot" {volumeSnapshot: {if parameter.backup.volumeSnapshotClass != _|_ {className: parameter.backup.volumeSnapshotClass}}}
+	if parameter.backup.retentionPolicy != _|_ {retentionPolicy: parameter.backup.retentionPolicy}
+}`))
+
+	tpl.Output(cluster)
+
+	// The owner credentials CloudNativePG bootstraps with, in the keys of a
+	// basic-auth Secret, next to the keys of the database component convention.
+	// It is only written from a password, not with an existing Secret.
+	connection := defkit.NewResource("v1", "Secret").
+		Set("metadata.name", secretName).
+		Set("type", defkit.Lit("kubernetes.io/basic-auth")).
+		Set("stringData.username", owner).
+		Set("stringData.password", password).
+		Set("stringData.DB_HOST", defkit.Interpolation(vela.Name(), defkit.Lit("-rw."), vela.Namespace(), defkit.Lit(".svc"))).
+		Set("stringData.DB_PORT", defkit.Lit("5432")).
+		Set("stringData.DB_NAME", database).
+		Set("stringData.DB_USER", owner).
+		Set("stringData.DB_PASSWORD", password)
+
+	tpl.OutputsIf(password.IsSet(), "connection", connection)
+
+	scheduledBackup := defkit.NewResource("postgresql.cnpg.io/v1", "ScheduledBackup").
+		Set("metadata.name", vela.Name()).
+		Set("spec.cluster.name", vela.Name()).
+		Set("spec.schedule", defkit.Reference("parameter.backup.schedule")).
+		Set("spec.method", defkit.Reference("parameter.backup.method")).
+		Set("spec.backupOwnerReference", defkit.Lit("self"))
+
+	tpl.OutputsIf(backup.IsSet(), "scheduledBackup", scheduledBackup)
+}
+
+func init() {
+	defkit.Register(PostgresCluster())
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"cuelang.org/go/cue"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("PostgresCluster Component", func() {
+	Describe("PostgresCluster()", func() {
+		It("should create a postgres-cluster component definition", func() {
+			comp := components.PostgresCluster()
+			Expect(comp.GetName()).To(Equal("postgres-cluster"))
+			Expect(comp.GetDescription()).To(ContainSubstring("CloudNativePG"))
+		})
+
+		It("should have postgresql.cnpg.io/v1 Cluster workload", func() {
+			workload := components.PostgresCluster().GetWorkload()
+			Expect(workload.APIVersion()).To(Equal("postgresql.cnpg.io/v1"))
+			Expect(workload.Kind()).To(Equal("Cluster"))
+		})
+
+		It("should have cluster, bootstrap and backup parameters", func() {
+			comp := components.PostgresCluster()
+			for _, name := range []string{"instances", "version", "storage", "database", "owner", "password", "credentialsSecret", "resources", "backup"} {
+				Expect(comp).To(HaveParamNamed(name))
+			}
+		})
+
+		It("should output the Cluster, its connection Secret and its ScheduledBackup", func() {
+			tpl := defkit.NewTemplate()
+			components.PostgresCluster().GetTemplate()(tpl)
+			Expect(tpl.GetOutput()).To(BeResourceOfKind("Cluster"))
+			outputs := tpl.GetOutputs()
+			Expect(outputs["connection"]).To(BeResourceOfKind("Secret"))
+			Expect(outputs["scheduledBackup"]).To(BeResourceOfKind("ScheduledBackup"))
+		})
+	})
+
+	Describe("PostgresCluster rendering", func() {
+		ctx := `{name: "db", namespace: "prod"}`
+
+		It("should bootstrap the database with the owner of the connection Secret", func() {
+			v := render(components.PostgresCluster(), ctx, `{password: "s3cret"}`)
+			Expect(lookup(v, "output.spec.imageName")).To(Equal("ghcr.io/cloudnative-pg/postgresql:16"))
+			Expect(lookup(v, "output.spec.storage.size")).To(Equal("1Gi"))
+			Expect(lookup(v, "output.spec.bootstrap.initdb.owner")).To(Equal("app"))
+			Expect(lookup(v, "output.spec.bootstrap.initdb.secret.name")).To(Equal("db-connection"))
+			Expect(v.LookupPath(cue.ParsePath("output.spec.backup")).Exists()).To(BeFalse())
+			Expect(v.LookupPath(cue.ParsePath("outputs.scheduledBackup")).Exists()).To(BeFalse())
+		})
+
+		It("should write the generate-jdbc-connection keys next to the basic-auth keys", func() {
+			v := render(components.PostgresCluster(), ctx, `{password: "s3cret", database: "orders", owner: "shop"}`)
+			Expect(lookup(v, "outputs.connection.type")).To(Equal("kubernetes.io/basic-auth"))
+			Expect(lookup(v, "outputs.connection.stringData.username")).To(Equal("shop"))
+			Expect(lookup(v, "outputs.connection.stringData.password")).To(Equal("s3cret"))
+			Expect(lookup(v, "outputs.connection.stringData.DB_HOST")).To(Equal("db-rw.prod.svc"))
+			Expect(lookup(v, "outputs.connection.stringData.DB_PORT")).To(Equal("5432"))
+			Expect(lookup(v, "outputs.connection.stringData.DB_NAME")).To(Equal("orders"))
+			Expect(lookup(v, "outputs.connection.stringData.DB_USER")).To(Equal("shop"))
+			Expect(lookup(v, "outputs.connection.stringData.DB_PASSWORD")).To(Equal("s3cret"))
+		})
+
+		It("should bootstrap with an existing Secret instead of a password", func() {
+			v := render(components.PostgresCluster(), ctx, `{credentialsSecret: "db-owner"}`)
+			Expect(lookup(v, "output.spec.bootstrap.initdb.secret.name")).To(Equal("db-owner"))
+			Expect(v.LookupPath(cue.ParsePath("outputs.connection")).Exists()).To(BeFalse())
+		})
+
+		It("should document that generate-jdbc-connection needs a password in plain text", func() {
+			for _, p := range components.PostgresCluster().GetParams() {
+				if p.Name() == "credentialsSecret" {
+					Expect(p.GetDescription()).To(ContainSubstring("the generate-jdbc-connection step is not supported"))
+				}
+				if p.Name() == "password" {
+					Expect(p.GetDescription()).To(ContainSubstring("plain text"))
+				}
+			}
+		})
+
+		It("should require exactly one of password and credentialsSecret", func() {
+			err := renderTemplate(components.PostgresCluster(), ctx, `{}`).Validate(cue.Concrete(true))
+			Expect(err).To(MatchError(ContainSubstring("password or credentialsSecret is required")))
+
+			err = renderTemplate(components.PostgresCluster(), ctx, `{password: "s3cret", credentialsSecret: "db-owner"}`).Validate(cue.Concrete(true))
+			Expect(err).To(MatchError(ContainSubstring("set either password or credentialsSecret, not both")))
+		})
+
+		It("should configure the backups of the method and schedule them", func() {
+			v := render(components.PostgresCluster(), ctx, `{password: "s3cret", backup: {schedule: "0 0 2 * * *", method: "volumeSnapshot", volumeSnapshotClass: "csi", retentionPolicy: "30d"}}`)
+			Expect(lookup(v, "output.spec.backup.volumeSnapshot.className")).To(Equal("csi"))
+			Expect(lookup(v, "output.spec.backup.retentionPolicy")).To(Equal("30d"))
+			Expect(v.LookupPath(cue.ParsePath("output.spec.backup.barmanObjectStore")).Exists()).To(BeFalse())
+			Expect(lookup(v, "outputs.scheduledBackup.spec.cluster.name")).To(Equal("db"))
+			Expect(lookup(v, "outputs.scheduledBackup.spec.schedule")).To(Equal("0 0 2 * * *"))
+			Expect(lookup(v, "outputs.scheduledBackup.spec.method")).To(Equal("volumeSnapshot"))
+		})
+
+		It("should require the object store of barmanObjectStore backups", func() {
+			v := render(components.PostgresCluster(), ctx, `{password: "s3cret", backup: {schedule: "0 0 2 * * *", barmanObjectStore: {destinationPath: "s3://backups/db"}}}`)
+			Expect(lookup(v, "output.spec.backup.barmanObjectStore.destinationPath")).To(Equal("s3://backups/db"))
+
+			err := renderTemplate(components.PostgresCluster(), ctx, `{password: "s3cret", backup: {schedule: "0 0 2 * * *"}}`).Validate(cue.Concrete(true))
+			Expect(err).To(MatchError(ContainSubstring("backup.barmanObjectStore is required with method barmanObjectStore")))
+		})
+
+		It("should report the connection Secret and be healthy once all instances are ready", func() {
+			ready := `context: output: {spec: {instances: 2, bootstrap: initdb: secret: name: "db-connection"}, status: {readyInstances: 2, phase: "Cluster in healthy state", conditions: [{type: "Ready", status: "True"}]}}`
+			Expect(evalStatus(components.PostgresCluster(), "customStatus", "message", ready).String()).To(Equal("Ready:2/2, phase:Cluster in healthy state, secret:db-connection"))
+			Expect(evalStatus(components.PostgresCluster(), "healthPolicy", "isHealth", ready).Bool()).To(BeTrue())
+
+			creating := `context: output: {spec: {instances: 2, bootstrap: initdb: secret: name: "db-connection"}, status: {readyInstances: 1, phase: "Creating replica"}}`
+			Expect(evalStatus(components.PostgresCluster(), "healthPolicy", "isHealth", creating).Bool()).To(BeFalse())
+		})
+	})
+})
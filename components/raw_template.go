@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// rawTemplateAnchor opens the template block of a generated definition.
+const rawTemplateAnchor = "\ntemplate: {\n"
+
+// WithRawTemplateBlocks writes the raw header and outputs blocks set on the
+// template of a component at the top of its generated template, in the order
+// the trait generator writes them. The defkit component generator only
+// writes the resource builders, so components with hidden fields or outputs
+// that the builders cannot express set these blocks with
+// tpl.SetRawHeaderBlock and tpl.SetRawOutputsBlock and are wrapped by this
+// function. The imports of the component are kept.
+//
+// It panics when the generated definition has no template block.
+func WithRawTemplateBlocks(def *defkit.ComponentDefinition) *defkit.ComponentDefinition {
+	tpl := defkit.NewTemplate()
+	def.GetTemplate()(tpl)
+
+	var blocks strings.Builder
+	for _, block := range []string{tpl.GetRawHeaderBlock(), tpl.GetRawOutputsBlock()} {
+		if block == "" {
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimSpace(block), "\n") {
+			if line != "" {
+				blocks.WriteString("\t" + line)
+			}
+			blocks.WriteString("\n")
+		}
+		blocks.WriteString("\n")
+	}
+
+	cue := defkit.NewCUEGenerator().WithImports(def.GetImports()...).GenerateFullDefinition(def)
+	if blocks.Len() == 0 || strings.Contains(cue, blocks.String()) {
+		return def.RawCUE(cue)
+	}
+	i := rawTemplateIndex(def, cue)
+	return def.RawCUE(cue[:i+len(rawTemplateAnchor)] + blocks.String() + cue[i+len(rawTemplateAnchor):])
+}
+
+// withRawTemplate replaces the generated template block of a component with
+// template, a whole template block written in CUE, for components whose
+// parameters the builders cannot express. The header of the definition,
+// with its status and health policies, is kept.
+func withRawTemplate(def *defkit.ComponentDefinition, template string) *defkit.ComponentDefinition {
+	cue := defkit.NewCUEGenerator().WithImports(def.GetImports()...).GenerateFullDefinition(def)
+	return def.RawCUE(cue[:rawTemplateIndex(def, cue)+1] + template)
+}
+
+// rawTemplateIndex returns the index of the template block in the generated
+// definition cue of def. It panics when there is not exactly one, so that a
+// change of the generator fails at registration instead of dropping blocks.
+func rawTemplateIndex(def *defkit.ComponentDefinition, cue string) int {
+	if strings.Count(cue, rawTemplateAnchor) != 1 {
+		panic(fmt.Sprintf("component %s: no template block to write the raw template blocks into", def.GetName()))
+	}
+	return strings.Index(cue, rawTemplateAnchor)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+var _ = Describe("WithRawTemplateBlocks", func() {
+	newComponent := func() *defkit.ComponentDefinition {
+		return defkit.NewComponent("raw-blocks").
+			Workload("v1", "ConfigMap").
+			WithImports("strings").
+			Params(defkit.String("name")).
+			Template(func(tpl *defkit.Template) {
+				tpl.SetRawHeaderBlock(`_upper: strings.ToUpper(parameter.name)`)
+				tpl.SetRawOutputsBlock(`outputs: copy: {
+	apiVersion: "v1"
+	kind:       "ConfigMap"
+	data: name: _upper
+}`)
+				tpl.Output(defkit.NewResource("v1", "ConfigMap").Set("data.name", defkit.String("name")))
+			})
+	}
+
+	It("should write the header and outputs blocks at the top of the template", func() {
+		cue := components.WithRawTemplateBlocks(newComponent()).ToCue()
+		Expect(cue).To(ContainSubstring("template: {\n\t_upper: strings.ToUpper(parameter.name)\n\n\toutputs: copy: {\n\t\tapiVersion: \"v1\""))
+		Expect(cue).To(ContainSubstring("\toutput: {"))
+		Expect(cue).To(ContainSubstring("name: parameter.name"))
+	})
+
+	It("should keep the imports of the component", func() {
+		cue := components.WithRawTemplateBlocks(newComponent()).ToCue()
+		Expect(cue).To(HavePrefix("import (\n\t\"strings\"\n)"))
+	})
+
+	It("should write the blocks once", func() {
+		cue := components.WithRawTemplateBlocks(newComponent()).ToCue()
+		Expect(strings.Count(cue, "_upper: strings.ToUpper(parameter.name)")).To(Equal(1))
+	})
+})
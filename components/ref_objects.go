@@ -22,66 +22,30 @@ import (
 
 // RefObjects creates the ref-objects component definition.
 // Ref-objects allow users to specify ref objects to use. Notice that this component type have special handle logic.
+//
+// The first object is the workload: its health and status follow the rule of
+// its kind, or its Ready condition, and objects no rule applies to are healthy.
 func RefObjects() *defkit.ComponentDefinition {
-	return defkit.NewComponent("ref-objects").
+	def := defkit.NewComponent("ref-objects").
 		Description("Ref-objects allow users to specify ref objects to use. Notice that this component type have special handle logic.").
 		AutodetectWorkload().
-		RawCUE(`"ref-objects": {
-	type: "component"
-	annotations: {}
-	labels: {
-		"ui-hidden": "true"
-	}
-	description: "Ref-objects allow users to specify ref objects to use. Notice that this component type have special handle logic."
-	attributes: {
-		workload: type: "autodetects.core.oam.dev"
-		status: {
-			customStatus: #"""
-				if context.output.apiVersion == "apps/v1" && context.output.kind == "Deployment" {
-					ready: {
-						readyReplicas: *0 | int
-					} & {
-						if context.output.status.readyReplicas != _|_ {
-							readyReplicas: context.output.status.readyReplicas
-						}
-					}
-					message: "Ready:\(ready.readyReplicas)/\(context.output.spec.replicas)"
-				}
-				if context.output.apiVersion != "apps/v1" || context.output.kind != "Deployment" {
-					message: ""
-				}
-				"""#
-			healthPolicy: #"""
-				if context.output.apiVersion == "apps/v1" && context.output.kind == "Deployment" {
-					ready: {
-						updatedReplicas:    *0 | int
-						readyReplicas:      *0 | int
-						replicas:           *0 | int
-						observedGeneration: *0 | int
-					} & {
-						if context.output.status.updatedReplicas != _|_ {
-							updatedReplicas: context.output.status.updatedReplicas
-						}
-						if context.output.status.readyReplicas != _|_ {
-							readyReplicas: context.output.status.readyReplicas
-						}
-						if context.output.status.replicas != _|_ {
-							replicas: context.output.status.replicas
-						}
-						if context.output.status.observedGeneration != _|_ {
-							observedGeneration: context.output.status.observedGeneration
-						}
-					}
-					isHealth: (context.output.spec.replicas == ready.readyReplicas) && (context.output.spec.replicas == ready.updatedReplicas) && (context.output.spec.replicas == ready.replicas) && (ready.observedGeneration == context.output.metadata.generation || ready.observedGeneration > context.output.metadata.generation)
-				}
-				if context.output.apiVersion != "apps/v1" || context.output.kind != "Deployment" {
-					isHealth: true
-				}
-				"""#
-		}
-	}
+		Labels(map[string]string{"ui-hidden": "true"}).
+		CustomStatus(objectHealthRuleOf("context.output") + "\n" + objectStatusMessage("_rule", "context.output")).
+		HealthPolicy(objectHealthRuleOf("context.output") + "\n" + objectHealthCheck("_rule", "context.output") + `
+if _rule == "" {
+	isHealth: true
 }
-template: {
+if _rule != "" {
+	isHealth: healthy
+}`)
+
+	// The objects are open to any field of the objects they select, which
+	// the parameter builders cannot express, so the template is written in CUE.
+	return withRawTemplate(def, refObjectsTemplate)
+}
+
+// refObjectsTemplate selects the objects to use.
+const refObjectsTemplate = `template: {
 	#K8sObject: {
 		// +usage=The resource type for the Kubernetes objects
 		resource?: string
@@ -119,8 +83,7 @@ template: {
 		urls?: [...string]
 	}
 }
-`)
-}
+`
 
 func init() {
 	defkit.Register(RefObjects())
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+)
+
+var _ = Describe("RefObjects Component", func() {
+	var cue string
+
+	BeforeEach(func() {
+		cue = components.RefObjects().ToCue()
+	})
+
+	It("should keep the ref-objects header and template", func() {
+		Expect(cue).To(ContainSubstring(`"ui-hidden": "true"`))
+		Expect(cue).To(ContainSubstring(`workload: type: "autodetects.core.oam.dev"`))
+		Expect(cue).To(ContainSubstring("objects?: [...#K8sObject]"))
+		Expect(cue).To(ContainSubstring("urls?: [...string]"))
+	})
+
+	It("should select the health rule by the kind of the output", func() {
+		Expect(cue).To(ContainSubstring(`if context.output.apiVersion == "apps/v1" && context.output.kind == "StatefulSet" {"StatefulSet"},`))
+		Expect(cue).To(ContainSubstring(`if context.output.apiVersion == "batch/v1" && context.output.kind == "CronJob" {"CronJob"},`))
+		Expect(cue).To(ContainSubstring(`if context.output.apiVersion == "v1" && context.output.kind == "PersistentVolumeClaim" {"PersistentVolumeClaim"},`))
+		Expect(cue).To(ContainSubstring(`for c in context.output.status.conditions if c.type == "Ready" {"Ready"},`))
+		Expect(cue).To(ContainSubstring("_rule: _rules[0]"))
+	})
+
+	It("should report the status of each kind", func() {
+		Expect(cue).To(ContainSubstring(`message: "Ready:\(_st.numberReady)/\(_st.desiredNumberScheduled)"`))
+		Expect(cue).To(ContainSubstring(`message: "Succeeded:\(_st.succeeded)/\(_completions)"`))
+		Expect(cue).To(ContainSubstring(`message: "Phase:\(_st.phase)"`))
+		Expect(cue).To(ContainSubstring(`message: "Type:\(context.output.spec.type)\(_ingress)"`))
+	})
+
+	It("should stay healthy for objects no rule applies to", func() {
+		Expect(cue).To(ContainSubstring("if _rule == \"\" {\n\t\t\t\t\tisHealth: true"))
+		Expect(cue).To(ContainSubstring("isHealth: healthy"))
+	})
+
+	Describe("evaluated on the live workload", func() {
+		isHealth := func(live string) bool {
+			healthy, err := evalStatus(components.RefObjects(), "healthPolicy", "isHealth", "context: output: "+live).Bool()
+			Expect(err).NotTo(HaveOccurred())
+			return healthy
+		}
+
+		It("should check the workload with the rule of its kind", func() {
+			deployment := `{apiVersion: "apps/v1", kind: "Deployment", metadata: generation: 1, spec: replicas: 2, status: {replicas: 2, readyReplicas: %d, updatedReplicas: 2, observedGeneration: 1}}`
+			Expect(isHealth(fmt.Sprintf(deployment, 2))).To(BeTrue())
+			Expect(isHealth(fmt.Sprintf(deployment, 1))).To(BeFalse())
+			Expect(evalStatus(components.RefObjects(), "customStatus", "message", "context: output: "+fmt.Sprintf(deployment, 1)).String()).To(Equal("Ready:1/2"))
+
+			pvc := `{apiVersion: "v1", kind: "PersistentVolumeClaim", spec: {}, status: phase: %q}`
+			Expect(isHealth(fmt.Sprintf(pvc, "Bound"))).To(BeTrue())
+			Expect(isHealth(fmt.Sprintf(pvc, "Pending"))).To(BeFalse())
+		})
+
+		It("should check the Ready condition of other kinds", func() {
+			Expect(isHealth(`{apiVersion: "example.com/v1", kind: "Database", status: conditions: [{type: "Ready", status: "True"}]}`)).To(BeTrue())
+			Expect(isHealth(`{apiVersion: "example.com/v1", kind: "Database", status: conditions: [{type: "Ready", status: "False"}]}`)).To(BeFalse())
+		})
+
+		It("should stay healthy for objects no rule applies to", func() {
+			Expect(isHealth(`{apiVersion: "v1", kind: "ConfigMap", data: {}}`)).To(BeTrue())
+		})
+	})
+})
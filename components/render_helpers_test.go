@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"regexp"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// renderTemplate evaluates the template of the generated definition of def,
+// with its imports, in the context ctx with the parameters params, both CUE
+// structs. It is not validated, so that invalid parameters can be checked for
+// the errors they render.
+func renderTemplate(def *defkit.ComponentDefinition, ctx, params string) cue.Value {
+	definition := def.ToCue()
+	header := regexp.MustCompile(`(?m)^"?` + regexp.QuoteMeta(def.GetName()) + `"?: \{`).FindStringIndex(definition)
+	Expect(header).NotTo(BeNil())
+	src := definition[:header[0]] +
+		definition[strings.Index(definition, "\ntemplate: {"):] +
+		"\ncontext: " + ctx + "\ntemplate: parameter: " + params
+	return cuecontext.New().CompileString(src).LookupPath(cue.ParsePath("template"))
+}
+
+// render evaluates the template of def like renderTemplate, and checks that
+// it is concrete.
+func render(def *defkit.ComponentDefinition, ctx, params string) cue.Value {
+	v := renderTemplate(def, ctx, params)
+	Expect(v.Validate(cue.Concrete(true))).To(Succeed())
+	return v
+}
+
+// renderOutput evaluates the output of def like renderTemplate, and checks
+// that it is concrete.
+func renderOutput(def *defkit.ComponentDefinition, ctx, params string) cue.Value {
+	v := renderTemplate(def, ctx, params).LookupPath(cue.ParsePath("output"))
+	Expect(v.Validate(cue.Concrete(true))).To(Succeed())
+	return v
+}
+
+// evalPolicy evaluates the status policy named policy, e.g. healthPolicy or
+// customStatus, of the generated definition of def with the CUE fields of
+// live, e.g. `context: output: {...}`.
+func evalPolicy(def *defkit.ComponentDefinition, policy, live string) cue.Value {
+	m := regexp.MustCompile(`(?s)` + policy + `: #"""(.*?)"""#`).FindStringSubmatch(def.ToCue())
+	Expect(m).To(HaveLen(2))
+	v := cuecontext.New().CompileString(m[1] + "\n" + live)
+	Expect(v.Err()).NotTo(HaveOccurred())
+	return v
+}
+
+// evalStatus returns the field name of the status policy named policy of def,
+// evaluated like evalPolicy.
+func evalStatus(def *defkit.ComponentDefinition, policy, name, live string) cue.Value {
+	return field(evalPolicy(def, policy, live), name)
+}
+
+// lookup returns the string at path in v, or its default.
+func lookup(v cue.Value, path string) string {
+	s, err := field(v, path).String()
+	Expect(err).NotTo(HaveOccurred())
+	return s
+}
+
+// lookupInt returns the integer at path in v, or its default.
+func lookupInt(v cue.Value, path string) int64 {
+	i, err := field(v, path).Int64()
+	Expect(err).NotTo(HaveOccurred())
+	return i
+}
+
+// field returns the concrete value at path in v, or its default.
+func field(v cue.Value, path string) cue.Value {
+	f := v.LookupPath(cue.ParsePath(path))
+	Expect(f.Validate(cue.Concrete(true))).To(Succeed())
+	f, _ = f.Default()
+	return f
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// RolloutService creates the rollout-service component definition.
+// It describes long-running services progressively delivered by Argo Rollouts,
+// either as canary steps or as a blue-green switch between an active and a
+// preview Service.
+func RolloutService() *defkit.ComponentDefinition {
+	labels := defkit.StringKeyMap("labels").Optional().Description("Specify the labels in the workload")
+	annotations := defkit.StringKeyMap("annotations").Optional().Description("Specify the annotations in the workload")
+	replicas := defkit.Int("replicas").Default(1).Min(0).Description("Specify the number of replicas")
+
+	ports := defkit.Array("ports").
+		MinItems(1).
+		Description("Which ports the Services send traffic to").
+		WithFields(
+			defkit.Int("port").Description("Number of port to expose on the pod's IP address"),
+			defkit.String("name").Optional().Description("Name of the port"),
+			defkit.Enum("protocol").Values("TCP", "UDP", "SCTP").Default("TCP").Description("Protocol for port. Must be UDP, TCP, or SCTP"),
+		)
+
+	strategy := defkit.Enum("strategy").
+		Values("canary", "blueGreen").
+		Default("canary").
+		Description(`Specify the delivery strategy: "canary" shifts traffic by steps, "blueGreen" switches the active Service to the preview once promoted`)
+	steps := defkit.Array("steps").
+		Optional().
+		Description("Canary steps, run in order on every update. Only valid when strategy is canary").
+		WithFields(
+			defkit.Int("setWeight").Optional().Min(0).Max(100).Description("Percentage of the replicas running the new version. A step sets exactly one of setWeight, pause or analysis"),
+			defkit.Object("pause").Optional().Description("Pause the rollout, until promoted when no duration is given").WithFields(
+				defkit.String("duration").Optional().Description("Duration of the pause, e.g. 30s, 5m or 1h"),
+			),
+			defkit.Object("analysis").Optional().Description("Run an analysis and abort the rollout if it fails").WithFields(
+				defkit.Array("templates").Description("AnalysisTemplates to run").WithFields(
+					defkit.String("templateName").Description("Name of the AnalysisTemplate"),
+				),
+				defkit.Array("args").Optional().Description("Arguments of the AnalysisTemplates").WithFields(
+					defkit.String("name"),
+					defkit.String("value"),
+				),
+			),
+		)
+	blueGreen := defkit.Object("blueGreen").
+		Description("Blue-green settings. Only valid when strategy is blueGreen").
+		WithFields(
+			defkit.Bool("autoPromotionEnabled").Default(true).Description("Promote the preview once it is ready, instead of waiting for a manual promotion"),
+			defkit.Int("autoPromotionSeconds").Optional().Description("Wait the given seconds after the preview is ready before promoting it"),
+			defkit.Int("scaleDownDelaySeconds").Optional().Description("Seconds to keep the previous version running after the switch"),
+			defkit.Int("previewReplicaCount").Optional().Description("Number of replicas of the preview, defaults to replicas"),
+		)
+
+	return defkit.NewComponent("rollout-service").
+		Description("Describes long-running services progressively delivered by Argo Rollouts with canary steps or a blue-green switch.").
+		Workload("argoproj.io/v1alpha1", "Rollout").
+		WithImports("list").
+		CustomStatus(defkit.Status().
+			StringField("status.phase", "status.phase", "Progressing").
+			IntField("status.readyReplicas", "status.readyReplicas", 0).
+			StringField("status.message", "status.message", "").
+			Message(`Phase:\(status.phase), ready:\(status.readyReplicas)/\(context.output.spec.replicas) \(status.message)`).
+			Build()).
+		// Argo Rollouts reports observedGeneration as a string.
+		HealthPolicy(defkit.Health().
+			StringField("ready.phase", "status.phase", "").
+			StringField("ready.observedGeneration", "status.observedGeneration", "").
+			HealthyWhen(
+				`ready.phase == "Healthy"`,
+				defkit.StatusEq("ready.observedGeneration", `"\(context.output.metadata.generation)"`),
+			).
+			Build()).
+		Params(labels, annotations, replicas).
+		Params(PodContainerParams()...).
+		Params(ports, strategy, steps, blueGreen).
+		Params(PodResourceParams()...).
+		Params(PodVolumeParams()...).
+		Params(PodProbeParams()...).
+		Params(PodSchedulingParams()...).
+		Helper("HealthProbe", HealthProbeParam()).
+		Template(rolloutServiceTemplate)
+}
+
+// rolloutServiceSteps renders the canary steps, each of which must be exactly
+// one of a weight, a pause or an analysis.
+const rolloutServiceSteps = `[for i, s in parameter.steps {
+	_kinds: [for k in ["setWeight", "pause", "analysis"] if s[k] != _|_ {k}]
+	if len(_kinds) != 1 {error("steps[\(i)] must set exactly one of setWeight, pause or analysis")}
+	s
+}]`
+
+// rolloutServiceTemplate defines the template function for rollout-service.
+func rolloutServiceTemplate(tpl *defkit.Template) {
+	vela := defkit.VelaCtx()
+	labels := defkit.Object("labels")
+	annotations := defkit.Object("annotations")
+	replicas := defkit.Int("replicas")
+	ports := defkit.List("ports")
+	strategy := defkit.String("strategy")
+	steps := defkit.List("steps")
+	pod := NewPodTemplate(tpl, "spec.template")
+
+	isCanary := defkit.Eq(strategy, defkit.Lit("canary"))
+	isBlueGreen := defkit.Eq(strategy, defkit.Lit("blueGreen"))
+	previewName := defkit.Interpolation(vela.Name(), defkit.Lit("-preview"))
+
+	rollout := defkit.NewResource("argoproj.io/v1alpha1", "Rollout").
+		Set("spec.replicas", replicas).
+		Set("spec.selector.matchLabels[app.oam.dev/component]", vela.Name()).
+		SpreadIf(labels.IsSet(), "spec.template.metadata.labels", labels).
+		Set("spec.template.metadata.labels[app.oam.dev/name]", vela.AppName()).
+		Set("spec.template.metadata.labels[app.oam.dev/component]", vela.Name()).
+		SetIf(annotations.IsSet(), "spec.template.metadata.annotations", annotations)
+	pod.Apply(rollout).
+		Set(pod.Container("ports"), ContainerPortsTransform(ports)).
+		// Without steps the canary updates all replicas at once
+		SetIf(defkit.And(isCanary, steps.IsSet()), "spec.strategy.canary.steps", defkit.Reference(rolloutServiceSteps)).
+		SetIf(defkit.And(isCanary, steps.NotSet()), "spec.strategy.canary.steps", defkit.Lit([]any{})).
+		// Argo Rollouts points the Services at the active and preview versions
+		SetIf(isBlueGreen, "spec.strategy.blueGreen", defkit.Reference(`[
+	if parameter["steps"] != _|_ {error("steps is only valid with strategy canary")},
+	parameter.blueGreen & {activeService: context.name, previewService: "\(context.name)-preview"},
+][0]`))
+	tpl.Output(rollout)
+
+	service := defkit.NewResource("v1", "Service").
+		Set("metadata.name", vela.Name()).
+		Set("spec.selector[app.oam.dev/component]", vela.Name()).
+		Set("spec.ports", ServicePortsTransform(ports))
+	tpl.Outputs("rolloutService", service)
+
+	preview := defkit.NewResource("v1", "Service").
+		Set("metadata.name", previewName).
+		Set("spec.selector[app.oam.dev/component]", vela.Name()).
+		Set("spec.ports", ServicePortsTransform(ports))
+	tpl.OutputsIf(isBlueGreen, "rolloutServicePreview", preview)
+}
+
+func init() {
+	defkit.Register(RolloutService())
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"cuelang.org/go/cue"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("RolloutService Component", func() {
+	Describe("RolloutService()", func() {
+		It("should create a rollout-service component definition", func() {
+			comp := components.RolloutService()
+			Expect(comp.GetName()).To(Equal("rollout-service"))
+			Expect(comp.GetDescription()).To(ContainSubstring("Argo Rollouts"))
+		})
+
+		It("should have argoproj.io/v1alpha1 Rollout workload", func() {
+			comp := components.RolloutService()
+			workload := comp.GetWorkload()
+			Expect(workload.APIVersion()).To(Equal("argoproj.io/v1alpha1"))
+			Expect(workload.Kind()).To(Equal("Rollout"))
+		})
+
+		It("should reuse the webservice container parameters", func() {
+			comp := components.RolloutService()
+			Expect(comp).To(HaveParamNamed("image"))
+			Expect(comp).To(HaveParamNamed("env"))
+			Expect(comp).To(HaveParamNamed("cpu"))
+			Expect(comp).To(HaveParamNamed("volumeMounts"))
+			Expect(comp).To(HaveParamNamed("readinessProbe"))
+			Expect(comp).To(HaveParamNamed("ports"))
+		})
+
+		It("should have strategy parameters", func() {
+			comp := components.RolloutService()
+			Expect(comp).To(HaveParamNamed("strategy"))
+			Expect(comp).To(HaveParamNamed("steps"))
+			Expect(comp).To(HaveParamNamed("blueGreen"))
+		})
+
+		It("should execute template and produce Rollout output with Services", func() {
+			comp := components.RolloutService()
+			tpl := defkit.NewTemplate()
+			comp.GetTemplate()(tpl)
+			Expect(tpl.GetOutput()).To(BeResourceOfKind("Rollout"))
+			outputs := tpl.GetOutputs()
+			Expect(outputs).To(HaveKey("rolloutService"))
+			Expect(outputs).To(HaveKey("rolloutServicePreview"))
+			Expect(outputs["rolloutService"]).To(BeService())
+		})
+	})
+
+	Describe("RolloutService CUE generation", func() {
+		var cue string
+
+		BeforeEach(func() {
+			cue = defkit.NewCUEGenerator().GenerateFullDefinition(components.RolloutService())
+		})
+
+		It("should default to the canary strategy", func() {
+			Expect(cue).To(ContainSubstring(`strategy: *"canary" | "blueGreen"`))
+		})
+
+		Context("canary", func() {
+			It("should render the canary steps", func() {
+				Expect(cue).To(ContainSubstring(`if parameter.strategy == "canary" && parameter["steps"] != _|_ {`))
+				Expect(cue).To(ContainSubstring("steps: [for i, s in parameter.steps {"))
+				Expect(cue).To(ContainSubstring("setWeight?: int & >=0 & <=100"))
+				Expect(cue).To(ContainSubstring("templateName: string"))
+			})
+
+			It("should require each step to set exactly one of setWeight, pause or analysis", func() {
+				Expect(cue).To(ContainSubstring(`_kinds: [for k in ["setWeight", "pause", "analysis"] if s[k] != _|_ {k}]`))
+				Expect(cue).To(ContainSubstring(`if len(_kinds) != 1 {error("steps[\(i)] must set exactly one of setWeight, pause or analysis")}`))
+			})
+
+			It("should update all replicas at once without steps", func() {
+				Expect(cue).To(ContainSubstring(`if parameter.strategy == "canary" && parameter["steps"] == _|_ {`))
+				Expect(cue).To(ContainSubstring("steps: []"))
+			})
+		})
+
+		Context("blueGreen", func() {
+			It("should switch between the active and preview Services", func() {
+				Expect(cue).To(ContainSubstring(`if parameter.strategy == "blueGreen" {`))
+				Expect(cue).To(ContainSubstring(`parameter.blueGreen & {activeService: context.name, previewService: "\(context.name)-preview"},`))
+				Expect(cue).To(ContainSubstring("autoPromotionEnabled: *true | bool"))
+			})
+
+			It("should only output the preview Service for blueGreen", func() {
+				Expect(cue).To(MatchRegexp(`if parameter.strategy == "blueGreen" \{\s+rolloutServicePreview: \{`))
+				Expect(cue).To(ContainSubstring(`name: "\(context.name)-preview"`))
+			})
+		})
+
+		It("should derive health from the Rollout phase of the current generation", func() {
+			Expect(cue).To(ContainSubstring("phase: context.output.status.phase"))
+			Expect(cue).To(ContainSubstring(`isHealth: (ready.phase == "Healthy") && (ready.observedGeneration == "\(context.output.metadata.generation)")`))
+			Expect(cue).To(ContainSubstring(`observedGeneration: *"" | string`))
+			Expect(cue).To(ContainSubstring(`message: "Phase:\(status.phase), ready:\(status.readyReplicas)/\(context.output.spec.replicas) \(status.message)"`))
+		})
+	})
+
+	Describe("RolloutService rendering", func() {
+		ctx := `{name: "api", appName: "shop", namespace: "prod"}`
+
+		It("should reject steps with strategy blueGreen", func() {
+			v := render(components.RolloutService(), ctx, `{image: "api:1", ports: [{port: 8080}], strategy: "blueGreen"}`)
+			Expect(lookup(v, "output.spec.strategy.blueGreen.previewService")).To(Equal("api-preview"))
+			Expect(v.LookupPath(cue.ParsePath("output.spec.strategy.canary")).Exists()).To(BeFalse())
+
+			err := renderTemplate(components.RolloutService(), ctx, `{image: "api:1", ports: [{port: 8080}], strategy: "blueGreen", steps: [{setWeight: 20}]}`).Validate(cue.Concrete(true))
+			Expect(err).To(MatchError(ContainSubstring("steps is only valid with strategy canary")))
+		})
+	})
+})
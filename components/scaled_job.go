@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// ScaledJob creates the scaled-job component definition.
+// It describes a KEDA ScaledJob, which starts Jobs with the container options
+// of task as events such as queue messages wait to be processed.
+//
+// The metadata of a trigger is passed to its scaler as strings. The metadata
+// keys required by the rabbitmq, kafka, prometheus and cron scalers are
+// checked when rendering.
+func ScaledJob() *defkit.ComponentDefinition {
+	labels := defkit.StringKeyMap("labels").Optional().Description("Specify the labels in the workload")
+	annotations := defkit.StringKeyMap("annotations").Optional().Description("Specify the annotations in the workload")
+
+	triggers := defkit.Array("triggers").
+		Description("Events scaling the jobs, the number of jobs follows the trigger asking for the most by default").
+		WithFields(
+			defkit.String("type").Description("Type of the KEDA scaler, e.g. rabbitmq, kafka, prometheus or cron"),
+			defkit.String("name").Optional().Description("Name of the trigger"),
+			defkit.Object("metadata").
+				WithSchema(`{[string]: string | number | bool}`).
+				Description("Metadata of the scaler, e.g. `queueName` and `value` for rabbitmq"),
+			defkit.Object("authenticationRef").Optional().Description("TriggerAuthentication with the credentials of the scaler").WithFields(
+				defkit.String("name").Description("Name of the TriggerAuthentication"),
+				defkit.Enum("kind").Values("TriggerAuthentication", "ClusterTriggerAuthentication").Default("TriggerAuthentication").
+					Description("Kind of the authentication"),
+			),
+		)
+	pollingInterval := defkit.Int("pollingInterval").
+		Default(30).
+		Min(1).
+		Description("Number of seconds between two checks of the triggers")
+	minReplicaCount := defkit.Int("minReplicaCount").
+		Optional().
+		Min(0).
+		Description("Number of jobs kept running without events")
+	maxReplicaCount := defkit.Int("maxReplicaCount").
+		Default(100).
+		Min(1).
+		Description("Maximum number of jobs running at the same time")
+	successfulJobsHistoryLimit := defkit.Int("successfulJobsHistoryLimit").
+		Default(100).
+		Min(0).
+		Description("The number of successful finished jobs to retain")
+	failedJobsHistoryLimit := defkit.Int("failedJobsHistoryLimit").
+		Default(100).
+		Min(0).
+		Description("The number of failed finished jobs to retain")
+	scalingStrategy := defkit.Object("scalingStrategy").
+		Optional().
+		Description("Specify how the number of jobs to start is computed from the triggers").
+		WithFields(
+			defkit.Enum("strategy").Values("default", "custom", "accurate", "eager").Default("default").
+				Description(`Deduct the running jobs from the queue length with "default", as configured with "custom", or deduct only the pending jobs with "accurate"; "eager" fills up to maxReplicaCount`),
+			defkit.Int("customScalingQueueLengthDeduction").Optional().Description(`Number deducted from the queue length, with strategy "custom"`),
+			defkit.String("customScalingRunningJobPercentage").Optional().Description(`Share of the running jobs deducted from the queue length, e.g. "0.5", with strategy "custom"`),
+			defkit.StringList("pendingPodConditions").Optional().Description("Pod conditions a job is pending until, e.g. Ready"),
+			defkit.Enum("multipleScalersCalculation").Optional().Values("max", "min", "avg", "sum").
+				Description("Combine the triggers by their maximum, minimum, average or sum"),
+		)
+	rolloutStrategy := defkit.Enum("rolloutStrategy").
+		Default("default").
+		Values("default", "gradual").
+		Description(`Delete the running jobs when the ScaledJob changes with "default", or let them finish with "gradual"`)
+
+	return defkit.NewComponent("scaled-job").
+		Description("Describes jobs started by KEDA as events such as queue messages wait to be processed.").
+		Workload("keda.sh/v1alpha1", "ScaledJob").
+		CustomStatus(scaledJobHealth.BuildStatus()).
+		HealthPolicy(scaledJobHealth.Build()).
+		Helper("HealthProbe", HealthProbeParam()).
+		Params(labels, annotations,
+			triggers, pollingInterval, minReplicaCount, maxReplicaCount,
+			successfulJobsHistoryLimit, failedJobsHistoryLimit, scalingStrategy, rolloutStrategy).
+		Params(JobParams()...).
+		Params(PodContainerParams()...).
+		Params(PodResourceParams()...).
+		Params(PodVolumeParams()...).
+		Params(PodProbeParams()...).
+		Params(PodSchedulingParams()...).
+		Template(scaledJobTemplate)
+}
+
+// scaledJobHealth checks that KEDA reads the triggers of a ScaledJob, and
+// reports whether they ask for jobs.
+var scaledJobHealth = &ObjectHealth{
+	name:       "ScaledJob",
+	apiVersion: "keda.sh/v1alpha1",
+	kind:       "ScaledJob",
+	fields: `_st: {
+	conditions: *[] | [...]
+	...
+} & %[1]s.status
+_ready:  [for c in _st.conditions if c.type == "Ready" {c.status}, "Unknown"][0]
+_active: [for c in _st.conditions if c.type == "Active" {c.status}, "Unknown"][0]`,
+	healthy: `_ready == "True"`,
+	message: `Ready:\(_ready), active:\(_active)`,
+}
+
+// scaledJobTriggers renders the triggers with the metadata values as strings,
+// and requires the metadata keys of the scalers that cannot run without them.
+const scaledJobTriggers = `[for t in parameter.triggers {
+	_required: {
+		rabbitmq:   ["queueName"]
+		kafka:      ["bootstrapServers", "consumerGroup"]
+		prometheus: ["serverAddress", "query", "threshold"]
+		cron:       ["timezone", "start", "end", "desiredReplicas"]
+	}
+	type: t.type
+	if t.name != _|_ {name: t.name}
+	metadata: {for k, v in t.metadata {(k): "\(v)"}}
+	if _required[t.type] != _|_ {metadata: {for k in _required[t.type] {(k)!: string}}}
+	if t.authenticationRef != _|_ {authenticationRef: t.authenticationRef}
+}]`
+
+// scaledJobTemplate defines the template function for scaled-job.
+func scaledJobTemplate(tpl *defkit.Template) {
+	vela := defkit.VelaCtx()
+	labels := defkit.StringKeyMap("labels")
+	annotations := defkit.StringKeyMap("annotations")
+	pollingInterval := defkit.Int("pollingInterval")
+	minReplicaCount := defkit.Int("minReplicaCount")
+	maxReplicaCount := defkit.Int("maxReplicaCount")
+	successfulJobsHistoryLimit := defkit.Int("successfulJobsHistoryLimit")
+	failedJobsHistoryLimit := defkit.Int("failedJobsHistoryLimit")
+	scalingStrategy := defkit.Object("scalingStrategy")
+	rolloutStrategy := defkit.String("rolloutStrategy")
+
+	scaledJob := defkit.NewResource("keda.sh/v1alpha1", "ScaledJob").
+		Set("spec.pollingInterval", pollingInterval).
+		SetIf(minReplicaCount.IsSet(), "spec.minReplicaCount", minReplicaCount).
+		Set("spec.maxReplicaCount", maxReplicaCount).
+		Set("spec.successfulJobsHistoryLimit", successfulJobsHistoryLimit).
+		Set("spec.failedJobsHistoryLimit", failedJobsHistoryLimit).
+		SetIf(scalingStrategy.IsSet(), "spec.scalingStrategy", scalingStrategy).
+		Set("spec.rollout.strategy", rolloutStrategy).
+		Set("spec.triggers", defkit.Reference(scaledJobTriggers)).
+		SpreadIf(labels.IsSet(), "spec.jobTargetRef.template.metadata.labels", labels).
+		Set("spec.jobTargetRef.template.metadata.labels[app.oam.dev/name]", vela.AppName()).
+		Set("spec.jobTargetRef.template.metadata.labels[app.oam.dev/component]", vela.Name()).
+		SetIf(annotations.IsSet(), "spec.jobTargetRef.template.metadata.annotations", annotations)
+	NewJobTemplate("spec.jobTargetRef").Apply(scaledJob)
+	NewPodTemplate(tpl, "spec.jobTargetRef.template").Apply(scaledJob)
+
+	tpl.Output(scaledJob)
+}
+
+func init() {
+	defkit.Register(ScaledJob())
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"cuelang.org/go/cue"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("ScaledJob Component", func() {
+	Describe("ScaledJob()", func() {
+		It("should create a scaled-job component definition", func() {
+			comp := components.ScaledJob()
+			Expect(comp.GetName()).To(Equal("scaled-job"))
+			Expect(comp.GetDescription()).To(ContainSubstring("KEDA"))
+		})
+
+		It("should have keda.sh/v1alpha1 ScaledJob workload", func() {
+			workload := components.ScaledJob().GetWorkload()
+			Expect(workload.APIVersion()).To(Equal("keda.sh/v1alpha1"))
+			Expect(workload.Kind()).To(Equal("ScaledJob"))
+		})
+
+		It("should have scaling parameters and the task container parameters", func() {
+			comp := components.ScaledJob()
+			for _, name := range []string{
+				"triggers", "pollingInterval", "minReplicaCount", "maxReplicaCount",
+				"successfulJobsHistoryLimit", "failedJobsHistoryLimit", "scalingStrategy", "rolloutStrategy",
+				"image", "cmd", "env", "cpu", "memory", "volumeMounts", "backoffLimit", "restart",
+			} {
+				Expect(comp).To(HaveParamNamed(name))
+			}
+		})
+
+		It("should output a ScaledJob", func() {
+			tpl := defkit.NewTemplate()
+			components.ScaledJob().GetTemplate()(tpl)
+			Expect(tpl.GetOutput()).To(BeResourceOfKind("ScaledJob"))
+		})
+	})
+
+	Describe("ScaledJob rendering", func() {
+		ctx := `{name: "worker", appName: "orders", namespace: "prod"}`
+
+		// trigger renders the first trigger of a worker scaled by triggers.
+		trigger := func(triggers string) cue.Value {
+			return renderOutput(components.ScaledJob(), ctx, `{image: "worker:1", triggers: `+triggers+`}`).LookupPath(cue.ParsePath("spec.triggers[0]"))
+		}
+
+		It("should run the task container options as the job target", func() {
+			v := renderOutput(components.ScaledJob(), ctx, `{image: "worker:1", cmd: ["process"], cpu: "500m", backoffLimit: 2, triggers: [{type: "cron", metadata: {timezone: "UTC", start: "0 8 * * *", end: "0 18 * * *", desiredReplicas: 1}}]}`)
+			Expect(lookupInt(v, "spec.pollingInterval")).To(Equal(int64(30)))
+			Expect(lookupInt(v, "spec.maxReplicaCount")).To(Equal(int64(100)))
+			Expect(lookupInt(v, "spec.successfulJobsHistoryLimit")).To(Equal(int64(100)))
+			Expect(lookup(v, "spec.rollout.strategy")).To(Equal("default"))
+			Expect(v.LookupPath(cue.ParsePath("spec.minReplicaCount")).Exists()).To(BeFalse())
+			Expect(lookupInt(v, "spec.jobTargetRef.backoffLimit")).To(Equal(int64(2)))
+			Expect(lookup(v, "spec.jobTargetRef.template.spec.restartPolicy")).To(Equal("Never"))
+			Expect(lookup(v, "spec.jobTargetRef.template.spec.containers[0].image")).To(Equal("worker:1"))
+			Expect(lookup(v, "spec.jobTargetRef.template.spec.containers[0].command[0]")).To(Equal("process"))
+			Expect(lookup(v, "spec.jobTargetRef.template.spec.containers[0].resources.requests.cpu")).To(Equal("500m"))
+			Expect(lookup(v, `spec.jobTargetRef.template.metadata.labels."app.oam.dev/component"`)).To(Equal("worker"))
+		})
+
+		It("should render a rabbitmq trigger with its authentication", func() {
+			t := trigger(`[{type: "rabbitmq", name: "orders", metadata: {queueName: "orders", mode: "QueueLength", value: 5}, authenticationRef: {name: "rabbitmq-auth"}}]`)
+			Expect(lookup(t, "type")).To(Equal("rabbitmq"))
+			Expect(lookup(t, "name")).To(Equal("orders"))
+			Expect(lookup(t, "metadata.queueName")).To(Equal("orders"))
+			Expect(lookup(t, "metadata.value")).To(Equal("5"))
+			Expect(lookup(t, "authenticationRef.name")).To(Equal("rabbitmq-auth"))
+			Expect(lookup(t, "authenticationRef.kind")).To(Equal("TriggerAuthentication"))
+		})
+
+		It("should render a kafka trigger", func() {
+			t := trigger(`[{type: "kafka", metadata: {bootstrapServers: "kafka:9092", consumerGroup: "workers", topic: "orders", lagThreshold: 50, offsetResetPolicy: "earliest"}}]`)
+			Expect(lookup(t, "metadata.bootstrapServers")).To(Equal("kafka:9092"))
+			Expect(lookup(t, "metadata.lagThreshold")).To(Equal("50"))
+			Expect(t.LookupPath(cue.ParsePath("authenticationRef")).Exists()).To(BeFalse())
+		})
+
+		It("should render a prometheus trigger", func() {
+			t := trigger(`[{type: "prometheus", metadata: {serverAddress: "http://prometheus:9090", query: "sum(pending_jobs)", threshold: 10, activationThreshold: 0.5}}]`)
+			Expect(lookup(t, "metadata.query")).To(Equal("sum(pending_jobs)"))
+			Expect(lookup(t, "metadata.threshold")).To(Equal("10"))
+			Expect(lookup(t, "metadata.activationThreshold")).To(Equal("0.5"))
+		})
+
+		It("should render a cron trigger", func() {
+			t := trigger(`[{type: "cron", metadata: {timezone: "Europe/Berlin", start: "0 8 * * 1-5", end: "0 18 * * 1-5", desiredReplicas: 3}}]`)
+			Expect(lookup(t, "metadata.timezone")).To(Equal("Europe/Berlin"))
+			Expect(lookup(t, "metadata.desiredReplicas")).To(Equal("3"))
+		})
+
+		It("should render the triggers of other scalers as they are", func() {
+			t := trigger(`[{type: "aws-sqs-queue", metadata: {queueURL: "https://sqs.eu-west-1.amazonaws.com/1/jobs", awsRegion: "eu-west-1", queueLength: 5}, authenticationRef: {name: "aws", kind: "ClusterTriggerAuthentication"}}]`)
+			Expect(lookup(t, "metadata.queueLength")).To(Equal("5"))
+			Expect(lookup(t, "authenticationRef.kind")).To(Equal("ClusterTriggerAuthentication"))
+		})
+
+		It("should require the metadata keys of the known scalers", func() {
+			for _, triggers := range []string{
+				`[{type: "rabbitmq", metadata: {mode: "QueueLength", value: 5}}]`,
+				`[{type: "kafka", metadata: {bootstrapServers: "kafka:9092"}}]`,
+				`[{type: "prometheus", metadata: {serverAddress: "http://prometheus:9090", query: "up"}}]`,
+				`[{type: "cron", metadata: {timezone: "UTC", start: "0 8 * * *"}}]`,
+			} {
+				err := renderTemplate(components.ScaledJob(), ctx, `{image: "worker:1", triggers: `+triggers+`}`).LookupPath(cue.ParsePath("output")).Validate(cue.Concrete(true))
+				Expect(err).To(MatchError(ContainSubstring("field is required but not present")), triggers)
+			}
+		})
+
+		It("should render the scaling strategy", func() {
+			v := renderOutput(components.ScaledJob(), ctx, `{image: "worker:1", minReplicaCount: 1, rolloutStrategy: "gradual", scalingStrategy: {strategy: "custom", customScalingQueueLengthDeduction: 1, customScalingRunningJobPercentage: "0.5", multipleScalersCalculation: "sum"}, triggers: [{type: "cron", metadata: {timezone: "UTC", start: "0 8 * * *", end: "0 9 * * *", desiredReplicas: 1}}]}`)
+			Expect(lookup(v, "spec.scalingStrategy.strategy")).To(Equal("custom"))
+			Expect(lookupInt(v, "spec.scalingStrategy.customScalingQueueLengthDeduction")).To(Equal(int64(1)))
+			Expect(lookup(v, "spec.scalingStrategy.customScalingRunningJobPercentage")).To(Equal("0.5"))
+			Expect(lookup(v, "spec.scalingStrategy.multipleScalersCalculation")).To(Equal("sum"))
+			Expect(lookupInt(v, "spec.minReplicaCount")).To(Equal(int64(1)))
+			Expect(lookup(v, "spec.rollout.strategy")).To(Equal("gradual"))
+		})
+
+		It("should be healthy once KEDA reads the triggers", func() {
+			ready := `{status: conditions: [{type: "Ready", status: "True"}, {type: "Active", status: "False"}]}`
+			Expect(evalStatus(components.ScaledJob(), "customStatus", "message", "context: output: "+ready).String()).To(Equal("Ready:True, active:False"))
+			Expect(evalStatus(components.ScaledJob(), "healthPolicy", "isHealth", "context: output: "+ready).Bool()).To(BeTrue())
+
+			failing := `{status: conditions: [{type: "Ready", status: "False"}]}`
+			Expect(evalStatus(components.ScaledJob(), "healthPolicy", "isHealth", "context: output: "+failing).Bool()).To(BeFalse())
+		})
+	})
+})
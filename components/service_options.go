@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// serviceOptionFields are the Service spec fields users can set next to the
+// ports and type a component already exposes.
+var serviceOptionFields = []string{
+	"clusterIP",
+	"sessionAffinity",
+	"externalTrafficPolicy",
+	"loadBalancerSourceRanges",
+	"ipFamilyPolicy",
+	"ipFamilies",
+}
+
+// ServiceOptionsParam returns the service parameter tuning the Service that
+// exposes the ports of a component or of the expose trait.
+func ServiceOptionsParam() defkit.Param {
+	return defkit.Object("service").
+		Optional().
+		Description("Specify the options of the Service exposing the ports").
+		WithFields(
+			defkit.StringKeyMap("annotations").Optional().Description("Annotations of the Service, e.g. to configure a cloud load balancer"),
+			defkit.String("clusterIP").Optional().Description(`Set to "None" for a headless Service, only valid for a ClusterIP Service`),
+			defkit.Enum("sessionAffinity").Optional().Values("None", "ClientIP").Description(`Set to "ClientIP" to send the requests of a client to the same pod`),
+			defkit.Enum("externalTrafficPolicy").Optional().Values("Cluster", "Local").
+				Description(`Set to "Local" to keep the client source IP, only valid for a NodePort or LoadBalancer Service`),
+			defkit.StringList("loadBalancerSourceRanges").Optional().Description("Client CIDRs allowed to reach a LoadBalancer Service"),
+			defkit.Enum("ipFamilyPolicy").Optional().Values("SingleStack", "PreferDualStack", "RequireDualStack").Description("Specify the dual-stack behavior of the Service"),
+			defkit.StringList("ipFamilies").Optional().WithSchema(`[..."IPv4" | "IPv6"]`).Description("IP families of the Service, in order of preference"),
+		)
+}
+
+// serviceOptionTypes are the Service types the options only valid for some
+// types are checked against, with the error raised for the other types.
+var serviceOptionTypes = map[string]struct {
+	cond, err string
+}{
+	"clusterIP": {
+		cond: `parameter.service.clusterIP == "None" if %[1]s != "ClusterIP"`,
+		err:  `service.clusterIP None is only valid for a ClusterIP Service`,
+	},
+	"externalTrafficPolicy": {
+		cond: `%[1]s != "NodePort" if %[1]s != "LoadBalancer"`,
+		err:  `service.externalTrafficPolicy is only valid for a NodePort or LoadBalancer Service`,
+	},
+}
+
+// serviceOption returns the CUE value of the Service spec field f of the
+// service parameter, for a Service of the type at the CUE path serviceType.
+func serviceOption(f, serviceType string) string {
+	t, ok := serviceOptionTypes[f]
+	if !ok {
+		return "parameter.service." + f
+	}
+	return fmt.Sprintf(`[if %s {error(%q)}, parameter.service.%s][0]`, fmt.Sprintf(t.cond, serviceType), t.err, f)
+}
+
+// ApplyServiceOptions renders the service parameter into the Service r, of
+// the type at the CUE path serviceType, e.g. "parameter.exposeType".
+func ApplyServiceOptions(r *defkit.Resource, serviceType string) *defkit.Resource {
+	r.SetIf(defkit.PathExists("parameter.service.annotations"), "metadata.annotations", defkit.Reference("parameter.service.annotations"))
+	for _, f := range serviceOptionFields {
+		r.SetIf(defkit.PathExists("parameter.service."+f), "spec."+f, defkit.Reference(serviceOption(f, serviceType)))
+	}
+	return r
+}
+
+// ServiceOptionsCUE renders the service parameter, but its annotations, into
+// the fields of a Service spec written in CUE, of the type at the CUE path
+// serviceType, each line prefixed by indent.
+func ServiceOptionsCUE(indent, serviceType string) string {
+	lines := make([]string, 0, len(serviceOptionFields))
+	for _, f := range serviceOptionFields {
+		lines = append(lines, fmt.Sprintf("if parameter.service.%s != _|_ {\n\t%s: %s\n}", f, f, serviceOption(f, serviceType)))
+	}
+	return indentCUE(strings.Join(lines, "\n"), indent)
+}
@@ -180,19 +180,67 @@ func ServicePortsTransform(ports defkit.Value) *defkit.CollectionOp {
 	})
 }
 
+// --- Status Helpers ---
+
+// readyCondition looks up the Ready condition of the output, as set by Knative,
+// Flux and most controllers following the Kubernetes API conventions: its
+// status, and its message as a ", <message>" suffix.
+const readyCondition = `_readyCond: *[] | [...]
+if context.output.status.conditions != _|_ {
+	_readyCond: [for c in context.output.status.conditions if c.type == "Ready" {c}]
+}
+_readyStatus: *"Unknown" | string
+_readyMessage: *"" | string
+if len(_readyCond) > 0 {
+	_readyStatus: _readyCond[0].status
+	if _readyCond[0].message != _|_ {
+		_readyMessage: ", \(_readyCond[0].message)"
+	}
+}`
+
+// ReadyConditionStatus returns a customStatus reporting the Ready condition.
+// fields declares the status fields used in message, which can also use
+// \(_readyStatus) and \(_readyMessage).
+//
+// Usage:
+//
+//	CustomStatus(ReadyConditionStatus(
+//		defkit.Status().StringField("status.url", "status.url", ""),
+//		`Ready:\(_readyStatus), url:\(status.url)\(_readyMessage)`))
+func ReadyConditionStatus(fields *defkit.StatusBuilder, message string) string {
+	return fields.Build() + "\n" + readyCondition + "\nmessage: \"" + message + "\""
+}
+
+// ReadyConditionHealth returns a healthPolicy that is healthy when the Ready
+// condition is True for the latest generation of the output.
+func ReadyConditionHealth() string {
+	return defkit.Health().
+		IntField("ready.observedGeneration", "status.observedGeneration", 0).
+		Build() + "\n" + readyCondition + `
+isHealth: _readyStatus == "True" && ready.observedGeneration == context.output.metadata.generation`
+}
+
 // --- Common Parameter Definitions ---
+//
+// Superseded by the pod template parameter groups in pod_template.go.
 
 // CommonVolumeParams returns the standard volumeMounts parameter definition.
+//
+// Deprecated: Use PodVolumeParams, which declares the typed volumeMounts schema.
 func CommonVolumeParams() defkit.Param {
 	return defkit.Object("volumeMounts").Description("Volume mount configurations")
 }
 
 // CommonImagePullSecretsParam returns the standard imagePullSecrets parameter.
+//
+// Deprecated: Use PodContainerParams.
 func CommonImagePullSecretsParam() defkit.Param {
 	return defkit.StringList("imagePullSecrets").Description("Specify image pull secrets for your service")
 }
 
 // CommonProbeParams returns liveness and readiness probe parameters.
+//
+// Deprecated: Use PodProbeParams, which types the probes with the #HealthProbe helper.
 func CommonProbeParams() (livenessProbe, readinessProbe defkit.Param) {
 	livenessProbe = defkit.Object("livenessProbe").
 		Description("Instructions for assessing whether the container is alive")
@@ -202,6 +250,8 @@ func CommonProbeParams() (livenessProbe, readinessProbe defkit.Param) {
 }
 
 // CommonResourceParams returns cpu and memory parameters.
+//
+// Deprecated: Use PodResourceParams.
 func CommonResourceParams() (cpu, memory defkit.Param) {
 	cpu = defkit.String("cpu").
 		Description("Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)")
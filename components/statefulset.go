@@ -22,24 +22,16 @@ import (
 
 // StatefulSet creates a statefulset component definition.
 // It describes a StatefulSet for stateful applications with persistent storage
-// and stable network identities.
+// and stable network identities. When serviceName is set, a headless Service
+// of that name governing the pods is created; serviceName and
+// volumeClaimTemplates cannot be changed once the StatefulSet exists. The
+// headless Service is not created by default, as that would set serviceName
+// on StatefulSets created without it, which Kubernetes rejects.
 func StatefulSet() *defkit.ComponentDefinition {
 	// Use StringKeyMap for labels and annotations (generates [string]: string)
 	labels := defkit.StringKeyMap("labels").Optional().Description("Specify the labels in the workload")
 	annotations := defkit.StringKeyMap("annotations").Optional().Description("Specify the annotations in the workload")
 
-	image := defkit.String("image").Description("Which image would you like to use for your service").Short("i")
-
-	// Use Enum for imagePullPolicy to generate proper CUE enum type
-	imagePullPolicy := defkit.Enum("imagePullPolicy").
-		Optional().
-		Values("Always", "Never", "IfNotPresent").
-		Description("Specify image pull policy for your service")
-
-	imagePullSecrets := defkit.StringList("imagePullSecrets").
-		Optional().
-		Description("Specify image pull secrets for your service")
-
 	// Deprecated port parameter - fallback for older definitions
 	port := defkit.Int("port").
 		Optional().
@@ -58,6 +50,7 @@ func StatefulSet() *defkit.ComponentDefinition {
 			defkit.Enum("protocol").Values("TCP", "UDP", "SCTP").Default("TCP").Description("Protocol for port. Must be UDP, TCP, or SCTP"),
 			defkit.Bool("expose").Default(false).Description("Specify if the port should be exposed"),
 			defkit.Int("nodePort").Optional().Description("exposed node port. Only Valid when exposeType is NodePort"),
+			defkit.String("appProtocol").Optional().Description("Application protocol of the port, e.g. http, https or kubernetes.io/h2c"),
 		)
 
 	exposeType := defkit.Enum("exposeType").
@@ -71,139 +64,40 @@ func StatefulSet() *defkit.ComponentDefinition {
 		Ignore().
 		Description("If addRevisionLabel is true, the revision label will be added to the underlying pods")
 
-	cmd := defkit.StringList("cmd").Optional().Description("Commands to run in the container")
-	args := defkit.StringList("args").Optional().Description("Arguments to the entrypoint")
-
-	// Structured env array with detailed valueFrom schema
-	env := defkit.List("env").
+	serviceName := defkit.String("serviceName").
 		Optional().
-		Description("Define arguments by using environment variables").
-		WithFields(
-			defkit.String("name").Description("Environment variable name"),
-			defkit.String("value").Optional().Description("The value of the environment variable"),
-			defkit.Object("valueFrom").Optional().Description("Specifies a source the value of this var should come from").
-				WithFields(
-					defkit.Object("secretKeyRef").Optional().Description("Selects a key of a secret in the pod's namespace").
-						WithFields(
-							defkit.String("name").Description("The name of the secret in the pod's namespace to select from"),
-							defkit.String("key").Description("The key of the secret to select from. Must be a valid secret key"),
-						),
-					defkit.Object("configMapKeyRef").Optional().Description("Selects a key of a config map in the pod's namespace").
-						WithFields(
-							defkit.String("name").Description("The name of the config map in the pod's namespace to select from"),
-							defkit.String("key").Description("The key of the config map to select from. Must be a valid secret key"),
-						),
-				),
-		)
+		Description("Name of the headless Service governing the pods, created with the component. Cannot be changed once the StatefulSet exists")
 
-	cpu := defkit.String("cpu").Optional().Description("Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)")
-	memory := defkit.String("memory").Optional().Description("Specifies the attributes of the memory resource required for the container.")
+	podManagementPolicy := defkit.Enum("podManagementPolicy").
+		Values("OrderedReady", "Parallel").
+		Default("OrderedReady").
+		Description("Specify how pods are created and deleted during scaling")
 
-	// VolumeMounts with subPath in all volume types
-	volumeMounts := defkit.Object("volumeMounts").
+	updateStrategy := defkit.Object("updateStrategy").
 		Optional().
+		Description("Specify the strategy used to replace pods on an update").
 		WithFields(
-			defkit.List("pvc").Optional().Description("Mount PVC type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.String("subPath").Optional(),
-				defkit.String("claimName").Description("The name of the PVC"),
-			),
-			defkit.List("configMap").Optional().Description("Mount ConfigMap type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.String("subPath").Optional(),
-				defkit.Int("defaultMode").Default(420),
-				defkit.String("cmName"),
-				defkit.List("items").Optional().WithFields(
-					defkit.String("key"),
-					defkit.String("path"),
-					defkit.Int("mode").Default(511),
-				),
-			),
-			defkit.List("secret").Optional().Description("Mount Secret type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.String("subPath").Optional(),
-				defkit.Int("defaultMode").Default(420),
-				defkit.String("secretName"),
-				defkit.List("items").Optional().WithFields(
-					defkit.String("key"),
-					defkit.String("path"),
-					defkit.Int("mode").Default(511),
-				),
-			),
-			defkit.List("emptyDir").Optional().Description("Mount EmptyDir type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.String("subPath").Optional(),
-				defkit.Enum("medium").Values("", "Memory").Default(""),
-			),
-			defkit.List("hostPath").Optional().Description("Mount HostPath type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.String("subPath").Optional(),
-				defkit.String("path"),
-			),
+			defkit.Enum("type").Values("RollingUpdate", "OnDelete").Default("RollingUpdate").Description("Type of the update strategy"),
+			defkit.Int("partition").Optional().Description("Only pods with an ordinal greater than or equal to partition are updated. Only valid when type is RollingUpdate"),
 		)
 
-	// Deprecated volumes parameter - discriminated union with type-based conditional fields
-	volumes := defkit.List("volumes").Optional().Description("Deprecated field, use volumeMounts instead.").
+	pvcRetentionPolicy := defkit.Object("persistentVolumeClaimRetentionPolicy").
+		Optional().
+		Description("Specify what happens to the claims created from volumeClaimTemplates").
 		WithFields(
-			defkit.String("name"),
-			defkit.String("mountPath"),
-			defkit.OneOf("type").
-				Description(`Specify volume type, options: "pvc","configMap","secret","emptyDir", default to emptyDir`).
-				Default("emptyDir").
-				Variants(
-					defkit.Variant("pvc").WithFields(
-						defkit.Field("claimName", defkit.ParamTypeString),
-					),
-					defkit.Variant("configMap").WithFields(
-						defkit.Field("defaultMode", defkit.ParamTypeInt).Default(420),
-						defkit.Field("cmName", defkit.ParamTypeString),
-						defkit.Field("items", defkit.ParamTypeArray).Optional().Nested(
-							defkit.Struct("").WithFields(
-								defkit.Field("key", defkit.ParamTypeString),
-								defkit.Field("path", defkit.ParamTypeString),
-								defkit.Field("mode", defkit.ParamTypeInt).Default(511),
-							),
-						),
-					),
-					defkit.Variant("secret").WithFields(
-						defkit.Field("defaultMode", defkit.ParamTypeInt).Default(420),
-						defkit.Field("secretName", defkit.ParamTypeString),
-						defkit.Field("items", defkit.ParamTypeArray).Optional().Nested(
-							defkit.Struct("").WithFields(
-								defkit.Field("key", defkit.ParamTypeString),
-								defkit.Field("path", defkit.ParamTypeString),
-								defkit.Field("mode", defkit.ParamTypeInt).Default(511),
-							),
-						),
-					),
-					defkit.Variant("emptyDir").WithFields(
-						defkit.Field("medium", defkit.ParamTypeString).Default("").Values("", "Memory"),
-					),
-				),
+			defkit.Enum("whenDeleted").Values("Retain", "Delete").Default("Retain").Description("What happens to the claims when the StatefulSet is deleted"),
+			defkit.Enum("whenScaled").Values("Retain", "Delete").Default("Retain").Description("What happens to the claims when the StatefulSet is scaled down"),
 		)
 
-	// Health probes referencing the helper definition
-	livenessProbe := defkit.Object("livenessProbe").
-		Optional().
-		Description("Instructions for assessing whether the container is alive.").
-		WithSchemaRef("HealthProbe")
-	readinessProbe := defkit.Object("readinessProbe").
-		Optional().
-		Description("Instructions for assessing whether the container is in a suitable state to serve traffic.").
-		WithSchemaRef("HealthProbe")
-
-	// Structured hostAliases with required hostnames
-	hostAliases := defkit.List("hostAliases").
+	volumeClaimTemplates := defkit.Array("volumeClaimTemplates").
 		Optional().
-		Description("Specify the hostAliases to add").
+		Description("Claims each pod gets its own persistent volume from, mounted in the container").
 		WithFields(
-			defkit.String("ip"),
-			defkit.StringList("hostnames"),
+			defkit.String("name").Description("Name of the claim template and of the volume"),
+			defkit.String("mountPath").Description("Path the volume is mounted at in the container"),
+			defkit.String("storageClass").Optional().Description("Storage class of the claims, defaults to the cluster default"),
+			defkit.String("size").Default("1Gi").Description("Requested storage size of each claim"),
+			defkit.StringList("accessModes").WithSchema(`*["ReadWriteOnce"] | [...string]`).Description("Access modes of the claims"),
 		)
 
 	return defkit.NewComponent("statefulset").
@@ -222,24 +116,33 @@ func StatefulSet() *defkit.ComponentDefinition {
 				IntField("ready.readyReplicas", "status.readyReplicas", 0).
 				IntField("ready.replicas", "status.replicas", 0).
 				IntField("ready.observedGeneration", "status.observedGeneration", 0).
+				StringField("ready.currentRevision", "status.currentRevision", "").
+				StringField("ready.updateRevision", "status.updateRevision", "").
+				IntField("ready.partition", "spec.updateStrategy.rollingUpdate.partition", 0).
 				HealthyWhen(
 					defkit.StatusEq("context.output.spec.replicas", "ready.readyReplicas"),
-					defkit.StatusEq("context.output.spec.replicas", "ready.updatedReplicas"),
+					// A partitioned rollout only updates the pods from the partition ordinal on.
+					defkit.StatusOr(
+						defkit.StatusEq("context.output.spec.replicas", "ready.updatedReplicas"),
+						defkit.StatusAnd("ready.partition > 0", defkit.StatusGte("ready.updatedReplicas", "context.output.spec.replicas - ready.partition")),
+					),
 					defkit.StatusEq("context.output.spec.replicas", "ready.replicas"),
 					defkit.StatusOr(defkit.StatusEq("ready.observedGeneration", "context.output.metadata.generation"), "ready.observedGeneration > context.output.metadata.generation"),
+					// It also keeps the pods below the partition on the current revision.
+					defkit.StatusOr(defkit.StatusEq("ready.currentRevision", "ready.updateRevision"), "ready.partition > 0"),
 				).
 				WithDefault().
 				WithDisableAnnotation("app.oam.dev/disable-health-check").
 				Build(),
 		).
-		Params(
-			labels, annotations,
-			image, imagePullPolicy, imagePullSecrets,
-			port, ports, exposeType, addRevisionLabel,
-			cmd, args, env,
-			cpu, memory, volumeMounts, volumes,
-			livenessProbe, readinessProbe, hostAliases,
-		).
+		Params(labels, annotations).
+		Params(PodContainerParams()...).
+		Params(port, ports, exposeType, ServiceOptionsParam(), addRevisionLabel).
+		Params(PodResourceParams()...).
+		Params(PodVolumeParams()...).
+		Params(PodProbeParams()...).
+		Params(PodSchedulingParams()...).
+		Params(serviceName, podManagementPolicy, updateStrategy, pvcRetentionPolicy, volumeClaimTemplates).
 		Helper("HealthProbe", HealthProbeParam()).
 		Template(statefulsetTemplate)
 }
@@ -247,25 +150,27 @@ func StatefulSet() *defkit.ComponentDefinition {
 // statefulsetTemplate defines the template function for statefulset.
 func statefulsetTemplate(tpl *defkit.Template) {
 	vela := defkit.VelaCtx()
-	image := defkit.String("image")
 	port := defkit.Int("port")
 	ports := defkit.List("ports")
 	exposeType := defkit.String("exposeType")
 	addRevisionLabel := defkit.Bool("addRevisionLabel")
-	cmd := defkit.StringList("cmd")
-	args := defkit.StringList("args")
-	env := defkit.List("env")
-	cpu := defkit.String("cpu")
-	memory := defkit.String("memory")
-	volumeMounts := defkit.Object("volumeMounts")
-	volumes := defkit.List("volumes")
-	livenessProbe := defkit.Object("livenessProbe")
-	readinessProbe := defkit.Object("readinessProbe")
-	hostAliases := defkit.List("hostAliases")
 	labels := defkit.Object("labels")
 	annotations := defkit.Object("annotations")
-	imagePullPolicy := defkit.String("imagePullPolicy")
-	imagePullSecrets := defkit.StringList("imagePullSecrets")
+	serviceName := defkit.String("serviceName")
+	podManagementPolicy := defkit.String("podManagementPolicy")
+	updateStrategy := defkit.Object("updateStrategy")
+	pvcRetentionPolicy := defkit.Object("persistentVolumeClaimRetentionPolicy")
+	volumeClaimTemplates := defkit.List("volumeClaimTemplates")
+
+	claimMountsArray := tpl.Helper("claimMountsArray").
+		From(volumeClaimTemplates).
+		Guard(volumeClaimTemplates.IsSet()).
+		Map(defkit.FieldMap{
+			"name":      defkit.FieldRef("name"),
+			"mountPath": defkit.FieldRef("mountPath"),
+		}).
+		Build()
+	pod := NewPodTemplate(tpl, "spec.template").WithVolumeMounts(volumeClaimTemplates.IsSet(), claimMountsArray)
 
 	// Transform ports to container format using ForEachWith for complex
 	// _name let binding with containerPort preference and protocol suffix.
@@ -308,60 +213,6 @@ func statefulsetTemplate(tpl *defkit.Template) {
 		})
 	})
 
-	// Transform imagePullSecrets: ["secret1", "secret2"] -> [{name: "secret1"}, ...]
-	pullSecrets := defkit.Each(imagePullSecrets).Wrap("name")
-
-	// Define template-level helpers for volumeMounts
-	mountsArray := tpl.Helper("mountsArray").
-		FromFields(volumeMounts, "pvc", "configMap", "secret", "emptyDir", "hostPath").
-		Pick("name", "mountPath").
-		PickIf(defkit.ItemFieldIsSet("subPath"), "subPath").
-		Build()
-
-	// volumesList: Transform volume sources to Kubernetes volume specs
-	volumesList := tpl.Helper("volumesList").
-		FromFields(volumeMounts, "pvc", "configMap", "secret", "emptyDir", "hostPath").
-		MapBySource(map[string]defkit.FieldMap{
-			"pvc": {
-				"name":                  defkit.FieldRef("name"),
-				"persistentVolumeClaim": defkit.Nested(defkit.FieldMap{"claimName": defkit.FieldRef("claimName")}),
-			},
-			"configMap": {
-				"name": defkit.FieldRef("name"),
-				"configMap": defkit.Nested(defkit.FieldMap{
-					"name":        defkit.FieldRef("cmName"),
-					"defaultMode": defkit.FieldRef("defaultMode"),
-					"items":       defkit.Optional("items"),
-				}),
-			},
-			"secret": {
-				"name": defkit.FieldRef("name"),
-				"secret": defkit.Nested(defkit.FieldMap{
-					"secretName":  defkit.FieldRef("secretName"),
-					"defaultMode": defkit.FieldRef("defaultMode"),
-					"items":       defkit.Optional("items"),
-				}),
-			},
-			"emptyDir": {
-				"name":     defkit.FieldRef("name"),
-				"emptyDir": defkit.Nested(defkit.FieldMap{"medium": defkit.FieldRef("medium")}),
-			},
-			"hostPath": {
-				"name":     defkit.FieldRef("name"),
-				"hostPath": defkit.Nested(defkit.FieldMap{"path": defkit.FieldRef("path")}),
-			},
-		}).
-		Build()
-
-	// deDupVolumesArray: Deduplicated volumes by name
-	deDupVolumesArray := tpl.Helper("deDupVolumesArray").
-		FromHelper(volumesList).
-		Dedupe("name").
-		Build()
-
-	// Suppress unused variable warnings
-	_ = volumesList
-
 	// Primary output: StatefulSet
 	statefulset := defkit.NewResource("apps/v1", "StatefulSet").
 		Set("spec.selector.matchLabels[app.oam.dev/component]", vela.Name()).
@@ -373,76 +224,49 @@ func statefulsetTemplate(tpl *defkit.Template) {
 		// SpreadIf spreads user labels inside the labels block
 		SpreadIf(labels.IsSet(), "spec.template.metadata.labels", labels).
 		SetIf(annotations.IsSet(), "spec.template.metadata.annotations", annotations).
-		// Container spec
-		Set("spec.template.spec.containers[0].name", vela.Name()).
-		Set("spec.template.spec.containers[0].image", image).
+		SetIf(serviceName.IsSet(), "spec.serviceName", serviceName).
+		Set("spec.podManagementPolicy", podManagementPolicy).
+		// The partition is nested under the type check, as CUE evaluates both
+		// sides of && and updateStrategy is optional.
+		SetIf(updateStrategy.IsSet(), "spec.updateStrategy", defkit.Reference(
+			`{type: parameter.updateStrategy.type, if parameter.updateStrategy.type == "RollingUpdate" if parameter.updateStrategy.partition != _|_ {rollingUpdate: partition: parameter.updateStrategy.partition}}`,
+		)).
+		SetIf(pvcRetentionPolicy.IsSet(), "spec.persistentVolumeClaimRetentionPolicy", pvcRetentionPolicy).
+		SetIf(volumeClaimTemplates.IsSet(), "spec.volumeClaimTemplates", defkit.Each(volumeClaimTemplates).Map(defkit.FieldMap{
+			"metadata": defkit.NestedFieldMap(defkit.FieldMap{
+				"name": defkit.FieldRef("name"),
+			}),
+			"spec": defkit.NestedFieldMap(defkit.FieldMap{
+				"accessModes":      defkit.FieldRef("accessModes"),
+				"storageClassName": defkit.OptionalFieldRef("storageClass"),
+				"resources": defkit.NestedFieldMap(defkit.FieldMap{
+					"requests": defkit.NestedFieldMap(defkit.FieldMap{
+						"storage": defkit.FieldRef("size"),
+					}),
+				}),
+			}),
+		}))
+	pod.Apply(statefulset).
 		// Deprecated port fallback (before modern ports)
 		If(defkit.And(port.IsSet(), ports.NotSet())).
-		Set("spec.template.spec.containers[0].ports", defkit.InlineArray(map[string]defkit.Value{
+		Set(pod.Container("ports"), defkit.InlineArray(map[string]defkit.Value{
 			"containerPort": port,
 		})).
 		EndIf().
-		SetIf(ports.IsSet(), "spec.template.spec.containers[0].ports", containerPorts).
-		SetIf(imagePullPolicy.IsSet(), "spec.template.spec.containers[0].imagePullPolicy", imagePullPolicy).
-		SetIf(cmd.IsSet(), "spec.template.spec.containers[0].command", cmd).
-		SetIf(args.IsSet(), "spec.template.spec.containers[0].args", args).
-		SetIf(env.IsSet(), "spec.template.spec.containers[0].env", env).
-		SetIf(defkit.PathExists(`context["config"]`), "spec.template.spec.containers[0].env", defkit.Reference("context.config")).
-		SetIf(cpu.IsSet(), "spec.template.spec.containers[0].resources.limits.cpu", cpu).
-		SetIf(cpu.IsSet(), "spec.template.spec.containers[0].resources.requests.cpu", cpu).
-		SetIf(memory.IsSet(), "spec.template.spec.containers[0].resources.limits.memory", memory).
-		SetIf(memory.IsSet(), "spec.template.spec.containers[0].resources.requests.memory", memory).
-		// Deprecated volumes fallback - container volumeMounts
-		If(defkit.And(volumes.IsSet(), volumeMounts.NotSet())).
-		Set("spec.template.spec.containers[0].volumeMounts",
-			defkit.Each(volumes).Map(defkit.FieldMap{
-				"mountPath": defkit.FieldRef("mountPath"),
-				"name":      defkit.FieldRef("name"),
-			})).
-		EndIf().
-		SetIf(volumeMounts.IsSet(), "spec.template.spec.containers[0].volumeMounts", mountsArray).
-		SetIf(livenessProbe.IsSet(), "spec.template.spec.containers[0].livenessProbe", livenessProbe).
-		SetIf(readinessProbe.IsSet(), "spec.template.spec.containers[0].readinessProbe", readinessProbe).
-		// Pod spec
-		SetIf(hostAliases.IsSet(), "spec.template.spec.hostAliases", hostAliases).
-		Directive("spec.template.spec.hostAliases", "patchKey=ip").
-		SetIf(imagePullSecrets.IsSet(), "spec.template.spec.imagePullSecrets", pullSecrets).
-		// Deprecated volumes fallback - pod spec volumes
-		If(defkit.And(volumes.IsSet(), volumeMounts.NotSet())).
-		Set("spec.template.spec.volumes",
-			defkit.Each(volumes).
-				Map(defkit.FieldMap{
-					"name": defkit.FieldRef("name"),
-				}).
-				MapVariant("type", "pvc", defkit.FieldMap{
-					"persistentVolumeClaim": defkit.NestedFieldMap(defkit.FieldMap{
-						"claimName": defkit.FieldRef("claimName"),
-					}),
-				}).
-				MapVariant("type", "configMap", defkit.FieldMap{
-					"configMap": defkit.NestedFieldMap(defkit.FieldMap{
-						"defaultMode": defkit.FieldRef("defaultMode"),
-						"name":        defkit.FieldRef("cmName"),
-						"items":       defkit.OptionalFieldRef("items"),
-					}),
-				}).
-				MapVariant("type", "secret", defkit.FieldMap{
-					"secret": defkit.NestedFieldMap(defkit.FieldMap{
-						"defaultMode": defkit.FieldRef("defaultMode"),
-						"secretName":  defkit.FieldRef("secretName"),
-						"items":       defkit.OptionalFieldRef("items"),
-					}),
-				}).
-				MapVariant("type", "emptyDir", defkit.FieldMap{
-					"emptyDir": defkit.NestedFieldMap(defkit.FieldMap{
-						"medium": defkit.FieldRef("medium"),
-					}),
-				})).
-		EndIf().
-		SetIf(volumeMounts.IsSet(), "spec.template.spec.volumes", deDupVolumesArray)
+		SetIf(ports.IsSet(), pod.Container("ports"), containerPorts)
 
 	tpl.Output(statefulset)
 
+	// Auxiliary output: headless Service giving each pod a stable DNS name.
+	// It is only created with serviceName, as spec.serviceName of existing
+	// StatefulSets cannot be changed.
+	headless := defkit.NewResource("v1", "Service").
+		Set("metadata.name", serviceName).
+		Set("spec.clusterIP", defkit.Lit("None")).
+		Set("spec.selector[app.oam.dev/component]", vela.Name())
+
+	tpl.OutputsIf(serviceName.IsSet(), "statefulsetHeadless", headless)
+
 	// exposePorts helper: Complex iteration with guard, filter, conditionals,
 	// _name let binding with containerPort preference, and protocol suffix.
 	// Uses FromArray with ForEachWithGuardedFiltered for full expressiveness.
@@ -499,6 +323,10 @@ func statefulsetTemplate(tpl *defkit.Template) {
 			item.IfSet("protocol", func() {
 				item.Set("protocol", v.Field("protocol"))
 			})
+
+			item.IfSet("appProtocol", func() {
+				item.Set("appProtocol", v.Field("appProtocol"))
+			})
 		},
 	)
 
@@ -513,6 +341,7 @@ func statefulsetTemplate(tpl *defkit.Template) {
 		Set("spec.selector[app.oam.dev/component]", vela.Name()).
 		Set("spec.ports", exposePorts).
 		Set("spec.type", exposeType)
+	ApplyServiceOptions(service, "parameter.exposeType")
 
 	tpl.OutputsIf(exposePorts.NotEmpty(), "statefulsetsExpose", service)
 }
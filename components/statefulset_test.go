@@ -17,8 +17,10 @@ limitations under the License.
 package components_test
 
 import (
+	"fmt"
 	"strings"
 
+	"cuelang.org/go/cue"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -51,10 +53,15 @@ var _ = Describe("StatefulSet Component", func() {
 		It("should NOT have removed parameters", func() {
 			comp := components.StatefulSet()
 			Expect(comp).NotTo(HaveParamNamed("replicas"))
-			Expect(comp).NotTo(HaveParamNamed("serviceName"))
-			Expect(comp).NotTo(HaveParamNamed("podManagementPolicy"))
-			Expect(comp).NotTo(HaveParamNamed("updateStrategy"))
-			Expect(comp).NotTo(HaveParamNamed("volumeClaimTemplates"))
+		})
+
+		It("should have stateful storage and rollout parameters", func() {
+			comp := components.StatefulSet()
+			Expect(comp).To(HaveParamNamed("serviceName"))
+			Expect(comp).To(HaveParamNamed("podManagementPolicy"))
+			Expect(comp).To(HaveParamNamed("updateStrategy"))
+			Expect(comp).To(HaveParamNamed("persistentVolumeClaimRetentionPolicy"))
+			Expect(comp).To(HaveParamNamed("volumeClaimTemplates"))
 		})
 
 		It("should have correct parameters matching reference CUE", func() {
@@ -103,9 +110,33 @@ var _ = Describe("StatefulSet Component", func() {
 			tpl := defkit.NewTemplate()
 			comp.GetTemplate()(tpl)
 			outputs := tpl.GetOutputs()
-			Expect(outputs).NotTo(HaveKey("statefulsetHeadless"))
 			Expect(outputs).NotTo(HaveKey("statefulsetExpose"))
 		})
+
+		It("should produce statefulsetHeadless as auxiliary output", func() {
+			comp := components.StatefulSet()
+			tpl := defkit.NewTemplate()
+			comp.GetTemplate()(tpl)
+			outputs := tpl.GetOutputs()
+			Expect(outputs).To(HaveKey("statefulsetHeadless"))
+			Expect(outputs["statefulsetHeadless"]).To(BeService())
+		})
+
+		It("should only render statefulsetHeadless with serviceName", func() {
+			comp := components.StatefulSet()
+			outputs := comp.RenderAll(defkit.TestContext().
+				WithName("db").
+				WithParam("image", "postgres:16"))
+			Expect(outputs.Auxiliary).NotTo(HaveKey("statefulsetHeadless"))
+
+			outputs = comp.RenderAll(defkit.TestContext().
+				WithName("db").
+				WithParam("image", "postgres:16").
+				WithParam("serviceName", "db-pods"))
+			Expect(outputs.Auxiliary).To(HaveKey("statefulsetHeadless"))
+			Expect(outputs.Auxiliary["statefulsetHeadless"].Get("metadata.name")).To(Equal("db-pods"))
+			Expect(outputs.Primary.Get("spec.serviceName")).To(Equal("db-pods"))
+		})
 	})
 
 	Describe("CUE Generation", func() {
@@ -144,17 +175,44 @@ var _ = Describe("StatefulSet Component", func() {
 			Expect(cueOutput).To(ContainSubstring(`app.oam.dev/disable-health-check`))
 		})
 
-		It("should NOT generate removed parameters in CUE", func() {
-			// Extract the parameter section to check for removed params
-			paramIdx := strings.Index(cueOutput, "\tparameter: {")
-			Expect(paramIdx).To(BeNumerically(">", 0))
-			paramSection := cueOutput[paramIdx:]
+		It("should generate stateful storage and rollout parameters", func() {
+			Expect(cueOutput).To(ContainSubstring(`serviceName?: string`))
+			Expect(cueOutput).To(ContainSubstring(`podManagementPolicy: *"OrderedReady" | "Parallel"`))
+			Expect(cueOutput).To(ContainSubstring(`type: *"RollingUpdate" | "OnDelete"`))
+			Expect(cueOutput).To(ContainSubstring(`partition?: int`))
+			Expect(cueOutput).To(ContainSubstring(`whenDeleted: *"Retain" | "Delete"`))
+			Expect(cueOutput).To(ContainSubstring(`whenScaled: *"Retain" | "Delete"`))
+			Expect(cueOutput).To(ContainSubstring(`size: *"1Gi" | string`))
+			Expect(cueOutput).To(ContainSubstring(`accessModes: *["ReadWriteOnce"] | [...string]`))
+		})
+
+		It("should generate volumeClaimTemplates mounted in the container", func() {
+			Expect(cueOutput).To(ContainSubstring("volumeClaimTemplates: [for v in parameter.volumeClaimTemplates"))
+			Expect(cueOutput).To(ContainSubstring("storage: v.size"))
+			Expect(cueOutput).To(ContainSubstring("storageClassName: v.storageClass"))
+			Expect(cueOutput).To(ContainSubstring("claimMountsArray: ["))
+			Expect(cueOutput).To(ContainSubstring(`"list"`))
+			Expect(cueOutput).To(ContainSubstring("volumeMounts: list.Concat([mountsArray, legacyMountsArray, claimMountsArray])"))
+		})
+
+		It("should generate serviceName and the headless Service only when serviceName is set", func() {
+			Expect(cueOutput).To(ContainSubstring("serviceName: parameter.serviceName"))
+			Expect(cueOutput).NotTo(ContainSubstring(`-headless"`))
+			Expect(cueOutput).To(MatchRegexp(`if parameter\["serviceName"\] != _\|_ \{\s*statefulsetHeadless: \{`))
+			Expect(cueOutput).To(ContainSubstring(`clusterIP: "None"`))
+		})
 
-			// These should not appear as top-level parameters
-			Expect(paramSection).NotTo(ContainSubstring(`serviceName`))
-			Expect(paramSection).NotTo(ContainSubstring(`podManagementPolicy`))
-			Expect(paramSection).NotTo(ContainSubstring(`updateStrategy`))
-			Expect(paramSection).NotTo(ContainSubstring(`volumeClaimTemplates`))
+		It("should generate updateStrategy with partition", func() {
+			Expect(cueOutput).To(ContainSubstring("podManagementPolicy: parameter.podManagementPolicy"))
+			Expect(cueOutput).To(ContainSubstring("type: parameter.updateStrategy.type"))
+			Expect(cueOutput).To(ContainSubstring("partition: parameter.updateStrategy.partition"))
+			Expect(cueOutput).To(ContainSubstring("persistentVolumeClaimRetentionPolicy: parameter.persistentVolumeClaimRetentionPolicy"))
+		})
+
+		It("should generate healthPolicy waiting for the update revision", func() {
+			Expect(cueOutput).To(ContainSubstring("currentRevision: context.output.status.currentRevision"))
+			Expect(cueOutput).To(ContainSubstring("updateRevision: context.output.status.updateRevision"))
+			Expect(cueOutput).To(ContainSubstring("(ready.currentRevision == ready.updateRevision || ready.partition > 0)"))
 		})
 
 		It("should generate deprecated port parameter with ignore and short directives", func() {
@@ -273,5 +331,53 @@ var _ = Describe("StatefulSet Component", func() {
 			Expect(cueOutput).To(ContainSubstring("v.protocol != _|_"))
 			Expect(cueOutput).To(ContainSubstring("protocol: v.protocol"))
 		})
+
+		It("should generate the service options on the exposed Service", func() {
+			Expect(cueOutput).To(ContainSubstring("service?: {"))
+			Expect(cueOutput).To(ContainSubstring(`ipFamilies?: [..."IPv4" | "IPv6"]`))
+			Expect(cueOutput).To(ContainSubstring("annotations: parameter.service.annotations"))
+			Expect(cueOutput).To(ContainSubstring(`clusterIP: [if parameter.service.clusterIP == "None" if parameter.exposeType != "ClusterIP" {error(`))
+			Expect(cueOutput).To(ContainSubstring("sessionAffinity: parameter.service.sessionAffinity"))
+			Expect(cueOutput).To(ContainSubstring(`externalTrafficPolicy: [if parameter.exposeType != "NodePort" if parameter.exposeType != "LoadBalancer" {error(`))
+		})
+
+		It("should generate appProtocol optional conditional in exposePorts", func() {
+			Expect(cueOutput).To(ContainSubstring("appProtocol?: string"))
+			Expect(cueOutput).To(ContainSubstring("appProtocol: v.appProtocol"))
+		})
+	})
+
+	Describe("StatefulSet rendering", func() {
+		ctx := `{name: "db", appName: "shop", namespace: "prod"}`
+
+		It("should render without updateStrategy", func() {
+			v := renderOutput(components.StatefulSet(), ctx, `{image: "postgres:16"}`)
+			Expect(v.LookupPath(cue.ParsePath("spec.updateStrategy")).Exists()).To(BeFalse())
+			Expect(v.LookupPath(cue.ParsePath("spec.serviceName")).Exists()).To(BeFalse())
+		})
+
+		It("should only render the partition of a RollingUpdate", func() {
+			v := renderOutput(components.StatefulSet(), ctx, `{image: "postgres:16", updateStrategy: {partition: 2}}`)
+			Expect(lookup(v, "spec.updateStrategy.type")).To(Equal("RollingUpdate"))
+			Expect(lookupInt(v, "spec.updateStrategy.rollingUpdate.partition")).To(Equal(int64(2)))
+
+			v = renderOutput(components.StatefulSet(), ctx, `{image: "postgres:16", updateStrategy: {type: "OnDelete", partition: 2}}`)
+			Expect(lookup(v, "spec.updateStrategy.type")).To(Equal("OnDelete"))
+			Expect(v.LookupPath(cue.ParsePath("spec.updateStrategy.rollingUpdate")).Exists()).To(BeFalse())
+
+			v = renderOutput(components.StatefulSet(), ctx, `{image: "postgres:16", updateStrategy: {}}`)
+			Expect(v.LookupPath(cue.ParsePath("spec.updateStrategy.rollingUpdate")).Exists()).To(BeFalse())
+		})
+
+		It("should be healthy once the pods from the partition on are updated", func() {
+			statefulset := func(partition, updated int, currentRevision string) string {
+				return fmt.Sprintf(`{metadata: generation: 2, spec: {replicas: 3, updateStrategy: rollingUpdate: partition: %d}, status: {observedGeneration: 2, replicas: 3, readyReplicas: 3, updatedReplicas: %d, currentRevision: %q, updateRevision: "db-v2"}}`, partition, updated, currentRevision)
+			}
+			Expect(evalStatus(components.StatefulSet(), "healthPolicy", "isHealth", "context: output: "+statefulset(0, 3, "db-v2")).Bool()).To(BeTrue())
+			Expect(evalStatus(components.StatefulSet(), "healthPolicy", "isHealth", "context: output: "+statefulset(0, 1, "db-v1")).Bool()).To(BeFalse())
+			Expect(evalStatus(components.StatefulSet(), "healthPolicy", "isHealth", "context: output: "+statefulset(2, 1, "db-v1")).Bool()).To(BeTrue())
+			Expect(evalStatus(components.StatefulSet(), "healthPolicy", "isHealth", "context: output: "+statefulset(1, 1, "db-v1")).Bool()).To(BeFalse())
+			Expect(evalStatus(components.StatefulSet(), "healthPolicy", "isHealth", "context: output: "+statefulset(5, 0, "db-v1")).Bool()).To(BeTrue())
+		})
 	})
 })
@@ -0,0 +1,222 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// StaticSite creates the static-site component definition.
+// It describes a static website served by nginx, from inline files, from a
+// ConfigMap or Secret, or from a git repository cloned by a git-sync init
+// container. Exactly one of files, configMap, secret and git is set.
+//
+// The hash of the content parameters and of the nginx settings is set in a
+// pod annotation, so that changing them rolls the pods out. Changes to the
+// data of a referenced ConfigMap or Secret, or to a git branch, need a
+// restart.
+func StaticSite() *defkit.ComponentDefinition {
+	image := defkit.String("image").
+		Default("nginx:1.27-alpine").
+		Description("Image of nginx serving the site")
+	replicas := defkit.Int("replicas").
+		Default(1).
+		Min(0).
+		Description("Number of replicas")
+
+	files := defkit.StringKeyMap("files").
+		Optional().
+		Description("Content of the files of the site by file name, e.g. `index.html`. Subdirectories are not supported")
+	configMap := defkit.String("configMap").
+		Optional().
+		Description("Serve the files of this ConfigMap")
+	secret := defkit.String("secret").
+		Optional().
+		Description("Serve the files of this Secret")
+	git := defkit.Object("git").
+		Optional().
+		Description("Serve the files of a git repository, cloned when the pods start").
+		WithFields(
+			defkit.String("repo").Description("URL of the repository"),
+			defkit.String("ref").Default("HEAD").Description("Branch, tag or commit to clone"),
+			defkit.String("path").Optional().Description("Directory of the site in the repository, defaults to its root"),
+			defkit.String("credentialsSecret").Optional().Description("Secret with the username and password keys to clone a private repository"),
+			defkit.String("image").Default("registry.k8s.io/git-sync/git-sync:v4.4.0").Description("Image of git-sync"),
+		)
+
+	spa := defkit.Bool("spa").
+		Default(true).
+		Description("Serve index.html for the paths matching no file, for single page applications")
+	gzip := defkit.Bool("gzip").
+		Default(true).
+		Description("Compress text responses with gzip")
+	cacheMaxAge := defkit.Int("cacheMaxAge").
+		Default(3600).
+		Min(0).
+		Description("Number of seconds browsers cache assets such as scripts, styles, images and fonts. HTML is always revalidated")
+
+	ingress := defkit.Object("ingress").
+		Optional().
+		Description("Expose the site on a host through an Ingress").
+		WithFields(
+			defkit.String("host").Description("Host name of the site"),
+			defkit.String("className").Optional().Description("IngressClass of the Ingress, defaults to the cluster default"),
+			defkit.String("tlsSecretName").Optional().Description("Secret with the TLS certificate of the host, to serve the site over HTTPS"),
+		)
+
+	return WithRawTemplateBlocks(defkit.NewComponent("static-site").
+		Description("Describes a static website served by nginx from inline files, a ConfigMap, a Secret or a git repository.").
+		Workload("apps/v1", "Deployment").
+		WithImports("crypto/sha256", "encoding/hex", "encoding/json", "strings").
+		CustomStatus(defkit.DeploymentStatus().Build()).
+		HealthPolicy(defkit.DeploymentHealth().Build()).
+		Params(image, replicas, files, configMap, secret, git, spa, gzip, cacheMaxAge, ingress).
+		Params(PodResourceParams()...).
+		Template(staticSiteTemplate))
+}
+
+// staticSiteContent selects the source of the files, and renders the nginx
+// configuration and the pod volumes serving them.
+const staticSiteContent = `_sources: [for k in ["files", "configMap", "secret", "git"] if parameter[k] != _|_ {k}]
+_source: [
+	if len(_sources) == 0 {error("one of files, configMap, secret or git is required")},
+	if len(_sources) > 1 {error("only one of files, configMap, secret or git can be set, got \(strings.Join(_sources, ", "))")},
+	_sources[0],
+][0]
+
+_root: [
+	if _source == "git" if parameter.git.path != _|_ {"/usr/share/nginx/html/current/\(parameter.git.path)"},
+	if _source == "git" {"/usr/share/nginx/html/current"},
+	"/usr/share/nginx/html",
+][0]
+
+_content: {
+	for k in ["files", "configMap", "secret", "git", "spa", "gzip", "cacheMaxAge"] if parameter[k] != _|_ {
+		(k): parameter[k]
+	}
+}
+_contentHash: hex.Encode(sha256.Sum256(json.Marshal(_content)))
+
+_nginxConf: strings.Join([
+	"server {",
+	"    listen 8080;",
+	"    root \(_root);",
+	"    index index.html;",
+	if parameter.gzip {"    gzip on;\n    gzip_vary on;\n    gzip_min_length 256;\n    gzip_types text/css text/plain application/javascript application/json image/svg+xml;"},
+	"    location / {",
+	if parameter.spa {"        try_files $uri $uri/ /index.html;"},
+	if !parameter.spa {"        try_files $uri $uri/ =404;"},
+	"        add_header Cache-Control \"no-cache\";",
+	"    }",
+	"    location ~* \\.(?:css|js|mjs|map|json|png|jpe?g|gif|svg|ico|webp|avif|woff2?|ttf)$ {",
+	"        try_files $uri =404;",
+	"        add_header Cache-Control \"public, max-age=\(parameter.cacheMaxAge)\";",
+	"    }",
+	"}",
+	"",
+], "\n")
+
+_volumes: [
+	{name: "nginx-conf", configMap: name: "\(context.name)-nginx"},
+	if _source == "files" {name: "content", configMap: name: "\(context.name)-content"},
+	if _source == "configMap" {name: "content", configMap: name: parameter.configMap},
+	if _source == "secret" {name: "content", secret: secretName: parameter.secret},
+	if _source == "git" {name: "content", emptyDir: {}},
+]
+
+_volumeMounts: [
+	{name: "nginx-conf", mountPath: "/etc/nginx/conf.d"},
+	{name: "content", mountPath: "/usr/share/nginx/html"},
+]
+
+_initContainers: [if _source == "git" {
+	name:  "git-sync"
+	image: parameter.git.image
+	args: ["--repo=\(parameter.git.repo)", "--ref=\(parameter.git.ref)", "--root=/usr/share/nginx/html", "--link=current", "--depth=1", "--one-time"]
+	if parameter.git.credentialsSecret != _|_ {
+		env: [
+			{name: "GITSYNC_USERNAME", valueFrom: secretKeyRef: {name: parameter.git.credentialsSecret, key: "username"}},
+			{name: "GITSYNC_PASSWORD", valueFrom: secretKeyRef: {name: parameter.git.credentialsSecret, key: "password"}},
+		]
+	}
+	volumeMounts: [{name: "content", mountPath: "/usr/share/nginx/html"}]
+}]
+`
+
+// staticSiteTemplate defines the template function for static-site.
+func staticSiteTemplate(tpl *defkit.Template) {
+	vela := defkit.VelaCtx()
+	image := defkit.String("image")
+	replicas := defkit.Int("replicas")
+	files := defkit.Object("files")
+	ingress := defkit.Object("ingress")
+	pod := NewPodTemplate(tpl, "spec.template")
+
+	// Several resources share the selected source and the nginx configuration,
+	// so they are declared once as hidden template fields ahead of the outputs.
+	tpl.SetRawHeaderBlock(staticSiteContent)
+
+	deployment := defkit.NewResource("apps/v1", "Deployment").
+		Set("spec.replicas", replicas).
+		Set("spec.selector.matchLabels[app.oam.dev/component]", vela.Name()).
+		Set("spec.template.metadata.labels[app.oam.dev/name]", vela.AppName()).
+		Set("spec.template.metadata.labels[app.oam.dev/component]", vela.Name()).
+		Set("spec.template.metadata.annotations[static-site.oam.dev/content-hash]", defkit.Reference("_contentHash")).
+		Set(pod.Container("name"), vela.Name()).
+		Set(pod.Container("image"), image).
+		Set(pod.Container("ports"), defkit.Reference(`[{name: "http", containerPort: 8080}]`)).
+		Set(pod.Container("readinessProbe.tcpSocket.port"), defkit.Lit(8080)).
+		Set(pod.Container("volumeMounts"), defkit.Reference("_volumeMounts")).
+		Set(pod.PodSpec("initContainers"), defkit.Reference("_initContainers")).
+		Set(pod.PodSpec("volumes"), defkit.Reference("_volumes"))
+	pod.ApplyResources(deployment)
+
+	tpl.Output(deployment)
+
+	nginxConfig := defkit.NewResource("v1", "ConfigMap").
+		Set("metadata.name", defkit.Interpolation(vela.Name(), defkit.Lit("-nginx"))).
+		Set("data[default.conf]", defkit.Reference("_nginxConf"))
+
+	tpl.Outputs("nginxConfig", nginxConfig)
+
+	content := defkit.NewResource("v1", "ConfigMap").
+		Set("metadata.name", defkit.Interpolation(vela.Name(), defkit.Lit("-content"))).
+		Set("data", files)
+
+	tpl.OutputsIf(files.IsSet(), "content", content)
+
+	service := defkit.NewResource("v1", "Service").
+		Set("metadata.name", vela.Name()).
+		Set("spec.selector[app.oam.dev/component]", vela.Name()).
+		Set("spec.ports", defkit.Reference(`[{name: "http", port: 80, targetPort: 8080}]`))
+
+	tpl.Outputs("service", service)
+
+	ingressRule := defkit.Reference(`[{host: parameter.ingress.host, http: paths: [{path: "/", pathType: "Prefix", backend: service: {name: context.name, port: number: 80}}]}]`)
+	ingressResource := defkit.NewResource("networking.k8s.io/v1", "Ingress").
+		Set("metadata.name", vela.Name()).
+		SetIf(defkit.PathExists("parameter.ingress.className"), "spec.ingressClassName", defkit.Reference("parameter.ingress.className")).
+		Set("spec.rules", ingressRule).
+		SetIf(defkit.PathExists("parameter.ingress.tlsSecretName"), "spec.tls",
+			defkit.Reference(`[{hosts: [parameter.ingress.host], secretName: parameter.ingress.tlsSecretName}]`))
+
+	tpl.OutputsIf(ingress.IsSet(), "ingress", ingressResource)
+}
+
+func init() {
+	defkit.Register(StaticSite())
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"cuelang.org/go/cue"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("StaticSite Component", func() {
+	Describe("StaticSite()", func() {
+		It("should create a static-site component definition", func() {
+			comp := components.StaticSite()
+			Expect(comp.GetName()).To(Equal("static-site"))
+			Expect(comp.GetDescription()).To(ContainSubstring("nginx"))
+		})
+
+		It("should have apps/v1 Deployment workload", func() {
+			workload := components.StaticSite().GetWorkload()
+			Expect(workload.APIVersion()).To(Equal("apps/v1"))
+			Expect(workload.Kind()).To(Equal("Deployment"))
+		})
+
+		It("should have content, nginx and ingress parameters", func() {
+			comp := components.StaticSite()
+			for _, name := range []string{"image", "replicas", "files", "configMap", "secret", "git", "spa", "gzip", "cacheMaxAge", "ingress"} {
+				Expect(comp).To(HaveParamNamed(name))
+			}
+		})
+
+		It("should output the Deployment, its ConfigMaps, Service and Ingress", func() {
+			tpl := defkit.NewTemplate()
+			components.StaticSite().GetTemplate()(tpl)
+			Expect(tpl.GetOutput()).To(BeResourceOfKind("Deployment"))
+			outputs := tpl.GetOutputs()
+			Expect(outputs["nginxConfig"]).To(BeResourceOfKind("ConfigMap"))
+			Expect(outputs["content"]).To(BeResourceOfKind("ConfigMap"))
+			Expect(outputs["service"]).To(BeResourceOfKind("Service"))
+			Expect(outputs["ingress"]).To(BeResourceOfKind("Ingress"))
+		})
+	})
+
+	Describe("StaticSite rendering", func() {
+		ctx := `{name: "site", appName: "web", namespace: "default"}`
+
+		contentHash := func(v cue.Value) string {
+			return lookup(v, `output.spec.template.metadata.annotations."static-site.oam.dev/content-hash"`)
+		}
+
+		It("should serve the inline files from a generated ConfigMap", func() {
+			v := render(components.StaticSite(), ctx, `{files: {"index.html": "<h1>hi</h1>"}}`)
+			Expect(lookup(v, `outputs.content.data."index.html"`)).To(Equal("<h1>hi</h1>"))
+			Expect(lookup(v, "output.spec.template.spec.volumes[1].configMap.name")).To(Equal("site-content"))
+			Expect(lookup(v, "output.spec.template.spec.containers[0].volumeMounts[1].mountPath")).To(Equal("/usr/share/nginx/html"))
+			Expect(v.LookupPath(cue.ParsePath("outputs.ingress")).Exists()).To(BeFalse())
+		})
+
+		It("should render the SPA fallback, gzip and cache headers into the nginx configuration", func() {
+			conf := lookup(render(components.StaticSite(), ctx, `{configMap: "assets", cacheMaxAge: 600}`), `outputs.nginxConfig.data."default.conf"`)
+			Expect(conf).To(ContainSubstring("listen 8080;"))
+			Expect(conf).To(ContainSubstring("root /usr/share/nginx/html;"))
+			Expect(conf).To(ContainSubstring("gzip on;"))
+			Expect(conf).To(ContainSubstring("try_files $uri $uri/ /index.html;"))
+			Expect(conf).To(ContainSubstring(`add_header Cache-Control "public, max-age=600";`))
+
+			conf = lookup(render(components.StaticSite(), ctx, `{configMap: "assets", spa: false, gzip: false}`), `outputs.nginxConfig.data."default.conf"`)
+			Expect(conf).NotTo(ContainSubstring("gzip"))
+			Expect(conf).To(ContainSubstring("try_files $uri $uri/ =404;"))
+		})
+
+		It("should clone a git repository with git-sync and serve its path", func() {
+			v := render(components.StaticSite(), ctx, `{git: {repo: "https://github.com/acme/site", ref: "main", path: "dist", credentialsSecret: "git-creds"}}`)
+			Expect(lookup(v, "output.spec.template.spec.initContainers[0].image")).To(Equal("registry.k8s.io/git-sync/git-sync:v4.4.0"))
+			Expect(lookup(v, "output.spec.template.spec.initContainers[0].args[0]")).To(Equal("--repo=https://github.com/acme/site"))
+			Expect(lookup(v, "output.spec.template.spec.initContainers[0].args[1]")).To(Equal("--ref=main"))
+			Expect(lookup(v, "output.spec.template.spec.initContainers[0].env[1].valueFrom.secretKeyRef.name")).To(Equal("git-creds"))
+			Expect(v.LookupPath(cue.ParsePath("output.spec.template.spec.volumes[1].emptyDir")).Exists()).To(BeTrue())
+			Expect(lookup(v, `outputs.nginxConfig.data."default.conf"`)).To(ContainSubstring("root /usr/share/nginx/html/current/dist;"))
+			Expect(v.LookupPath(cue.ParsePath("outputs.content")).Exists()).To(BeFalse())
+		})
+
+		It("should change the content hash when the content changes", func() {
+			hash := contentHash(render(components.StaticSite(), ctx, `{files: {"index.html": "v1"}}`))
+			Expect(hash).To(HaveLen(64))
+			Expect(contentHash(render(components.StaticSite(), ctx, `{files: {"index.html": "v1"}}`))).To(Equal(hash))
+			Expect(contentHash(render(components.StaticSite(), ctx, `{files: {"index.html": "v2"}}`))).NotTo(Equal(hash))
+			Expect(contentHash(render(components.StaticSite(), ctx, `{files: {"index.html": "v1"}, spa: false}`))).NotTo(Equal(hash))
+		})
+
+		It("should require exactly one source of the files", func() {
+			err := renderTemplate(components.StaticSite(), ctx, `{}`).Validate(cue.Concrete(true))
+			Expect(err).To(MatchError(ContainSubstring("one of files, configMap, secret or git is required")))
+
+			err = renderTemplate(components.StaticSite(), ctx, `{files: {"index.html": "<h1>hi</h1>"}, configMap: "assets"}`).Validate(cue.Concrete(true))
+			Expect(err).To(MatchError(ContainSubstring("only one of files, configMap, secret or git can be set, got files, configMap")))
+		})
+
+		It("should expose the site on the ingress host over TLS", func() {
+			v := render(components.StaticSite(), ctx, `{secret: "site", ingress: {host: "www.example.com", className: "nginx", tlsSecretName: "www-tls"}}`)
+			Expect(lookup(v, "outputs.ingress.spec.ingressClassName")).To(Equal("nginx"))
+			Expect(lookup(v, "outputs.ingress.spec.rules[0].host")).To(Equal("www.example.com"))
+			Expect(lookup(v, "outputs.ingress.spec.rules[0].http.paths[0].backend.service.name")).To(Equal("site"))
+			Expect(lookup(v, "outputs.ingress.spec.tls[0].secretName")).To(Equal("www-tls"))
+			Expect(lookup(v, "output.spec.template.spec.volumes[1].secret.secretName")).To(Equal("site"))
+		})
+	})
+})
@@ -20,87 +20,20 @@ import (
 	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 )
 
+// taskStatusMessage reports the pod counts of the Job and, for an Indexed
+// task, the completed and failed indexes.
+const taskStatusMessage = `_indexes: *"" | string
+if context.output.spec.completionMode == "Indexed" {
+	_indexes: " Completed indexes:\(status.completedIndexes) Failed indexes:\(status.failedIndexes)"
+}
+message: "Active/Failed/Succeeded:\(status.active)/\(status.failed)/\(status.succeeded)\(_indexes)"`
+
 // Task creates a task component definition.
 // It describes a one-time task that runs to completion.
 func Task() *defkit.ComponentDefinition {
 	labels := defkit.StringKeyMap("labels").Optional().Description("Specify the labels in the workload")
 	annotations := defkit.StringKeyMap("annotations").Optional().Description("Specify the annotations in the workload")
-	count := defkit.Int("count").Default(1).Description("Specify number of tasks to run in parallel").Short("c")
-	image := defkit.String("image").Description("Which image would you like to use for your service").Short("i")
-	imagePullPolicy := defkit.String("imagePullPolicy").
-		Optional().
-		Values("Always", "Never", "IfNotPresent").
-		Description("Specify image pull policy for your service")
-	imagePullSecrets := defkit.StringList("imagePullSecrets").Optional().Description("Specify image pull secrets for your service")
-	restart := defkit.String("restart").Default("Never").
-		Description("Define the job restart policy, the value can only be Never or OnFailure. By default, it's Never.")
-	cmd := defkit.StringList("cmd").Optional().Description("Commands to run in the container")
-	env := defkit.List("env").Optional().Description("Define arguments by using environment variables").
-		WithFields(
-			defkit.String("name").Description("Environment variable name"),
-			defkit.String("value").Optional().Description("The value of the environment variable"),
-			defkit.Object("valueFrom").Optional().Description("Specifies a source the value of this var should come from").
-				WithFields(
-					defkit.Object("secretKeyRef").Optional().Description("Selects a key of a secret in the pod's namespace").
-						WithFields(
-							defkit.String("name").Description("The name of the secret in the pod's namespace to select from"),
-							defkit.String("key").Description("The key of the secret to select from. Must be a valid secret key"),
-						),
-					defkit.Object("configMapKeyRef").Optional().Description("Selects a key of a config map in the pod's namespace").
-						WithFields(
-							defkit.String("name").Description("The name of the config map in the pod's namespace to select from"),
-							defkit.String("key").Description("The key of the config map to select from. Must be a valid secret key"),
-						),
-				),
-		)
-	cpu := defkit.String("cpu").Optional().Description("Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)")
-	memory := defkit.String("memory").Optional().Description("Specifies the attributes of the memory resource required for the container.")
-	volumes := defkit.List("volumes").Optional().Description("Declare volumes and volumeMounts").
-		WithFields(
-			defkit.String("name"),
-			defkit.String("mountPath"),
-			defkit.OneOf("type").
-				Description("Specify volume type, options: \"pvc\",\"configMap\",\"secret\",\"emptyDir\", default to emptyDir").
-				Default("emptyDir").
-				Variants(
-					defkit.Variant("pvc").WithFields(
-						defkit.Field("claimName", defkit.ParamTypeString),
-					),
-					defkit.Variant("configMap").WithFields(
-						defkit.Field("defaultMode", defkit.ParamTypeInt).Default(420),
-						defkit.Field("cmName", defkit.ParamTypeString),
-						defkit.Field("items", defkit.ParamTypeArray).Optional().Nested(
-							defkit.Struct("").WithFields(
-								defkit.Field("key", defkit.ParamTypeString),
-								defkit.Field("path", defkit.ParamTypeString),
-								defkit.Field("mode", defkit.ParamTypeInt).Default(511),
-							),
-						),
-					),
-					defkit.Variant("secret").WithFields(
-						defkit.Field("defaultMode", defkit.ParamTypeInt).Default(420),
-						defkit.Field("secretName", defkit.ParamTypeString),
-						defkit.Field("items", defkit.ParamTypeArray).Optional().Nested(
-							defkit.Struct("").WithFields(
-								defkit.Field("key", defkit.ParamTypeString),
-								defkit.Field("path", defkit.ParamTypeString),
-								defkit.Field("mode", defkit.ParamTypeInt).Default(511),
-							),
-						),
-					),
-					defkit.Variant("emptyDir").WithFields(
-						defkit.Field("medium", defkit.ParamTypeString).Default("").Values("", "Memory"),
-					),
-				),
-		)
-	livenessProbe := defkit.Object("livenessProbe").
-		Optional().
-		WithSchemaRef("HealthProbe").
-		Description("Instructions for assessing whether the container is alive.")
-	readinessProbe := defkit.Object("readinessProbe").
-		Optional().
-		WithSchemaRef("HealthProbe").
-		Description("Instructions for assessing whether the container is in a suitable state to serve traffic.")
+	suspend := defkit.Bool("suspend").Optional().Description("Specify whether the task is suspended, no pods are created while suspended")
 
 	return defkit.NewComponent("task").
 		Description("Describes jobs that run code or a script to completion.").
@@ -109,17 +42,22 @@ func Task() *defkit.ComponentDefinition {
 			IntField("status.active", "status.active", 0).
 			IntField("status.failed", "status.failed", 0).
 			IntField("status.succeeded", "status.succeeded", 0).
-			Message("Active/Failed/Succeeded:\\(status.active)/\\(status.failed)/\\(status.succeeded)").
+			StringField("status.completedIndexes", "status.completedIndexes", "").
+			StringField("status.failedIndexes", "status.failedIndexes", "").
+			Build()+"\n"+taskStatusMessage).
+		HealthPolicy(defkit.Health().
+			IntField("succeeded", "status.succeeded", 0).
+			HealthyWhen("succeeded == context.output.spec.completions").
 			Build()).
-		HealthPolicy(defkit.JobHealth().Build()).
-		Helper("HealthProbe", CronTaskHealthProbeParam()).
-		Params(
-			labels, annotations,
-			count, image, imagePullPolicy, imagePullSecrets,
-			restart, cmd, env,
-			cpu, memory, volumes,
-			livenessProbe, readinessProbe,
-		).
+		Helper("HealthProbe", HealthProbeParam()).
+		Params(labels, annotations).
+		Params(JobParams()...).
+		Params(suspend).
+		Params(PodContainerParams()...).
+		Params(PodResourceParams()...).
+		Params(PodVolumeParams()...).
+		Params(PodProbeParams()...).
+		Params(PodSchedulingParams()...).
 		Template(taskTemplate)
 }
 
@@ -130,75 +68,17 @@ func taskTemplate(tpl *defkit.Template) {
 	// Parameter references for template
 	labels := defkit.StringKeyMap("labels")
 	annotations := defkit.StringKeyMap("annotations")
-	count := defkit.Int("count")
-	image := defkit.String("image")
-	imagePullPolicy := defkit.String("imagePullPolicy")
-	imagePullSecrets := defkit.StringList("imagePullSecrets")
-	restart := defkit.String("restart")
-	cmd := defkit.StringList("cmd")
-	env := defkit.List("env")
-	cpu := defkit.String("cpu")
-	memory := defkit.String("memory")
-	volumes := defkit.List("volumes")
+	suspend := defkit.Bool("suspend")
 
 	job := defkit.NewResource("batch/v1", "Job").
 		Set("metadata.name", defkit.Interpolation(vela.AppName(), defkit.Lit("-"), vela.Name())).
-		Set("spec.parallelism", count).
-		Set("spec.completions", count).
+		SetIf(suspend.IsSet(), "spec.suspend", suspend).
 		SpreadIf(labels.IsSet(), "spec.template.metadata.labels", labels).
 		Set("spec.template.metadata.labels[app.oam.dev/name]", vela.AppName()).
 		Set("spec.template.metadata.labels[app.oam.dev/component]", vela.Name()).
-		SetIf(annotations.IsSet(), "spec.template.metadata.annotations", annotations).
-		Set("spec.template.spec.restartPolicy", restart).
-		Set("spec.template.spec.containers[0].name", vela.Name()).
-		Set("spec.template.spec.containers[0].image", image).
-		SetIf(imagePullPolicy.IsSet(), "spec.template.spec.containers[0].imagePullPolicy", imagePullPolicy).
-		SetIf(cmd.IsSet(), "spec.template.spec.containers[0].command", cmd).
-		SetIf(env.IsSet(), "spec.template.spec.containers[0].env", env).
-		If(cpu.IsSet()).
-		Set("spec.template.spec.containers[0].resources.limits.cpu", cpu).
-		Set("spec.template.spec.containers[0].resources.requests.cpu", cpu).
-		EndIf().
-		If(memory.IsSet()).
-		Set("spec.template.spec.containers[0].resources.limits.memory", memory).
-		Set("spec.template.spec.containers[0].resources.requests.memory", memory).
-		EndIf().
-		SetIf(volumes.IsSet(), "spec.template.spec.containers[0].volumeMounts",
-			defkit.Each(volumes).Map(defkit.FieldMap{
-				"mountPath": defkit.FieldRef("mountPath"),
-				"name":      defkit.FieldRef("name"),
-			})).
-		SetIf(volumes.IsSet(), "spec.template.spec.volumes",
-			defkit.Each(volumes).
-				Map(defkit.FieldMap{
-					"name": defkit.FieldRef("name"),
-				}).
-				MapVariant("type", "pvc", defkit.FieldMap{
-					"persistentVolumeClaim": defkit.NestedFieldMap(defkit.FieldMap{
-						"claimName": defkit.FieldRef("claimName"),
-					}),
-				}).
-				MapVariant("type", "configMap", defkit.FieldMap{
-					"configMap": defkit.NestedFieldMap(defkit.FieldMap{
-						"defaultMode": defkit.FieldRef("defaultMode"),
-						"name":        defkit.FieldRef("cmName"),
-						"items":       defkit.OptionalFieldRef("items"),
-					}),
-				}).
-				MapVariant("type", "secret", defkit.FieldMap{
-					"secret": defkit.NestedFieldMap(defkit.FieldMap{
-						"defaultMode": defkit.FieldRef("defaultMode"),
-						"secretName":  defkit.FieldRef("secretName"),
-						"items":       defkit.OptionalFieldRef("items"),
-					}),
-				}).
-				MapVariant("type", "emptyDir", defkit.FieldMap{
-					"emptyDir": defkit.NestedFieldMap(defkit.FieldMap{
-						"medium": defkit.FieldRef("medium"),
-					}),
-				})).
-		SetIf(imagePullSecrets.IsSet(), "spec.template.spec.imagePullSecrets",
-			ImagePullSecretsTransform(imagePullSecrets))
+		SetIf(annotations.IsSet(), "spec.template.metadata.annotations", annotations)
+	NewJobTemplate("spec").Apply(job)
+	NewPodTemplate(tpl, "spec.template").Apply(job)
 
 	tpl.Output(job)
 }
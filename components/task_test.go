@@ -66,10 +66,24 @@ var _ = Describe("Task Component", func() {
 			Expect(comp).To(HaveParamNamed("readinessProbe"))
 		})
 
-		It("should NOT have removed parameters", func() {
+		It("should have the shared pod template parameters", func() {
 			comp := components.Task()
-			Expect(comp).NotTo(HaveParamNamed("args"))
-			Expect(comp).NotTo(HaveParamNamed("volumeMounts"))
+			Expect(comp).To(HaveParamNamed("args"))
+			Expect(comp).To(HaveParamNamed("limit"))
+			Expect(comp).To(HaveParamNamed("volumeMounts"))
+			Expect(comp).To(HaveParamNamed("hostAliases"))
+		})
+
+		It("should have indexed and parallel Job parameters", func() {
+			comp := components.Task()
+			Expect(comp).To(HaveParamNamed("completions"))
+			Expect(comp).To(HaveParamNamed("completionMode"))
+			Expect(comp).To(HaveParamNamed("backoffLimitPerIndex"))
+			Expect(comp).To(HaveParamNamed("maxFailedIndexes"))
+			Expect(comp).To(HaveParamNamed("podFailurePolicy"))
+			Expect(comp).To(HaveParamNamed("activeDeadlineSeconds"))
+			Expect(comp).To(HaveParamNamed("ttlSecondsAfterFinished"))
+			Expect(comp).To(HaveParamNamed("suspend"))
 		})
 
 		It("should have HealthProbe helper", func() {
@@ -176,18 +190,18 @@ var _ = Describe("Task Component", func() {
 			Expect(cueOutput).To(ContainSubstring("#HealthProbe:"))
 		})
 
-		It("should NOT generate args parameter", func() {
+		It("should generate args parameter", func() {
 			paramIdx := strings.Index(cueOutput, "\tparameter: {")
 			Expect(paramIdx).To(BeNumerically(">", 0))
 			paramSection := cueOutput[paramIdx:]
-			Expect(paramSection).NotTo(ContainSubstring("args?:"))
+			Expect(paramSection).To(ContainSubstring("args?: [...string]"))
 		})
 
-		It("should NOT generate volumeMounts parameter", func() {
+		It("should generate volumeMounts parameter", func() {
 			paramIdx := strings.Index(cueOutput, "\tparameter: {")
 			Expect(paramIdx).To(BeNumerically(">", 0))
 			paramSection := cueOutput[paramIdx:]
-			Expect(paramSection).NotTo(ContainSubstring("volumeMounts?:"))
+			Expect(paramSection).To(ContainSubstring("volumeMounts?:"))
 		})
 
 		It("should generate conditional resources block for cpu and memory", func() {
@@ -208,21 +222,22 @@ var _ = Describe("Task Component", func() {
 			Expect(cueOutput).To(ContainSubstring(`if v.type == "emptyDir"`))
 		})
 
-		It("should NOT generate probe passthrough in template", func() {
-			// The template should NOT have livenessProbe or readinessProbe SetIf
+		It("should generate probe passthrough in template", func() {
 			outputIdx := strings.Index(cueOutput, "output: {")
 			Expect(outputIdx).To(BeNumerically(">", 0))
 			paramIdx := strings.Index(cueOutput, "\tparameter: {")
 			Expect(paramIdx).To(BeNumerically(">", 0))
 			templateSection := cueOutput[outputIdx:paramIdx]
-			Expect(templateSection).NotTo(ContainSubstring("livenessProbe:"))
-			Expect(templateSection).NotTo(ContainSubstring("readinessProbe:"))
+			Expect(templateSection).To(ContainSubstring("livenessProbe: parameter.livenessProbe"))
+			Expect(templateSection).To(ContainSubstring("readinessProbe: parameter.readinessProbe"))
 		})
 
-		It("should NOT generate helper arrays", func() {
+		It("should generate the shared volume helper arrays", func() {
+			Expect(cueOutput).To(ContainSubstring("mountsArray:"))
+			Expect(cueOutput).To(ContainSubstring("volumesList:"))
+			Expect(cueOutput).To(ContainSubstring("deDupVolumesArray:"))
 			Expect(cueOutput).NotTo(ContainSubstring("containerMountsArray"))
 			Expect(cueOutput).NotTo(ContainSubstring("deDupVolumesList"))
-			Expect(cueOutput).NotTo(ContainSubstring("volumesList"))
 		})
 
 		It("should generate customStatus with active/failed/succeeded", func() {
@@ -233,12 +248,47 @@ var _ = Describe("Task Component", func() {
 			Expect(cueOutput).To(ContainSubstring("Active/Failed/Succeeded:"))
 		})
 
+		It("should generate customStatus with completed and failed indexes", func() {
+			Expect(cueOutput).To(ContainSubstring("completedIndexes: context.output.status.completedIndexes"))
+			Expect(cueOutput).To(ContainSubstring("failedIndexes: context.output.status.failedIndexes"))
+			Expect(cueOutput).To(ContainSubstring(`if context.output.spec.completionMode == "Indexed" {`))
+			Expect(cueOutput).To(ContainSubstring(`\(status.succeeded)\(_indexes)"`))
+		})
+
 		It("should generate healthPolicy with job health pattern", func() {
 			Expect(cueOutput).To(ContainSubstring("healthPolicy:"))
 			Expect(cueOutput).To(ContainSubstring("succeeded:"))
 			Expect(cueOutput).To(ContainSubstring("isHealth:"))
 		})
 
+		It("should generate healthPolicy waiting for all completions", func() {
+			Expect(cueOutput).To(ContainSubstring("isHealth: succeeded == context.output.spec.completions"))
+		})
+
+		It("should generate completions defaulting to count", func() {
+			Expect(cueOutput).To(ContainSubstring("parallelism: parameter.count"))
+			Expect(cueOutput).To(ContainSubstring("completions: parameter.completions"))
+			Expect(cueOutput).To(ContainSubstring("completions: parameter.count"))
+			Expect(cueOutput).To(ContainSubstring(`completionMode: *"NonIndexed" | "Indexed"`))
+			Expect(cueOutput).To(ContainSubstring("completionMode: parameter.completionMode"))
+		})
+
+		It("should generate indexed failure and lifecycle settings", func() {
+			Expect(cueOutput).To(ContainSubstring("backoffLimitPerIndex: parameter.backoffLimitPerIndex"))
+			Expect(cueOutput).To(ContainSubstring("maxFailedIndexes: parameter.maxFailedIndexes"))
+			Expect(cueOutput).To(ContainSubstring("podFailurePolicy: parameter.podFailurePolicy"))
+			Expect(cueOutput).To(ContainSubstring("activeDeadlineSeconds: parameter.activeDeadlineSeconds"))
+			Expect(cueOutput).To(ContainSubstring("ttlSecondsAfterFinished: parameter.ttlSecondsAfterFinished"))
+			Expect(cueOutput).To(ContainSubstring("suspend: parameter.suspend"))
+		})
+
+		It("should generate podFailurePolicy rules schema", func() {
+			Expect(cueOutput).To(ContainSubstring(`action: "FailJob" | "FailIndex" | "Ignore" | "Count"`))
+			Expect(cueOutput).To(ContainSubstring(`operator: "In" | "NotIn"`))
+			Expect(cueOutput).To(ContainSubstring("values: [...int]"))
+			Expect(cueOutput).To(ContainSubstring(`status: *"True" | string`))
+		})
+
 		It("should generate imagePullSecrets transformation", func() {
 			Expect(cueOutput).To(ContainSubstring("for v in parameter.imagePullSecrets"))
 			Expect(cueOutput).To(ContainSubstring("name: v"))
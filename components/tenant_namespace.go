@@ -0,0 +1,231 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// tenantNamespaceRoles are the groups parameters of tenant-namespace, bound
+// to the ClusterRole of the same order in the namespace.
+var tenantNamespaceRoles = []struct {
+	param       string
+	clusterRole string
+}{
+	{"admins", "admin"},
+	{"editors", "edit"},
+	{"viewers", "view"},
+}
+
+// tenantNamespaceGuardrails reads the live guardrails of the namespace: the
+// quota once the quota controller enforces it, and the RoleBinding of each
+// groups parameter that is set.
+const tenantNamespaceGuardrails = `_phase: *"Pending" | string
+if context.output.status != _|_ if context.output.status.phase != _|_ {
+	_phase: context.output.status.phase
+}
+_quota: [
+	if context.outputs.quota == _|_ {"missing"},
+	if context.outputs.quota.status.hard != _|_ {"enforced"},
+	"pending",
+][0]
+_bindings: [for k in ["admins", "editors", "viewers"] if parameter[k] != _|_ {context.outputs["\(k)Binding"] != _|_}]
+_boundGroups: len([for b in _bindings if b {b}])
+`
+
+// TenantNamespace creates the tenant-namespace component definition.
+// It describes a namespace named after the component, with the guardrails of
+// a tenant: Pod Security Admission labels, a ResourceQuota, a LimitRange
+// giving the containers the requests the quota requires, NetworkPolicies
+// denying the traffic not allowed, and RoleBindings of the groups of the
+// tenant to the admin, edit and view ClusterRoles.
+//
+// The component is healthy once the namespace is active, the quota enforced
+// and the RoleBindings created.
+func TenantNamespace() *defkit.ComponentDefinition {
+	labels := defkit.StringKeyMap("labels").Optional().Description("Labels of the namespace")
+	annotations := defkit.StringKeyMap("annotations").Optional().Description("Annotations of the namespace")
+	podSecurity := defkit.Object("podSecurity").
+		Description("Pod Security Standards applied to the pods of the namespace").
+		WithFields(
+			defkit.Enum("enforce").Values("privileged", "baseline", "restricted").Default("baseline").Description("Reject the pods violating this level"),
+			defkit.Enum("audit").Values("privileged", "baseline", "restricted").Default("restricted").Description("Record the pods violating this level in the audit log"),
+			defkit.Enum("warn").Values("privileged", "baseline", "restricted").Default("restricted").Description("Warn the users creating pods violating this level"),
+			defkit.String("version").Default("latest").Description("Kubernetes version of the standards, e.g. v1.30"),
+		)
+	quota := defkit.Object("quota").
+		Description("ResourceQuota of the namespace").
+		WithFields(
+			defkit.String("cpu").Default("4").Description("Total CPU requests"),
+			defkit.String("memory").Default("8Gi").Description("Total memory requests"),
+			defkit.String("limitsCpu").Optional().Description("Total CPU limits"),
+			defkit.String("limitsMemory").Optional().Description("Total memory limits"),
+			defkit.String("storage").Optional().Description("Total storage requests of the PersistentVolumeClaims"),
+			defkit.Int("pods").Default(50).Description("Maximum number of pods"),
+			defkit.Int("services").Optional().Description("Maximum number of Services"),
+			defkit.Int("persistentVolumeClaims").Optional().Description("Maximum number of PersistentVolumeClaims"),
+			defkit.StringKeyMap("hard").Optional().Description("Other quotas by resource name, e.g. `services.loadbalancers`, taking precedence over the fields above for the same resource"),
+		)
+	limitRange := defkit.Object("limitRange").
+		Description("Resources of the containers of the namespace").
+		WithFields(
+			defkit.Object("defaultRequest").Description("Requests of the containers without requests").WithFields(
+				defkit.String("cpu").Default("100m").Description("CPU request"),
+				defkit.String("memory").Default("128Mi").Description("Memory request"),
+			),
+			defkit.Object("defaultLimit").Description("Limits of the containers without limits").WithFields(
+				defkit.String("cpu").Default("500m").Description("CPU limit"),
+				defkit.String("memory").Default("512Mi").Description("Memory limit"),
+			),
+			defkit.Object("max").Optional().Description("Maximum limits of a container, e.g. `memory: \"4Gi\"`"),
+		)
+	networkPolicy := defkit.Object("networkPolicy").
+		Description("NetworkPolicies of the namespace, denying the traffic of its pods not allowed").
+		WithFields(
+			defkit.Bool("defaultDeny").Default(true).Description("Deny the ingress and egress traffic of the pods"),
+			defkit.Bool("allowSameNamespace").Default(true).Description("Allow the traffic between the pods of the namespace"),
+			defkit.Bool("allowDNS").Default(true).Description("Allow the DNS queries to kube-dns"),
+			defkit.StringList("allowFromNamespaces").Optional().Description("Namespaces allowed to reach the pods, e.g. ingress-nginx"),
+			defkit.StringList("allowEgressCIDRs").Optional().Description("CIDRs the pods are allowed to reach, e.g. of an external database"),
+		)
+	admins := defkit.StringList("admins").Optional().Description("Groups administrating the namespace, bound to the admin ClusterRole")
+	editors := defkit.StringList("editors").Optional().Description("Groups deploying to the namespace, bound to the edit ClusterRole")
+	viewers := defkit.StringList("viewers").Optional().Description("Groups reading the namespace, bound to the view ClusterRole")
+
+	return defkit.NewComponent("tenant-namespace").
+		Description("Describes a tenant namespace with pod security, quota, limit range, network policy and RBAC guardrails.").
+		Workload("v1", "Namespace").
+		CustomStatus(tenantNamespaceGuardrails+`message: "Phase:\(_phase), quota:\(_quota), bindings:\(_boundGroups)/\(len(_bindings))"`).
+		HealthPolicy(tenantNamespaceGuardrails+`isHealth: _phase == "Active" && _quota == "enforced" && _boundGroups == len(_bindings)`).
+		Params(labels, annotations, podSecurity, quota, limitRange, networkPolicy, admins, editors, viewers).
+		Template(tenantNamespaceTemplate)
+}
+
+// tenantNamespaceTemplate defines the template function for tenant-namespace.
+func tenantNamespaceTemplate(tpl *defkit.Template) {
+	vela := defkit.VelaCtx()
+	labels := defkit.StringKeyMap("labels")
+	annotations := defkit.StringKeyMap("annotations")
+
+	namespace := defkit.NewResource("v1", "Namespace").
+		Set("metadata.name", vela.Name()).
+		SpreadIf(labels.IsSet(), "metadata.labels", labels)
+	for _, mode := range []string{"enforce", "audit", "warn"} {
+		namespace.
+			Set("metadata.labels[pod-security.kubernetes.io/"+mode+"]", defkit.Reference("parameter.podSecurity."+mode)).
+			Set("metadata.labels[pod-security.kubernetes.io/"+mode+"-version]", defkit.Reference("parameter.podSecurity.version"))
+	}
+	namespace.SetIf(annotations.IsSet(), "metadata.annotations", annotations)
+
+	tpl.Output(namespace)
+
+	quota := defkit.NewResource("v1", "ResourceQuota").
+		Set("metadata.name", defkit.Lit("tenant-quota")).
+		Set("metadata.namespace", vela.Name()).
+		Set("spec.hard", defkit.Reference(`{
+	if parameter.quota.hard != _|_ {parameter.quota.hard}
+	for k, v in {
+		"requests.cpu":    parameter.quota.cpu
+		"requests.memory": parameter.quota.memory
+		if parameter.quota.limitsCpu != _|_ {"limits.cpu": parameter.quota.limitsCpu}
+		if parameter.quota.limitsMemory != _|_ {"limits.memory": parameter.quota.limitsMemory}
+		if parameter.quota.storage != _|_ {"requests.storage": parameter.quota.storage}
+		pods: "\(parameter.quota.pods)"
+		if parameter.quota.services != _|_ {services: "\(parameter.quota.services)"}
+		if parameter.quota.persistentVolumeClaims != _|_ {persistentvolumeclaims: "\(parameter.quota.persistentVolumeClaims)"}
+	} if parameter.quota.hard[k] == _|_ {(k): v}
+}`))
+
+	tpl.Outputs("quota", quota)
+
+	limitRange := defkit.NewResource("v1", "LimitRange").
+		Set("metadata.name", defkit.Lit("tenant-limits")).
+		Set("metadata.namespace", vela.Name()).
+		Set("spec.limits", defkit.Reference(`[{
+	type:           "Container"
+	defaultRequest: parameter.limitRange.defaultRequest
+	default:        parameter.limitRange.defaultLimit
+	if parameter.limitRange.max != _|_ {max: parameter.limitRange.max}
+}]`))
+
+	tpl.Outputs("limitRange", limitRange)
+
+	defaultDeny := defkit.NewResource("networking.k8s.io/v1", "NetworkPolicy").
+		Set("metadata.name", defkit.Lit("default-deny")).
+		Set("metadata.namespace", vela.Name()).
+		Set("spec.podSelector", defkit.Reference("{}")).
+		Set("spec.policyTypes", defkit.Reference(`["Ingress", "Egress"]`))
+
+	tpl.OutputsIf(defkit.Eq(defkit.Reference("parameter.networkPolicy.defaultDeny"), defkit.Lit(true)), "defaultDenyPolicy", defaultDeny)
+
+	sameNamespace := defkit.NewResource("networking.k8s.io/v1", "NetworkPolicy").
+		Set("metadata.name", defkit.Lit("allow-same-namespace")).
+		Set("metadata.namespace", vela.Name()).
+		Set("spec.podSelector", defkit.Reference("{}")).
+		Set("spec.policyTypes", defkit.Reference(`["Ingress", "Egress"]`)).
+		Set("spec.ingress", defkit.Reference(`[{from: [{podSelector: {}}]}]`)).
+		Set("spec.egress", defkit.Reference(`[{to: [{podSelector: {}}]}]`))
+
+	tpl.OutputsIf(defkit.Eq(defkit.Reference("parameter.networkPolicy.allowSameNamespace"), defkit.Lit(true)), "sameNamespacePolicy", sameNamespace)
+
+	dns := defkit.NewResource("networking.k8s.io/v1", "NetworkPolicy").
+		Set("metadata.name", defkit.Lit("allow-dns")).
+		Set("metadata.namespace", vela.Name()).
+		Set("spec.podSelector", defkit.Reference("{}")).
+		Set("spec.policyTypes", defkit.Reference(`["Egress"]`)).
+		Set("spec.egress", defkit.Reference(`[{
+	to: [{namespaceSelector: matchLabels: "kubernetes.io/metadata.name": "kube-system", podSelector: matchLabels: "k8s-app": "kube-dns"}]
+	ports: [{protocol: "UDP", port: 53}, {protocol: "TCP", port: 53}]
+}]`))
+
+	tpl.OutputsIf(defkit.Eq(defkit.Reference("parameter.networkPolicy.allowDNS"), defkit.Lit(true)), "dnsPolicy", dns)
+
+	fromNamespaces := defkit.NewResource("networking.k8s.io/v1", "NetworkPolicy").
+		Set("metadata.name", defkit.Lit("allow-from-namespaces")).
+		Set("metadata.namespace", vela.Name()).
+		Set("spec.podSelector", defkit.Reference("{}")).
+		Set("spec.policyTypes", defkit.Reference(`["Ingress"]`)).
+		Set("spec.ingress", defkit.Reference(`[{from: [{namespaceSelector: matchExpressions: [{key: "kubernetes.io/metadata.name", operator: "In", values: parameter.networkPolicy.allowFromNamespaces}]}]}]`))
+
+	tpl.OutputsIf(defkit.PathExists("parameter.networkPolicy.allowFromNamespaces"), "fromNamespacesPolicy", fromNamespaces)
+
+	egressCIDRs := defkit.NewResource("networking.k8s.io/v1", "NetworkPolicy").
+		Set("metadata.name", defkit.Lit("allow-egress-cidrs")).
+		Set("metadata.namespace", vela.Name()).
+		Set("spec.podSelector", defkit.Reference("{}")).
+		Set("spec.policyTypes", defkit.Reference(`["Egress"]`)).
+		Set("spec.egress", defkit.Reference(`[{to: [for c in parameter.networkPolicy.allowEgressCIDRs {ipBlock: cidr: c}]}]`))
+
+	tpl.OutputsIf(defkit.PathExists("parameter.networkPolicy.allowEgressCIDRs"), "egressCIDRsPolicy", egressCIDRs)
+
+	for _, r := range tenantNamespaceRoles {
+		groups := defkit.StringList(r.param)
+		binding := defkit.NewResource("rbac.authorization.k8s.io/v1", "RoleBinding").
+			Set("metadata.name", defkit.Lit("tenant-"+r.param)).
+			Set("metadata.namespace", vela.Name()).
+			Set("roleRef", defkit.Reference(fmt.Sprintf(`{apiGroup: "rbac.authorization.k8s.io", kind: "ClusterRole", name: %q}`, r.clusterRole))).
+			Set("subjects", defkit.Reference(fmt.Sprintf(`[for g in parameter.%s {apiGroup: "rbac.authorization.k8s.io", kind: "Group", name: g}]`, r.param)))
+
+		tpl.OutputsIf(groups.IsSet(), r.param+"Binding", binding)
+	}
+}
+
+func init() {
+	defkit.Register(TenantNamespace())
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"cuelang.org/go/cue"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/components"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	. "github.com/oam-dev/kubevela/pkg/definition/defkit/testing/matchers"
+)
+
+var _ = Describe("TenantNamespace Component", func() {
+	Describe("TenantNamespace()", func() {
+		It("should create a tenant-namespace component definition", func() {
+			comp := components.TenantNamespace()
+			Expect(comp.GetName()).To(Equal("tenant-namespace"))
+			Expect(comp.GetDescription()).To(ContainSubstring("guardrails"))
+		})
+
+		It("should have v1 Namespace workload", func() {
+			workload := components.TenantNamespace().GetWorkload()
+			Expect(workload.APIVersion()).To(Equal("v1"))
+			Expect(workload.Kind()).To(Equal("Namespace"))
+		})
+
+		It("should have guardrail parameters", func() {
+			comp := components.TenantNamespace()
+			for _, name := range []string{"labels", "annotations", "podSecurity", "quota", "limitRange", "networkPolicy", "admins", "editors", "viewers"} {
+				Expect(comp).To(HaveParamNamed(name))
+			}
+		})
+
+		It("should output the quota, limit range, network policies and role bindings", func() {
+			tpl := defkit.NewTemplate()
+			components.TenantNamespace().GetTemplate()(tpl)
+			Expect(tpl.GetOutput()).To(BeResourceOfKind("Namespace"))
+			outputs := tpl.GetOutputs()
+			Expect(outputs["quota"]).To(BeResourceOfKind("ResourceQuota"))
+			Expect(outputs["limitRange"]).To(BeResourceOfKind("LimitRange"))
+			for _, name := range []string{"defaultDenyPolicy", "sameNamespacePolicy", "dnsPolicy", "fromNamespacesPolicy", "egressCIDRsPolicy"} {
+				Expect(outputs[name]).To(BeResourceOfKind("NetworkPolicy"))
+			}
+			for _, name := range []string{"adminsBinding", "editorsBinding", "viewersBinding"} {
+				Expect(outputs[name]).To(BeResourceOfKind("RoleBinding"))
+			}
+		})
+	})
+
+	Describe("TenantNamespace rendering", func() {
+		ctx := `{name: "team-a", namespace: "vela-system"}`
+
+		exists := func(v cue.Value, path string) bool {
+			return v.LookupPath(cue.ParsePath(path)).Exists()
+		}
+
+		It("should label the namespace with the Pod Security Standards", func() {
+			v := render(components.TenantNamespace(), ctx, `{labels: {team: "a"}, podSecurity: {enforce: "restricted", version: "v1.30"}}`)
+			Expect(lookup(v, "output.metadata.name")).To(Equal("team-a"))
+			Expect(lookup(v, "output.metadata.labels.team")).To(Equal("a"))
+			Expect(lookup(v, `output.metadata.labels."pod-security.kubernetes.io/enforce"`)).To(Equal("restricted"))
+			Expect(lookup(v, `output.metadata.labels."pod-security.kubernetes.io/enforce-version"`)).To(Equal("v1.30"))
+			Expect(lookup(v, `output.metadata.labels."pod-security.kubernetes.io/warn"`)).To(Equal("restricted"))
+		})
+
+		It("should limit the resources of the namespace", func() {
+			v := render(components.TenantNamespace(), ctx, `{quota: {cpu: "8", limitsMemory: "32Gi", services: 5, hard: {"services.loadbalancers": "0"}}, limitRange: max: memory: "4Gi"}`)
+			Expect(lookup(v, "outputs.quota.metadata.namespace")).To(Equal("team-a"))
+			Expect(lookup(v, `outputs.quota.spec.hard."requests.cpu"`)).To(Equal("8"))
+			Expect(lookup(v, `outputs.quota.spec.hard."requests.memory"`)).To(Equal("8Gi"))
+			Expect(lookup(v, `outputs.quota.spec.hard."limits.memory"`)).To(Equal("32Gi"))
+			Expect(lookup(v, "outputs.quota.spec.hard.pods")).To(Equal("50"))
+			Expect(lookup(v, "outputs.quota.spec.hard.services")).To(Equal("5"))
+			Expect(lookup(v, `outputs.quota.spec.hard."services.loadbalancers"`)).To(Equal("0"))
+			Expect(exists(v, `outputs.quota.spec.hard."limits.cpu"`)).To(BeFalse())
+
+			Expect(lookup(v, "outputs.limitRange.spec.limits[0].type")).To(Equal("Container"))
+			Expect(lookup(v, "outputs.limitRange.spec.limits[0].defaultRequest.cpu")).To(Equal("100m"))
+			Expect(lookup(v, "outputs.limitRange.spec.limits[0].default.memory")).To(Equal("512Mi"))
+			Expect(lookup(v, "outputs.limitRange.spec.limits[0].max.memory")).To(Equal("4Gi"))
+		})
+
+		It("should let the other quotas override the fields of the same resource", func() {
+			v := render(components.TenantNamespace(), ctx, `{quota: {cpu: "8", services: 5, hard: {"requests.cpu": "16", pods: "100", services: "10"}}}`)
+			Expect(lookup(v, `outputs.quota.spec.hard."requests.cpu"`)).To(Equal("16"))
+			Expect(lookup(v, "outputs.quota.spec.hard.pods")).To(Equal("100"))
+			Expect(lookup(v, "outputs.quota.spec.hard.services")).To(Equal("10"))
+			Expect(lookup(v, `outputs.quota.spec.hard."requests.memory"`)).To(Equal("8Gi"))
+		})
+
+		It("should deny the traffic not allowed", func() {
+			v := render(components.TenantNamespace(), ctx, `{}`)
+			Expect(lookup(v, "outputs.defaultDenyPolicy.metadata.namespace")).To(Equal("team-a"))
+			Expect(lookup(v, "outputs.defaultDenyPolicy.spec.policyTypes[1]")).To(Equal("Egress"))
+			Expect(exists(v, "outputs.defaultDenyPolicy.spec.ingress")).To(BeFalse())
+			Expect(exists(v, "outputs.sameNamespacePolicy.spec.ingress[0].from[0].podSelector")).To(BeTrue())
+			Expect(lookup(v, `outputs.dnsPolicy.spec.egress[0].to[0].podSelector.matchLabels."k8s-app"`)).To(Equal("kube-dns"))
+			Expect(exists(v, "outputs.fromNamespacesPolicy")).To(BeFalse())
+			Expect(exists(v, "outputs.egressCIDRsPolicy")).To(BeFalse())
+
+			v = render(components.TenantNamespace(), ctx, `{networkPolicy: {allowSameNamespace: false, allowFromNamespaces: ["ingress-nginx"], allowEgressCIDRs: ["10.20.0.0/16"]}}`)
+			Expect(exists(v, "outputs.sameNamespacePolicy")).To(BeFalse())
+			Expect(lookup(v, "outputs.fromNamespacesPolicy.spec.ingress[0].from[0].namespaceSelector.matchExpressions[0].values[0]")).To(Equal("ingress-nginx"))
+			Expect(lookup(v, "outputs.egressCIDRsPolicy.spec.egress[0].to[0].ipBlock.cidr")).To(Equal("10.20.0.0/16"))
+
+			v = render(components.TenantNamespace(), ctx, `{networkPolicy: {defaultDeny: false, allowDNS: false}}`)
+			Expect(exists(v, "outputs.defaultDenyPolicy")).To(BeFalse())
+			Expect(exists(v, "outputs.dnsPolicy")).To(BeFalse())
+		})
+
+		It("should bind the groups to the ClusterRoles of their access", func() {
+			v := render(components.TenantNamespace(), ctx, `{admins: ["team-a-leads"], editors: ["team-a", "team-a-ci"]}`)
+			Expect(lookup(v, "outputs.adminsBinding.metadata.namespace")).To(Equal("team-a"))
+			Expect(lookup(v, "outputs.adminsBinding.roleRef.name")).To(Equal("admin"))
+			Expect(lookup(v, "outputs.adminsBinding.subjects[0].kind")).To(Equal("Group"))
+			Expect(lookup(v, "outputs.adminsBinding.subjects[0].name")).To(Equal("team-a-leads"))
+			Expect(lookup(v, "outputs.editorsBinding.roleRef.name")).To(Equal("edit"))
+			Expect(lookup(v, "outputs.editorsBinding.subjects[1].name")).To(Equal("team-a-ci"))
+			Expect(exists(v, "outputs.viewersBinding")).To(BeFalse())
+		})
+
+		It("should be healthy once the quota is enforced and the bindings exist", func() {
+			parameter := `{admins: ["team-a-leads"], viewers: ["auditors"]}`
+			namespace := `output: status: phase: "Active"`
+			enforced := `quota: status: hard: pods: "50"`
+			bindings := `adminsBinding: {}, viewersBinding: {}`
+
+			live := "{" + namespace + ", outputs: {" + enforced + ", " + bindings + "}}"
+			Expect(evalStatus(components.TenantNamespace(), "customStatus", "message", "parameter: "+parameter+"\ncontext: "+live).String()).To(Equal("Phase:Active, quota:enforced, bindings:2/2"))
+			Expect(evalStatus(components.TenantNamespace(), "healthPolicy", "isHealth", "parameter: "+parameter+"\ncontext: "+live).Bool()).To(BeTrue())
+
+			live = "{" + namespace + ", outputs: {quota: {}, " + bindings + "}}"
+			Expect(evalStatus(components.TenantNamespace(), "customStatus", "message", "parameter: "+parameter+"\ncontext: "+live).String()).To(Equal("Phase:Active, quota:pending, bindings:2/2"))
+			Expect(evalStatus(components.TenantNamespace(), "healthPolicy", "isHealth", "parameter: "+parameter+"\ncontext: "+live).Bool()).To(BeFalse())
+
+			live = "{" + namespace + ", outputs: {" + enforced + ", adminsBinding: {}}}"
+			Expect(evalStatus(components.TenantNamespace(), "customStatus", "message", "parameter: "+parameter+"\ncontext: "+live).String()).To(Equal("Phase:Active, quota:enforced, bindings:1/2"))
+			Expect(evalStatus(components.TenantNamespace(), "healthPolicy", "isHealth", "parameter: "+parameter+"\ncontext: "+live).Bool()).To(BeFalse())
+
+			live = "{output: {}, outputs: {}}"
+			Expect(evalStatus(components.TenantNamespace(), "customStatus", "message", "parameter: "+`{}`+"\ncontext: "+live).String()).To(Equal("Phase:Pending, quota:missing, bindings:0/0"))
+			Expect(evalStatus(components.TenantNamespace(), "healthPolicy", "isHealth", "parameter: "+`{}`+"\ncontext: "+live).Bool()).To(BeFalse())
+		})
+	})
+})
@@ -28,18 +28,6 @@ func Webservice() *defkit.ComponentDefinition {
 	labels := defkit.StringKeyMap("labels").Optional().Description("Specify the labels in the workload")
 	annotations := defkit.StringKeyMap("annotations").Optional().Description("Specify the annotations in the workload")
 
-	image := defkit.String("image").Description("Which image would you like to use for your service").Short("i")
-
-	// Use Enum for imagePullPolicy to generate proper CUE enum type
-	imagePullPolicy := defkit.Enum("imagePullPolicy").
-		Optional().
-		Values("Always", "Never", "IfNotPresent").
-		Description("Specify image pull policy for your service")
-
-	imagePullSecrets := defkit.StringList("imagePullSecrets").
-		Optional().
-		Description("Specify image pull secrets for your service")
-
 	// Deprecated port parameter with Ignore and Short directives
 	port := defkit.Int("port").
 		Optional().
@@ -58,6 +46,7 @@ func Webservice() *defkit.ComponentDefinition {
 			defkit.Enum("protocol").Values("TCP", "UDP", "SCTP").Default("TCP").Description("Protocol for port. Must be UDP, TCP, or SCTP"),
 			defkit.Bool("expose").Default(false).Description("Specify if the port should be exposed"),
 			defkit.Int("nodePort").Optional().Description("exposed node port. Only Valid when exposeType is NodePort"),
+			defkit.String("appProtocol").Optional().Description("Application protocol of the port, e.g. http, https or kubernetes.io/h2c"),
 		)
 
 	exposeType := defkit.Enum("exposeType").
@@ -71,145 +60,18 @@ func Webservice() *defkit.ComponentDefinition {
 		Ignore().
 		Description("If addRevisionLabel is true, the revision label will be added to the underlying pods")
 
-	cmd := defkit.StringList("cmd").Optional().Description("Commands to run in the container")
-	args := defkit.StringList("args").Optional().Description("Arguments to the entrypoint")
-
-	// Structured env array with detailed valueFrom schema
-	env := defkit.List("env").
-		Optional().
-		Description("Define arguments by using environment variables").
-		WithFields(
-			defkit.String("name").Description("Environment variable name"),
-			defkit.String("value").Optional().Description("The value of the environment variable"),
-			defkit.Object("valueFrom").Optional().Description("Specifies a source the value of this var should come from").
-				WithFields(
-					defkit.Object("secretKeyRef").Optional().Description("Selects a key of a secret in the pod's namespace").
-						WithFields(
-							defkit.String("name").Description("The name of the secret in the pod's namespace to select from"),
-							defkit.String("key").Description("The key of the secret to select from. Must be a valid secret key"),
-						),
-					defkit.Object("configMapKeyRef").Optional().Description("Selects a key of a config map in the pod's namespace").
-						WithFields(
-							defkit.String("name").Description("The name of the config map in the pod's namespace to select from"),
-							defkit.String("key").Description("The key of the config map to select from. Must be a valid secret key"),
-						),
-				),
-		)
-
-	cpu := defkit.String("cpu").Optional().Description("Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)")
-	memory := defkit.String("memory").Optional().Description("Specifies the attributes of the memory resource required for the container.")
-
-	// Resource limits
-	limit := defkit.Object("limit").Optional().WithFields(
-		defkit.String("cpu").Optional(),
-		defkit.String("memory").Optional(),
-	)
-
-	// VolumeMounts with subPath support, no mountPropagation/readOnly on hostPath
-	volumeMounts := defkit.Object("volumeMounts").
+	strategy := defkit.Object("strategy").
 		Optional().
+		Description("Specify how the pods are replaced on updates").
 		WithFields(
-			defkit.List("pvc").Optional().Description("Mount PVC type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.String("subPath").Optional(),
-				defkit.String("claimName").Description("The name of the PVC"),
-			),
-			defkit.List("configMap").Optional().Description("Mount ConfigMap type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.String("subPath").Optional(),
-				defkit.Int("defaultMode").Default(420),
-				defkit.String("cmName"),
-				defkit.List("items").Optional().WithFields(
-					defkit.String("key"),
-					defkit.String("path"),
-					defkit.Int("mode").Default(511),
-				),
-			),
-			defkit.List("secret").Optional().Description("Mount Secret type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.String("subPath").Optional(),
-				defkit.Int("defaultMode").Default(420),
-				defkit.String("secretName"),
-				defkit.List("items").Optional().WithFields(
-					defkit.String("key"),
-					defkit.String("path"),
-					defkit.Int("mode").Default(511),
-				),
+			defkit.Enum("type").
+				Values("RollingUpdate", "Recreate").
+				Default("RollingUpdate").
+				Description(`"RollingUpdate" replaces the pods progressively, "Recreate" deletes them all before creating the new ones`),
+			defkit.Object("rollingUpdate").Optional().Description("Tune the rolling update, only valid with type RollingUpdate").WithFields(
+				defkit.Map("maxSurge").Optional().WithSchema("int | string").Description("Number or percentage of pods created above the replicas, e.g. `25%`"),
+				defkit.Map("maxUnavailable").Optional().WithSchema("int | string").Description("Number or percentage of pods unavailable during the update, e.g. `25%`"),
 			),
-			defkit.List("emptyDir").Optional().Description("Mount EmptyDir type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.String("subPath").Optional(),
-				defkit.Enum("medium").Values("", "Memory").Default(""),
-			),
-			defkit.List("hostPath").Optional().Description("Mount HostPath type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.String("subPath").Optional(),
-				defkit.String("path"),
-			),
-		)
-
-	// Deprecated volumes parameter - discriminated union with type-based conditional fields
-	volumes := defkit.List("volumes").Optional().Description("Deprecated field, use volumeMounts instead.").
-		WithFields(
-			defkit.String("name"),
-			defkit.String("mountPath"),
-			defkit.OneOf("type").
-				Description(`Specify volume type, options: "pvc","configMap","secret","emptyDir", default to emptyDir`).
-				Default("emptyDir").
-				Variants(
-					defkit.Variant("pvc").WithFields(
-						defkit.Field("claimName", defkit.ParamTypeString),
-					),
-					defkit.Variant("configMap").WithFields(
-						defkit.Field("defaultMode", defkit.ParamTypeInt).Default(420),
-						defkit.Field("cmName", defkit.ParamTypeString),
-						defkit.Field("items", defkit.ParamTypeArray).Optional().Nested(
-							defkit.Struct("").WithFields(
-								defkit.Field("key", defkit.ParamTypeString),
-								defkit.Field("path", defkit.ParamTypeString),
-								defkit.Field("mode", defkit.ParamTypeInt).Default(511),
-							),
-						),
-					),
-					defkit.Variant("secret").WithFields(
-						defkit.Field("defaultMode", defkit.ParamTypeInt).Default(420),
-						defkit.Field("secretName", defkit.ParamTypeString),
-						defkit.Field("items", defkit.ParamTypeArray).Optional().Nested(
-							defkit.Struct("").WithFields(
-								defkit.Field("key", defkit.ParamTypeString),
-								defkit.Field("path", defkit.ParamTypeString),
-								defkit.Field("mode", defkit.ParamTypeInt).Default(511),
-							),
-						),
-					),
-					defkit.Variant("emptyDir").WithFields(
-						defkit.Field("medium", defkit.ParamTypeString).Default("").Values("", "Memory"),
-					),
-				),
-		)
-
-	// Health probes referencing the helper definition
-	livenessProbe := defkit.Object("livenessProbe").
-		Optional().
-		Description("Instructions for assessing whether the container is alive.").
-		WithSchemaRef("HealthProbe")
-	readinessProbe := defkit.Object("readinessProbe").
-		Optional().
-		Description("Instructions for assessing whether the container is in a suitable state to serve traffic.").
-		WithSchemaRef("HealthProbe")
-
-	// Structured hostAliases with required hostnames
-	hostAliases := defkit.List("hostAliases").
-		Optional().
-		Description("Specify the hostAliases to add").
-		WithFields(
-			defkit.String("ip"),
-			defkit.StringList("hostnames"),
 		)
 
 	return defkit.NewComponent("webservice").
@@ -218,15 +80,20 @@ func Webservice() *defkit.ComponentDefinition {
 		WithImports("strings").
 		CustomStatus(defkit.DeploymentStatus().Build()).
 		HealthPolicy(defkit.DeploymentHealth().Build()).
+		Params(labels, annotations).
+		Params(PodContainerParams()...).
 		Params(
-			labels, annotations,
-			image, imagePullPolicy, imagePullSecrets,
 			port, // deprecated
-			ports, exposeType, addRevisionLabel,
-			cmd, args, env,
-			cpu, memory, limit, volumeMounts, volumes,
-			livenessProbe, readinessProbe, hostAliases,
+			ports, exposeType, ServiceOptionsParam(), addRevisionLabel,
 		).
+		Params(PodResourceParams()...).
+		Params(PodVolumeParams()...).
+		Params(PodProbeParams()...).
+		Params(PodLifecycleParams()...).
+		Params(PodSecurityParams()...).
+		Params(PodSchedulingParams()...).
+		Params(PodPlacementParams()...).
+		Params(strategy).
 		Helper("HealthProbe", HealthProbeParam()).
 		Template(webserviceTemplate)
 }
@@ -234,25 +101,14 @@ func Webservice() *defkit.ComponentDefinition {
 // webserviceTemplate defines the template function for webservice.
 func webserviceTemplate(tpl *defkit.Template) {
 	vela := defkit.VelaCtx()
-	image := defkit.String("image")
 	port := defkit.Int("port")
 	ports := defkit.List("ports")
 	exposeType := defkit.String("exposeType")
 	addRevisionLabel := defkit.Bool("addRevisionLabel")
-	cmd := defkit.StringList("cmd")
-	args := defkit.StringList("args")
-	env := defkit.List("env")
-	cpu := defkit.String("cpu")
-	memory := defkit.String("memory")
-	volumeMounts := defkit.Object("volumeMounts")
-	volumes := defkit.List("volumes")
-	livenessProbe := defkit.Object("livenessProbe")
-	readinessProbe := defkit.Object("readinessProbe")
-	hostAliases := defkit.List("hostAliases")
+	strategy := defkit.Object("strategy")
 	labels := defkit.Object("labels")
 	annotations := defkit.Object("annotations")
-	imagePullPolicy := defkit.String("imagePullPolicy")
-	imagePullSecrets := defkit.StringList("imagePullSecrets")
+	pod := NewPodTemplate(tpl, "spec.template")
 
 	// Transform ports to container format using ForEachWith for complex
 	// _name let binding with containerPort preference and protocol suffix.
@@ -295,60 +151,6 @@ func webserviceTemplate(tpl *defkit.Template) {
 		})
 	})
 
-	// Transform imagePullSecrets: ["secret1", "secret2"] -> [{name: "secret1"}, ...]
-	pullSecrets := ImagePullSecretsTransform(imagePullSecrets)
-
-	// Define template-level helpers for volumeMounts
-	mountsArray := tpl.Helper("mountsArray").
-		FromFields(volumeMounts, "pvc", "configMap", "secret", "emptyDir", "hostPath").
-		Pick("name", "mountPath").
-		PickIf(defkit.ItemFieldIsSet("subPath"), "subPath").
-		Build()
-
-	// volumesList: Transform volume sources to Kubernetes volume specs
-	volumesList := tpl.Helper("volumesList").
-		FromFields(volumeMounts, "pvc", "configMap", "secret", "emptyDir", "hostPath").
-		MapBySource(map[string]defkit.FieldMap{
-			"pvc": {
-				"name":                  defkit.FieldRef("name"),
-				"persistentVolumeClaim": defkit.Nested(defkit.FieldMap{"claimName": defkit.FieldRef("claimName")}),
-			},
-			"configMap": {
-				"name": defkit.FieldRef("name"),
-				"configMap": defkit.Nested(defkit.FieldMap{
-					"name":        defkit.FieldRef("cmName"),
-					"defaultMode": defkit.FieldRef("defaultMode"),
-					"items":       defkit.Optional("items"),
-				}),
-			},
-			"secret": {
-				"name": defkit.FieldRef("name"),
-				"secret": defkit.Nested(defkit.FieldMap{
-					"secretName":  defkit.FieldRef("secretName"),
-					"defaultMode": defkit.FieldRef("defaultMode"),
-					"items":       defkit.Optional("items"),
-				}),
-			},
-			"emptyDir": {
-				"name":     defkit.FieldRef("name"),
-				"emptyDir": defkit.Nested(defkit.FieldMap{"medium": defkit.FieldRef("medium")}),
-			},
-			"hostPath": {
-				"name":     defkit.FieldRef("name"),
-				"hostPath": defkit.Nested(defkit.FieldMap{"path": defkit.FieldRef("path")}),
-			},
-		}).
-		Build()
-
-	// deDupVolumesArray: Deduplicated volumes by name
-	deDupVolumesArray := tpl.Helper("deDupVolumesArray").
-		FromHelper(volumesList).
-		Dedupe("name").
-		Build()
-
-	// Suppress unused variable warnings (helpers are registered and referenced by name)
-	_ = volumesList
-
 	// Primary output: Deployment
 	deployment := defkit.NewResource("apps/v1", "Deployment").
 		Set("spec.selector.matchLabels[app.oam.dev/component]", vela.Name()).
@@ -359,89 +161,19 @@ func webserviceTemplate(tpl *defkit.Template) {
 		SetIf(addRevisionLabel.IsTrue(), "spec.template.metadata.labels[app.oam.dev/revision]", vela.Revision()).
 		// SpreadIf spreads user labels inside the labels block
 		SpreadIf(labels.IsSet(), "spec.template.metadata.labels", labels).
-		SetIf(annotations.IsSet(), "spec.template.metadata.annotations", annotations).
-		// Container spec
-		Set("spec.template.spec.containers[0].name", vela.Name()).
-		Set("spec.template.spec.containers[0].image", image).
+		SetIf(annotations.IsSet(), "spec.template.metadata.annotations", annotations)
+	pod.Apply(deployment).
 		// Deprecated port fallback (before modern ports)
 		If(defkit.And(port.IsSet(), ports.NotSet())).
-		Set("spec.template.spec.containers[0].ports", defkit.InlineArray(map[string]defkit.Value{
+		Set(pod.Container("ports"), defkit.InlineArray(map[string]defkit.Value{
 			"containerPort": port,
 		})).
 		EndIf().
-		SetIf(ports.IsSet(), "spec.template.spec.containers[0].ports", containerPorts).
-		SetIf(imagePullPolicy.IsSet(), "spec.template.spec.containers[0].imagePullPolicy", imagePullPolicy).
-		SetIf(cmd.IsSet(), "spec.template.spec.containers[0].command", cmd).
-		SetIf(args.IsSet(), "spec.template.spec.containers[0].args", args).
-		SetIf(env.IsSet(), "spec.template.spec.containers[0].env", env).
-		SetIf(defkit.PathExists(`context["config"]`), "spec.template.spec.containers[0].env", defkit.Reference("context.config")).
-		// CPU with limit branching: when limit.cpu is set, use it for limits; otherwise use cpu for both
-		SetIf(defkit.And(cpu.IsSet(), defkit.PathExists("parameter.limit.cpu")),
-			"spec.template.spec.containers[0].resources.requests.cpu", cpu).
-		SetIf(defkit.And(cpu.IsSet(), defkit.PathExists("parameter.limit.cpu")),
-			"spec.template.spec.containers[0].resources.limits.cpu", defkit.Reference("parameter.limit.cpu")).
-		SetIf(defkit.And(cpu.IsSet(), defkit.Not(defkit.PathExists("parameter.limit.cpu"))),
-			"spec.template.spec.containers[0].resources.limits.cpu", cpu).
-		SetIf(defkit.And(cpu.IsSet(), defkit.Not(defkit.PathExists("parameter.limit.cpu"))),
-			"spec.template.spec.containers[0].resources.requests.cpu", cpu).
-		// Memory with limit branching: when limit.memory is set, use it for limits; otherwise use memory for both
-		SetIf(defkit.And(memory.IsSet(), defkit.PathExists("parameter.limit.memory")),
-			"spec.template.spec.containers[0].resources.limits.memory", defkit.Reference("parameter.limit.memory")).
-		SetIf(defkit.And(memory.IsSet(), defkit.PathExists("parameter.limit.memory")),
-			"spec.template.spec.containers[0].resources.requests.memory", memory).
-		SetIf(defkit.And(memory.IsSet(), defkit.Not(defkit.PathExists("parameter.limit.memory"))),
-			"spec.template.spec.containers[0].resources.limits.memory", memory).
-		SetIf(defkit.And(memory.IsSet(), defkit.Not(defkit.PathExists("parameter.limit.memory"))),
-			"spec.template.spec.containers[0].resources.requests.memory", memory).
-		// Deprecated volumes fallback - container volumeMounts
-		If(defkit.And(volumes.IsSet(), volumeMounts.NotSet())).
-		Set("spec.template.spec.containers[0].volumeMounts",
-			defkit.Each(volumes).Map(defkit.FieldMap{
-				"mountPath": defkit.FieldRef("mountPath"),
-				"name":      defkit.FieldRef("name"),
-			})).
-		EndIf().
-		SetIf(volumeMounts.IsSet(), "spec.template.spec.containers[0].volumeMounts", mountsArray).
-		SetIf(livenessProbe.IsSet(), "spec.template.spec.containers[0].livenessProbe", livenessProbe).
-		SetIf(readinessProbe.IsSet(), "spec.template.spec.containers[0].readinessProbe", readinessProbe).
-		// Pod spec
-		SetIf(hostAliases.IsSet(), "spec.template.spec.hostAliases", hostAliases).
-		Directive("spec.template.spec.hostAliases", "patchKey=ip").
-		SetIf(imagePullSecrets.IsSet(), "spec.template.spec.imagePullSecrets", pullSecrets).
-		// Deprecated volumes fallback - pod spec volumes
-		If(defkit.And(volumes.IsSet(), volumeMounts.NotSet())).
-		Set("spec.template.spec.volumes",
-			defkit.Each(volumes).
-				Map(defkit.FieldMap{
-					"name": defkit.FieldRef("name"),
-				}).
-				MapVariant("type", "pvc", defkit.FieldMap{
-					"persistentVolumeClaim": defkit.NestedFieldMap(defkit.FieldMap{
-						"claimName": defkit.FieldRef("claimName"),
-					}),
-				}).
-				MapVariant("type", "configMap", defkit.FieldMap{
-					"configMap": defkit.NestedFieldMap(defkit.FieldMap{
-						"defaultMode": defkit.FieldRef("defaultMode"),
-						"name":        defkit.FieldRef("cmName"),
-						"items":       defkit.OptionalFieldRef("items"),
-					}),
-				}).
-				MapVariant("type", "secret", defkit.FieldMap{
-					"secret": defkit.NestedFieldMap(defkit.FieldMap{
-						"defaultMode": defkit.FieldRef("defaultMode"),
-						"secretName":  defkit.FieldRef("secretName"),
-						"items":       defkit.OptionalFieldRef("items"),
-					}),
-				}).
-				MapVariant("type", "emptyDir", defkit.FieldMap{
-					"emptyDir": defkit.NestedFieldMap(defkit.FieldMap{
-						"medium": defkit.FieldRef("medium"),
-					}),
-				})).
-		EndIf().
-		SetIf(volumeMounts.IsSet(), "spec.template.spec.volumes", deDupVolumesArray)
-
+		SetIf(ports.IsSet(), pod.Container("ports"), containerPorts)
+	pod.ApplyLifecycle(deployment)
+	pod.ApplySecurity(deployment)
+	pod.ApplyPlacement(deployment)
+	deployment.SetIf(strategy.IsSet(), "spec.strategy", strategy)
 	tpl.Output(deployment)
 
 	// exposePorts helper: Complex iteration with guard, filter, conditionals,
@@ -500,6 +232,10 @@ func webserviceTemplate(tpl *defkit.Template) {
 			item.IfSet("protocol", func() {
 				item.Set("protocol", v.Field("protocol"))
 			})
+
+			item.IfSet("appProtocol", func() {
+				item.Set("appProtocol", v.Field("appProtocol"))
+			})
 		},
 	)
 
@@ -514,6 +250,7 @@ func webserviceTemplate(tpl *defkit.Template) {
 		Set("spec.selector[app.oam.dev/component]", vela.Name()).
 		Set("spec.ports", exposePorts).
 		Set("spec.type", exposeType)
+	ApplyServiceOptions(service, "parameter.exposeType")
 
 	tpl.OutputsIf(exposePorts.NotEmpty(), "webserviceExpose", service)
 }
@@ -19,6 +19,7 @@ package components_test
 import (
 	"strings"
 
+	"cuelang.org/go/cue"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -429,7 +430,7 @@ var _ = Describe("Webservice Component", func() {
 			Expect(cueOutput).To(ContainSubstring("parameter.limit.memory"))
 		})
 
-		It("should generate hostPath volumeMounts without mountPropagation or readOnly", func() {
+		It("should generate hostPath volumeMounts with the shared mountPropagation and readOnly fields", func() {
 			// Extract the hostPath section from the parameter block
 			paramIdx := strings.Index(cueOutput, "\tparameter: {")
 			Expect(paramIdx).To(BeNumerically(">", 0))
@@ -442,11 +443,64 @@ var _ = Describe("Webservice Component", func() {
 			hostPathIdx := strings.Index(paramSection, `hostPath`)
 			Expect(hostPathIdx).To(BeNumerically(">", 0))
 
-			// mountPropagation and readOnly should not appear in hostPath fields
-			// They may appear elsewhere, so we check the hostPath subsection
-			hostPathSection := paramSection[hostPathIdx : hostPathIdx+200]
-			Expect(hostPathSection).NotTo(ContainSubstring("mountPropagation"))
-			Expect(hostPathSection).NotTo(ContainSubstring("readOnly"))
+			// The shared volumes group carries daemon's hostPath fields to every pod component
+			hostPathSection := paramSection[hostPathIdx : hostPathIdx+300]
+			Expect(hostPathSection).To(ContainSubstring(`mountPropagation?: "None" | "HostToContainer" | "Bidirectional"`))
+			Expect(hostPathSection).To(ContainSubstring("readOnly?: bool"))
+		})
+
+		It("should generate the lifecycle, security and placement parameters", func() {
+			Expect(cueOutput).To(ContainSubstring("startupProbe?: #HealthProbe"))
+			Expect(cueOutput).To(ContainSubstring("terminationGracePeriodSeconds?: int & >=0"))
+			Expect(cueOutput).To(ContainSubstring("podSecurityContext?: {"))
+			Expect(cueOutput).To(ContainSubstring("serviceAccountName?: string"))
+			Expect(cueOutput).To(ContainSubstring("tolerations?: [...{"))
+			Expect(cueOutput).To(ContainSubstring("priorityClassName?: string"))
+		})
+
+		It("should render the lifecycle, security and placement parameters into the pod template", func() {
+			Expect(cueOutput).To(ContainSubstring("startupProbe: *parameter.startupProbe | _"))
+			Expect(cueOutput).To(ContainSubstring("securityContext: *parameter.securityContext | _"))
+			Expect(cueOutput).To(ContainSubstring("securityContext: *parameter.podSecurityContext | _"))
+			Expect(cueOutput).To(ContainSubstring("nodeSelector: *parameter.nodeSelector | _"))
+			Expect(cueOutput).To(ContainSubstring("// +patchKey=key\n\t\t\t\t\t\ttolerations: *parameter.tolerations | _"))
+			Expect(cueOutput).To(ContainSubstring("// +patchKey=topologyKey"))
+			Expect(cueOutput).To(ContainSubstring(`if c.labelSelector == _|_ {labelSelector: matchLabels: "app.oam.dev/component": context.name}`))
+		})
+
+		It("should generate the rollout strategy with int or string surge bounds", func() {
+			Expect(cueOutput).To(ContainSubstring(`type: *"RollingUpdate" | "Recreate"`))
+			Expect(cueOutput).To(ContainSubstring("maxSurge?: int | string"))
+			Expect(cueOutput).To(ContainSubstring("maxUnavailable?: int | string"))
+			Expect(cueOutput).To(ContainSubstring("strategy: parameter.strategy"))
+		})
+
+		It("should generate the service options on the exposed Service", func() {
+			Expect(cueOutput).To(ContainSubstring("service?: {"))
+			Expect(cueOutput).To(ContainSubstring(`ipFamilies?: [..."IPv4" | "IPv6"]`))
+			Expect(cueOutput).To(ContainSubstring("annotations: parameter.service.annotations"))
+			Expect(cueOutput).To(ContainSubstring(`clusterIP: [if parameter.service.clusterIP == "None" if parameter.exposeType != "ClusterIP" {error(`))
+			Expect(cueOutput).To(ContainSubstring("sessionAffinity: parameter.service.sessionAffinity"))
+			Expect(cueOutput).To(ContainSubstring(`externalTrafficPolicy: [if parameter.exposeType != "NodePort" if parameter.exposeType != "LoadBalancer" {error(`))
+		})
+
+		It("should generate appProtocol optional conditional in exposePorts", func() {
+			Expect(cueOutput).To(ContainSubstring("appProtocol?: string"))
+			Expect(cueOutput).To(ContainSubstring("appProtocol: v.appProtocol"))
+		})
+	})
+
+	Describe("Webservice rendering", func() {
+		ctx := `{name: "api", appName: "shop", namespace: "prod"}`
+
+		It("should check the Service options against exposeType", func() {
+			v := render(components.Webservice(), ctx, `{image: "api:1", ports: [{port: 80, expose: true}], exposeType: "NodePort", service: externalTrafficPolicy: "Local"}`)
+			Expect(lookup(v, "outputs.webserviceExpose.spec.externalTrafficPolicy")).To(Equal("Local"))
+
+			err := renderTemplate(components.Webservice(), ctx, `{image: "api:1", ports: [{port: 80, expose: true}], service: externalTrafficPolicy: "Local"}`).Validate(cue.Concrete(true))
+			Expect(err).To(MatchError(ContainSubstring("service.externalTrafficPolicy is only valid for a NodePort or LoadBalancer Service")))
+			err = renderTemplate(components.Webservice(), ctx, `{image: "api:1", ports: [{port: 80, expose: true}], exposeType: "LoadBalancer", service: clusterIP: "None"}`).Validate(cue.Concrete(true))
+			Expect(err).To(MatchError(ContainSubstring("service.clusterIP None is only valid for a ClusterIP Service")))
 		})
 	})
 })
@@ -24,125 +24,6 @@ import (
 // It describes long-running, scalable, containerized services that running at backend.
 // They do NOT have network endpoint to receive external network traffic.
 func Worker() *defkit.ComponentDefinition {
-	image := defkit.String("image").Description("Which image would you like to use for your service").Short("i")
-	imagePullPolicy := defkit.String("imagePullPolicy").Optional().Description("Specify image pull policy for your service")
-	imagePullSecrets := defkit.StringList("imagePullSecrets").Optional().Description("Specify image pull secrets for your service")
-	cmd := defkit.StringList("cmd").Optional().Description("Commands to run in the container")
-
-	env := defkit.List("env").
-		Optional().
-		Description("Define arguments by using environment variables").
-		WithFields(
-			defkit.String("name").Description("Environment variable name"),
-			defkit.String("value").Optional().Description("The value of the environment variable"),
-			defkit.Object("valueFrom").Optional().Description("Specifies a source the value of this var should come from").
-				WithFields(
-					defkit.Object("secretKeyRef").Optional().Description("Selects a key of a secret in the pod's namespace").
-						WithFields(
-							defkit.String("name").Description("The name of the secret in the pod's namespace to select from"),
-							defkit.String("key").Description("The key of the secret to select from. Must be a valid secret key"),
-						),
-					defkit.Object("configMapKeyRef").Optional().Description("Selects a key of a config map in the pod's namespace").
-						WithFields(
-							defkit.String("name").Description("The name of the config map in the pod's namespace to select from"),
-							defkit.String("key").Description("The key of the config map to select from. Must be a valid secret key"),
-						),
-				),
-		)
-
-	cpu := defkit.String("cpu").Optional().Description("Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)")
-	memory := defkit.String("memory").Optional().Description("Specifies the attributes of the memory resource required for the container.")
-
-	volumeMounts := defkit.Object("volumeMounts").
-		Optional().
-		WithFields(
-			defkit.List("pvc").Optional().Description("Mount PVC type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.String("claimName").Description("The name of the PVC"),
-			),
-			defkit.List("configMap").Optional().Description("Mount ConfigMap type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.Int("defaultMode").Default(420),
-				defkit.String("cmName"),
-				defkit.List("items").Optional().WithFields(
-					defkit.String("key"),
-					defkit.String("path"),
-					defkit.Int("mode").Default(511),
-				),
-			),
-			defkit.List("secret").Optional().Description("Mount Secret type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.Int("defaultMode").Default(420),
-				defkit.String("secretName"),
-				defkit.List("items").Optional().WithFields(
-					defkit.String("key"),
-					defkit.String("path"),
-					defkit.Int("mode").Default(511),
-				),
-			),
-			defkit.List("emptyDir").Optional().Description("Mount EmptyDir type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.Enum("medium").Values("", "Memory").Default(""),
-			),
-			defkit.List("hostPath").Optional().Description("Mount HostPath type volume").WithFields(
-				defkit.String("name"),
-				defkit.String("mountPath"),
-				defkit.String("path"),
-			),
-		)
-
-	volumes := defkit.List("volumes").Optional().Description("Deprecated field, use volumeMounts instead.").
-		WithFields(
-			defkit.String("name"),
-			defkit.String("mountPath"),
-			defkit.OneOf("type").
-				Description(`Specify volume type, options: "pvc","configMap","secret","emptyDir", default to emptyDir`).
-				Default("emptyDir").
-				Variants(
-					defkit.Variant("pvc").WithFields(
-						defkit.Field("claimName", defkit.ParamTypeString),
-					),
-					defkit.Variant("configMap").WithFields(
-						defkit.Field("defaultMode", defkit.ParamTypeInt).Default(420),
-						defkit.Field("cmName", defkit.ParamTypeString),
-						defkit.Field("items", defkit.ParamTypeArray).Optional().Nested(
-							defkit.Struct("").WithFields(
-								defkit.Field("key", defkit.ParamTypeString),
-								defkit.Field("path", defkit.ParamTypeString),
-								defkit.Field("mode", defkit.ParamTypeInt).Default(511),
-							),
-						),
-					),
-					defkit.Variant("secret").WithFields(
-						defkit.Field("defaultMode", defkit.ParamTypeInt).Default(420),
-						defkit.Field("secretName", defkit.ParamTypeString),
-						defkit.Field("items", defkit.ParamTypeArray).Optional().Nested(
-							defkit.Struct("").WithFields(
-								defkit.Field("key", defkit.ParamTypeString),
-								defkit.Field("path", defkit.ParamTypeString),
-								defkit.Field("mode", defkit.ParamTypeInt).Default(511),
-							),
-						),
-					),
-					defkit.Variant("emptyDir").WithFields(
-						defkit.Field("medium", defkit.ParamTypeString).Default("").Values("", "Memory"),
-					),
-				),
-		)
-
-	livenessProbe := defkit.Object("livenessProbe").
-		Optional().
-		Description("Instructions for assessing whether the container is alive.").
-		WithSchemaRef("HealthProbe")
-	readinessProbe := defkit.Object("readinessProbe").
-		Optional().
-		Description("Instructions for assessing whether the container is in a suitable state to serve traffic.").
-		WithSchemaRef("HealthProbe")
-
 	return defkit.NewComponent("worker").
 		Description("Describes long-running, scalable, containerized services that running at backend. They do NOT have network endpoint to receive external network traffic.").
 		Workload("apps/v1", "Deployment").
@@ -159,173 +40,29 @@ func Worker() *defkit.ComponentDefinition {
 				defkit.StatusEq("context.output.spec.replicas", "ready.replicas"),
 				defkit.StatusOr(defkit.StatusEq("ready.observedGeneration", "context.output.metadata.generation"), "ready.observedGeneration > context.output.metadata.generation"),
 			).Build()).
-		Params(
-			image, imagePullPolicy, imagePullSecrets,
-			cmd, env,
-			cpu, memory, volumeMounts, volumes,
-			livenessProbe, readinessProbe,
-		).
-		Helper("HealthProbe", workerHealthProbeParam()).
+		Params(PodContainerParams()...).
+		Params(PodResourceParams()...).
+		Params(PodVolumeParams()...).
+		Params(PodProbeParams()...).
+		Params(PodSchedulingParams()...).
+		Helper("HealthProbe", HealthProbeParam()).
 		Template(workerTemplate)
 }
 
 // workerTemplate defines the template function for worker.
 func workerTemplate(tpl *defkit.Template) {
 	vela := defkit.VelaCtx()
-	image := defkit.String("image")
-	cmd := defkit.StringList("cmd")
-	env := defkit.List("env")
-	cpu := defkit.String("cpu")
-	memory := defkit.String("memory")
-	volumeMounts := defkit.Object("volumeMounts")
-	volumes := defkit.List("volumes")
-	livenessProbe := defkit.Object("livenessProbe")
-	readinessProbe := defkit.Object("readinessProbe")
-	imagePullPolicy := defkit.String("imagePullPolicy")
-	imagePullSecrets := defkit.StringList("imagePullSecrets")
-
-	// Transform imagePullSecrets
-	pullSecrets := ImagePullSecretsTransform(imagePullSecrets)
-
-	mountsArray := tpl.Helper("mountsArray").
-		FromFields(volumeMounts, "pvc", "configMap", "secret", "emptyDir", "hostPath").
-		Pick("name", "mountPath").
-		PickIf(defkit.ItemFieldIsSet("subPath"), "subPath").
-		Build()
-
-	volumesList := tpl.Helper("volumesList").
-		FromFields(volumeMounts, "pvc", "configMap", "secret", "emptyDir", "hostPath").
-		MapBySource(map[string]defkit.FieldMap{
-			"pvc": {
-				"name":                  defkit.FieldRef("name"),
-				"persistentVolumeClaim": defkit.Nested(defkit.FieldMap{"claimName": defkit.FieldRef("claimName")}),
-			},
-			"configMap": {
-				"name": defkit.FieldRef("name"),
-				"configMap": defkit.Nested(defkit.FieldMap{
-					"name":        defkit.FieldRef("cmName"),
-					"defaultMode": defkit.FieldRef("defaultMode"),
-					"items":       defkit.Optional("items"),
-				}),
-			},
-			"secret": {
-				"name": defkit.FieldRef("name"),
-				"secret": defkit.Nested(defkit.FieldMap{
-					"secretName":  defkit.FieldRef("secretName"),
-					"defaultMode": defkit.FieldRef("defaultMode"),
-					"items":       defkit.Optional("items"),
-				}),
-			},
-			"emptyDir": {
-				"name":     defkit.FieldRef("name"),
-				"emptyDir": defkit.Nested(defkit.FieldMap{"medium": defkit.FieldRef("medium")}),
-			},
-			"hostPath": {
-				"name":     defkit.FieldRef("name"),
-				"hostPath": defkit.Nested(defkit.FieldMap{"path": defkit.FieldRef("path")}),
-			},
-		}).
-		Build()
-
-	deDupVolumesArray := tpl.Helper("deDupVolumesArray").
-		FromHelper(volumesList).
-		Dedupe("name").
-		Build()
-
-	// Suppress unused variable warnings
-	_ = volumesList
 
 	// Primary output: Deployment
 	deployment := defkit.NewResource("apps/v1", "Deployment").
 		Set("spec.selector.matchLabels[app.oam.dev/component]", vela.Name()).
 		Set("spec.template.metadata.labels[app.oam.dev/name]", vela.AppName()).
-		Set("spec.template.metadata.labels[app.oam.dev/component]", vela.Name()).
-		Set("spec.template.spec.containers[0].name", vela.Name()).
-		Set("spec.template.spec.containers[0].image", image).
-		SetIf(imagePullPolicy.IsSet(), "spec.template.spec.containers[0].imagePullPolicy", imagePullPolicy).
-		SetIf(cmd.IsSet(), "spec.template.spec.containers[0].command", cmd).
-		SetIf(env.IsSet(), "spec.template.spec.containers[0].env", env).
-		SetIf(cpu.IsSet(), "spec.template.spec.containers[0].resources.limits.cpu", cpu).
-		SetIf(cpu.IsSet(), "spec.template.spec.containers[0].resources.requests.cpu", cpu).
-		SetIf(memory.IsSet(), "spec.template.spec.containers[0].resources.limits.memory", memory).
-		SetIf(memory.IsSet(), "spec.template.spec.containers[0].resources.requests.memory", memory).
-		If(defkit.And(volumes.IsSet(), volumeMounts.NotSet())).
-		Set("spec.template.spec.containers[0].volumeMounts",
-			defkit.Each(volumes).Map(defkit.FieldMap{
-				"mountPath": defkit.FieldRef("mountPath"),
-				"name":      defkit.FieldRef("name"),
-			})).
-		EndIf().
-		SetIf(volumeMounts.IsSet(), "spec.template.spec.containers[0].volumeMounts", mountsArray).
-		SetIf(livenessProbe.IsSet(), "spec.template.spec.containers[0].livenessProbe", livenessProbe).
-		SetIf(readinessProbe.IsSet(), "spec.template.spec.containers[0].readinessProbe", readinessProbe).
-		// imagePullSecrets at pod spec level (before legacy volumes)
-		SetIf(imagePullSecrets.IsSet(), "spec.template.spec.imagePullSecrets", pullSecrets).
-		If(defkit.And(volumes.IsSet(), volumeMounts.NotSet())).
-		Set("spec.template.spec.volumes",
-			defkit.Each(volumes).
-				Map(defkit.FieldMap{
-					"name": defkit.FieldRef("name"),
-				}).
-				MapVariant("type", "pvc", defkit.FieldMap{
-					"persistentVolumeClaim": defkit.NestedFieldMap(defkit.FieldMap{
-						"claimName": defkit.FieldRef("claimName"),
-					}),
-				}).
-				MapVariant("type", "configMap", defkit.FieldMap{
-					"configMap": defkit.NestedFieldMap(defkit.FieldMap{
-						"defaultMode": defkit.FieldRef("defaultMode"),
-						"name":        defkit.FieldRef("cmName"),
-						"items":       defkit.OptionalFieldRef("items"),
-					}),
-				}).
-				MapVariant("type", "secret", defkit.FieldMap{
-					"secret": defkit.NestedFieldMap(defkit.FieldMap{
-						"defaultMode": defkit.FieldRef("defaultMode"),
-						"secretName":  defkit.FieldRef("secretName"),
-						"items":       defkit.OptionalFieldRef("items"),
-					}),
-				}).
-				MapVariant("type", "emptyDir", defkit.FieldMap{
-					"emptyDir": defkit.NestedFieldMap(defkit.FieldMap{
-						"medium": defkit.FieldRef("medium"),
-					}),
-				})).
-		EndIf().
-		SetIf(volumeMounts.IsSet(), "spec.template.spec.volumes", deDupVolumesArray)
+		Set("spec.template.metadata.labels[app.oam.dev/component]", vela.Name())
+	NewPodTemplate(tpl, "spec.template").Apply(deployment)
 
 	tpl.Output(deployment)
 }
 
-// workerHealthProbeParam returns a HealthProbe param for worker (without host/scheme in httpGet).
-func workerHealthProbeParam() *defkit.MapParam {
-	return defkit.Object("probe").
-		WithFields(
-			defkit.Object("exec").Optional().Description("Instructions for assessing container health by executing a command. Either this attribute or the httpGet attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the httpGet attribute and the tcpSocket attribute.").
-				WithFields(
-					defkit.StringList("command").Description("A command to be executed inside the container to assess its health. Each space delimited token of the command is a separate array element. Commands exiting 0 are considered to be successful probes, whilst all other exit codes are considered failures."),
-				),
-			defkit.Object("httpGet").Optional().Description("Instructions for assessing container health by executing an HTTP GET request. Either this attribute or the exec attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the exec attribute and the tcpSocket attribute.").
-				WithFields(
-					defkit.String("path").Description("The endpoint, relative to the port, to which the HTTP GET request should be directed."),
-					defkit.Int("port").Description("The TCP socket within the container to which the HTTP GET request should be directed."),
-					defkit.List("httpHeaders").Optional().WithFields(
-						defkit.String("name"),
-						defkit.String("value"),
-					),
-				),
-			defkit.Object("tcpSocket").Optional().Description("Instructions for assessing container health by probing a TCP socket. Either this attribute or the exec attribute or the httpGet attribute MUST be specified. This attribute is mutually exclusive with both the exec attribute and the httpGet attribute.").
-				WithFields(
-					defkit.Int("port").Description("The TCP socket within the container that should be probed to assess container health."),
-				),
-			defkit.Int("initialDelaySeconds").Default(0).Description("Number of seconds after the container is started before the first probe is initiated."),
-			defkit.Int("periodSeconds").Default(10).Description("How often, in seconds, to execute the probe."),
-			defkit.Int("timeoutSeconds").Default(1).Description("Number of seconds after which the probe times out."),
-			defkit.Int("successThreshold").Default(1).Description("Minimum consecutive successes for the probe to be considered successful after having failed."),
-			defkit.Int("failureThreshold").Default(3).Description("Number of consecutive failures required to determine the container is not alive (liveness probe) or not ready (readiness probe)."),
-		)
-}
-
 func init() {
 	defkit.Register(Worker())
 }
@@ -58,9 +58,9 @@ var _ = Describe("Worker Component", func() {
 			comp := components.Worker()
 			expectedParams := []string{
 				"image", "imagePullPolicy", "imagePullSecrets",
-				"cmd", "env",
-				"cpu", "memory", "volumeMounts", "volumes",
-				"livenessProbe", "readinessProbe",
+				"cmd", "args", "env",
+				"cpu", "memory", "limit", "volumeMounts", "volumes",
+				"livenessProbe", "readinessProbe", "hostAliases",
 			}
 			for _, param := range expectedParams {
 				Expect(comp).To(HaveParamNamed(param))
@@ -345,14 +345,14 @@ var _ = Describe("Worker Component", func() {
 			Expect(cueOutput).To(ContainSubstring("failureThreshold: *3 | int"))
 		})
 
-		It("should NOT include host and scheme in HealthProbe httpGet", func() {
+		It("should include the shared host and scheme in HealthProbe httpGet", func() {
 			// Find the #HealthProbe section
 			probeIdx := strings.Index(cueOutput, "#HealthProbe:")
 			Expect(probeIdx).To(BeNumerically(">", 0))
 			probeSection := cueOutput[probeIdx:]
 
-			Expect(probeSection).NotTo(ContainSubstring("host?:"))
-			Expect(probeSection).NotTo(ContainSubstring("scheme?:"))
+			Expect(probeSection).To(ContainSubstring("host?: string"))
+			Expect(probeSection).To(ContainSubstring(`scheme?: *"HTTP" | string`))
 		})
 
 		// Issue #9: mountsArray helper name
@@ -0,0 +1,256 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+	"github.com/oam-dev/kubevela/pkg/oam"
+
+	// Import all definition packages so the bundle can include their rendered CUE
+	_ "github.com/oam-dev/vela-go-definitions/components"
+	_ "github.com/oam-dev/vela-go-definitions/policies"
+	_ "github.com/oam-dev/vela-go-definitions/traits"
+	_ "github.com/oam-dev/vela-go-definitions/workflowsteps"
+)
+
+// --------------------------------------------------------------------------
+// Failure diagnostics bundle
+// --------------------------------------------------------------------------
+
+// getArtifactsDir returns the directory failure bundles are written to.
+// Bundles are disabled when E2E_ARTIFACTS_DIR is not set.
+func getArtifactsDir() string {
+	return os.Getenv("E2E_ARTIFACTS_DIR")
+}
+
+// writeFailureBundle dumps everything needed to debug a failed test after its
+// namespace is gone. It must run before cleanup deletes the applications.
+//
+// Layout of <E2E_ARTIFACTS_DIR>/<namespace>/:
+//
+//	diagnostics.txt         getAppFailureDiagnostics output
+//	applications.yaml       Applications with status
+//	resourcetrackers.yaml   ResourceTrackers owned by the applications
+//	resources.yaml          every listable namespaced resource in the namespace
+//	events.txt              namespace events, oldest first
+//	logs/<pod>.log          logs of all containers of each pod
+//	definitions/<type>/<name>.cue  rendered CUE of the definitions the applications use
+func writeFailureBundle(ctx context.Context, apps []*v1beta1.Application, namespace, diagnostics string) (string, error) {
+	dir := filepath.Join(getArtifactsDir(), namespace)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create bundle directory %s: %w", dir, err)
+	}
+
+	files := map[string]string{
+		"diagnostics.txt":       diagnostics,
+		"applications.yaml":     getApplicationsYAML(ctx, apps, namespace),
+		"resourcetrackers.yaml": getResourceTrackersYAML(ctx, apps, namespace),
+		"resources.yaml":        getNamespaceResourcesYAML(namespace),
+		"events.txt":            runKubectl("get", "events", "-n", namespace, "--sort-by=.lastTimestamp", "-o", "wide"),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	if err := writePodLogs(ctx, filepath.Join(dir, "logs"), namespace); err != nil {
+		return "", err
+	}
+	if err := writeDefinitionCUE(filepath.Join(dir, "definitions"), apps); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// runKubectl runs kubectl and returns its combined output, including any error.
+func runKubectl(args ...string) string {
+	output, err := exec.Command("kubectl", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Sprintf("%s\nkubectl %s error: %v\n", output, strings.Join(args, " "), err)
+	}
+	return string(output)
+}
+
+// toYAMLDocuments renders objects as a multi-doc YAML stream without managedFields.
+func toYAMLDocuments(objs []unstructured.Unstructured) string {
+	docs := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			docs = append(docs, fmt.Sprintf("# failed to marshal %s/%s: %v\n", obj.GetKind(), obj.GetName(), err))
+			continue
+		}
+		docs = append(docs, string(data))
+	}
+	return strings.Join(docs, "---\n")
+}
+
+// getApplicationsYAML returns the live Applications (spec and status) as YAML.
+func getApplicationsYAML(ctx context.Context, apps []*v1beta1.Application, namespace string) string {
+	var objs []unstructured.Unstructured
+	for _, app := range apps {
+		obj := unstructured.Unstructured{}
+		obj.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind(v1beta1.ApplicationKind))
+		if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: app.Name}, &obj); err != nil {
+			GinkgoWriter.Printf("Failed to get application %s for bundle: %v\n", app.Name, err)
+			continue
+		}
+		objs = append(objs, obj)
+	}
+	return toYAMLDocuments(objs)
+}
+
+// getResourceTrackersYAML returns the cluster-scoped ResourceTrackers of the applications.
+func getResourceTrackersYAML(ctx context.Context, apps []*v1beta1.Application, namespace string) string {
+	var objs []unstructured.Unstructured
+	for _, app := range apps {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind(v1beta1.ResourceTrackerKind + "List"))
+		if err := k8sClient.List(ctx, list, client.MatchingLabels{
+			oam.LabelAppName:      app.Name,
+			oam.LabelAppNamespace: namespace,
+		}); err != nil {
+			GinkgoWriter.Printf("Failed to list resource trackers of %s for bundle: %v\n", app.Name, err)
+			continue
+		}
+		objs = append(objs, list.Items...)
+	}
+	return toYAMLDocuments(objs)
+}
+
+// getNamespaceResourcesYAML dumps every listable namespaced resource type in the namespace.
+func getNamespaceResourcesYAML(namespace string) string {
+	output, err := exec.Command("kubectl", "api-resources", "--verbs=list", "--namespaced", "-o", "name").Output()
+	if err != nil {
+		return fmt.Sprintf("kubectl api-resources error: %v\n", err)
+	}
+	var kinds []string
+	for _, kind := range strings.Fields(string(output)) {
+		// Events are written separately to events.txt
+		if kind == "events" || kind == "events.events.k8s.io" {
+			continue
+		}
+		kinds = append(kinds, kind)
+	}
+	return runKubectl("get", strings.Join(kinds, ","), "-n", namespace, "--ignore-not-found", "-o", "yaml")
+}
+
+// writePodLogs writes the logs of all containers of every pod in the namespace.
+func writePodLogs(ctx context.Context, dir, namespace string) error {
+	pods := &corev1.PodList{}
+	if err := k8sClient.List(ctx, pods, client.InNamespace(namespace)); err != nil {
+		GinkgoWriter.Printf("Failed to list pods for bundle: %v\n", err)
+		return nil
+	}
+	if len(pods.Items) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create logs directory %s: %w", dir, err)
+	}
+	for _, pod := range pods.Items {
+		logs := runKubectl("logs", pod.Name, "-n", namespace, "--all-containers", "--prefix", "--ignore-errors")
+		if err := os.WriteFile(filepath.Join(dir, pod.Name+".log"), []byte(logs), 0o644); err != nil {
+			return fmt.Errorf("failed to write logs of pod %s: %w", pod.Name, err)
+		}
+	}
+	return nil
+}
+
+// usedDefinitions returns the definition names referenced by the applications,
+// keyed by definition type.
+func usedDefinitions(apps []*v1beta1.Application) map[defkit.DefinitionType]map[string]bool {
+	used := map[defkit.DefinitionType]map[string]bool{
+		defkit.DefinitionTypeComponent:    {},
+		defkit.DefinitionTypeTrait:        {},
+		defkit.DefinitionTypePolicy:       {},
+		defkit.DefinitionTypeWorkflowStep: {},
+	}
+	for _, app := range apps {
+		for _, comp := range app.Spec.Components {
+			used[defkit.DefinitionTypeComponent][comp.Type] = true
+			for _, trait := range comp.Traits {
+				used[defkit.DefinitionTypeTrait][trait.Type] = true
+			}
+		}
+		for _, policy := range app.Spec.Policies {
+			used[defkit.DefinitionTypePolicy][policy.Type] = true
+		}
+		if app.Spec.Workflow != nil {
+			for _, step := range app.Spec.Workflow.Steps {
+				used[defkit.DefinitionTypeWorkflowStep][step.Type] = true
+				for _, sub := range step.SubSteps {
+					used[defkit.DefinitionTypeWorkflowStep][sub.Type] = true
+				}
+			}
+		}
+	}
+	return used
+}
+
+// writeDefinitionCUE writes the rendered CUE of every registered definition the applications use.
+// Built-in types that are not defined in this repository (e.g. step-group) are listed in missing.txt.
+func writeDefinitionCUE(dir string, apps []*v1beta1.Application) error {
+	used := usedDefinitions(apps)
+	var missing []string
+	for _, def := range defkit.All() {
+		if !used[def.DefType()][def.DefName()] {
+			continue
+		}
+		delete(used[def.DefType()], def.DefName())
+
+		typeDir := filepath.Join(dir, string(def.DefType()))
+		if err := os.MkdirAll(typeDir, 0o755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", typeDir, err)
+		}
+		cuePath := filepath.Join(typeDir, def.DefName()+".cue")
+		if err := os.WriteFile(cuePath, []byte(def.ToCue()), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", cuePath, err)
+		}
+	}
+	for defType, names := range used {
+		for name := range names {
+			missing = append(missing, fmt.Sprintf("%s/%s", defType, name))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	return os.WriteFile(filepath.Join(dir, "missing.txt"), []byte(strings.Join(missing, "\n")+"\n"), 0o644)
+}
@@ -24,21 +24,24 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 )
 
 // definitionTestSuite describes a definition type's e2e test configuration.
 type definitionTestSuite struct {
-	label     string            // Ginkgo label for filtering: "components", "traits", etc.
-	subdir    string            // test data subdirectory under TESTDATA_PATH
-	descName  string            // human-readable name: "Component", "Trait", etc.
-	skipTests map[string]string // filename -> reason to skip
+	label     string                // Ginkgo label for filtering: "components", "traits", etc.
+	defType   defkit.DefinitionType // definition type of the tested definitions
+	subdir    string                // test data subdirectory under TESTDATA_PATH
+	descName  string                // human-readable name: "Component", "Trait", etc.
+	skipTests map[string]string     // filename -> reason to skip
 }
 
 var suites = []definitionTestSuite{
-	{label: "components", subdir: "applications/components", descName: "Component"},
-	{label: "traits", subdir: "applications/trait", descName: "Trait", skipTests: skipTraitTests},
-	{label: "policies", subdir: "applications/policies", descName: "Policy"},
-	{label: "workflowsteps", subdir: "applications/workflowsteps", descName: "WorkflowStep", skipTests: skipWorkflowStepTests},
+	{label: "components", defType: defkit.DefinitionTypeComponent, subdir: "applications/components", descName: "Component"},
+	{label: "traits", defType: defkit.DefinitionTypeTrait, subdir: "applications/trait", descName: "Trait", skipTests: skipTraitTests},
+	{label: "policies", defType: defkit.DefinitionTypePolicy, subdir: "applications/policies", descName: "Policy"},
+	{label: "workflowsteps", defType: defkit.DefinitionTypeWorkflowStep, subdir: "applications/workflowsteps", descName: "WorkflowStep", skipTests: skipWorkflowStepTests},
 }
 
 // Generate Describe blocks for each definition type.
@@ -69,7 +72,7 @@ var _ = func() bool {
 							skipTests = map[string]string{}
 						}
 
-						It(fmt.Sprintf("should run %s", filepath.Base(file)), func() {
+						It(fmt.Sprintf("should run %s", filepath.Base(file)), definitionLabels(s.defType, file), func() {
 							runDefinitionTest(ctx, file, skipTests)
 						})
 					}
@@ -0,0 +1,407 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/oam-dev/vela-go-definitions/test/httpstub"
+)
+
+const (
+	// Timeout for fixtures (CRDs, HTTP stubs) to become ready
+	FixtureReadyTimeout = 2 * time.Minute
+
+	// defaultHTTPStubImage is the image built from test/httpstub by `make e2e-httpstub-image`.
+	defaultHTTPStubImage = "vela-go-definitions/httpstub:e2e"
+	// httpStubContainerPort is the port the stub listens on inside the pod.
+	httpStubContainerPort = 8080
+	// httpStubConfigDir is where the stub's config.json is mounted.
+	httpStubConfigDir = "/etc/httpstub"
+)
+
+// --------------------------------------------------------------------------
+// Templating
+// --------------------------------------------------------------------------
+
+// templateVarPattern matches ${NAME} placeholders. Bare $name references are
+// left alone because CUE expressions in test data use them (e.g. $returns).
+var templateVarPattern = regexp.MustCompile(`\$\{([A-Z][A-Z0-9_]*)\}`)
+
+// templateVars returns the variables available to test data and expectation files.
+func templateVars(namespace, appName string) map[string]string {
+	return map[string]string{
+		"NAMESPACE": namespace,
+		"APP":       appName,
+	}
+}
+
+// renderTemplate replaces ${NAME} placeholders with their values.
+// Unknown placeholders are left untouched so mistakes show up in the rendered output.
+func renderTemplate(content string, vars map[string]string) string {
+	return templateVarPattern.ReplaceAllStringFunc(content, func(match string) string {
+		name := templateVarPattern.FindStringSubmatch(match)[1]
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// readTemplatedFile reads a file and renders its ${NAME} placeholders.
+// A nil vars map returns the content unchanged.
+func readTemplatedFile(filename string, vars map[string]string) ([]byte, error) {
+	bs, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if vars == nil {
+		return bs, nil
+	}
+	return []byte(renderTemplate(string(bs), vars)), nil
+}
+
+// --------------------------------------------------------------------------
+// Fixtures (declared in the `fixtures` section of .expect.yaml)
+// --------------------------------------------------------------------------
+
+// Fixtures declares the resources a test needs before its Applications are applied.
+type Fixtures struct {
+	// CRDs are paths to CustomResourceDefinition manifests, relative to TESTDATA_PATH.
+	// CRDs are cluster-scoped and shared between tests, so they are not deleted on cleanup.
+	CRDs       []string           `yaml:"crds,omitempty" json:"crds,omitempty"`
+	Secrets    []SecretFixture    `yaml:"secrets,omitempty" json:"secrets,omitempty"`
+	ConfigMaps []ConfigMapFixture `yaml:"configMaps,omitempty" json:"configMaps,omitempty"`
+	HTTPStubs  []HTTPStubFixture  `yaml:"httpStubs,omitempty" json:"httpStubs,omitempty"`
+}
+
+// SecretFixture is a Secret created in the test namespace.
+type SecretFixture struct {
+	Name       string            `yaml:"name" json:"name"`
+	Labels     map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Type       string            `yaml:"type,omitempty" json:"type,omitempty"` // defaults to Opaque
+	StringData map[string]string `yaml:"stringData,omitempty" json:"stringData,omitempty"`
+}
+
+// ConfigMapFixture is a ConfigMap created in the test namespace.
+type ConfigMapFixture struct {
+	Name   string            `yaml:"name" json:"name"`
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Data   map[string]string `yaml:"data,omitempty" json:"data,omitempty"`
+}
+
+// HTTPStubFixture is an in-cluster httpstub server that records requests and serves scripted responses.
+// It is reachable at http://<name>.<namespace>.svc.cluster.local (port 80).
+//
+// Status, Body, Mimic and Value are shorthand for a single route matching every request;
+// Routes gives full control (see the httpstub package) and takes precedence.
+type HTTPStubFixture struct {
+	Name   string           `yaml:"name" json:"name"`
+	Status int              `yaml:"status,omitempty" json:"status,omitempty"`
+	Body   string           `yaml:"body,omitempty" json:"body,omitempty"`
+	Mimic  httpstub.Mimic   `yaml:"mimic,omitempty" json:"mimic,omitempty"` // slack, dingtalk, lark, prometheus
+	Value  string           `yaml:"value,omitempty" json:"value,omitempty"` // prometheus sample value
+	Routes []httpstub.Route `yaml:"routes,omitempty" json:"routes,omitempty"`
+}
+
+// config converts the fixture into the stub server's configuration.
+func (f HTTPStubFixture) config() httpstub.Config {
+	if len(f.Routes) > 0 {
+		return httpstub.Config{Routes: f.Routes}
+	}
+	route := httpstub.Route{Mimic: f.Mimic, Value: f.Value}
+	if f.Status != 0 || f.Body != "" {
+		route.Responses = []httpstub.Response{{Status: f.Status, Body: f.Body}}
+	}
+	return httpstub.Config{Routes: []httpstub.Route{route}}
+}
+
+// getHTTPStubImage returns the httpstub image, overridable with E2E_HTTPSTUB_IMAGE.
+func getHTTPStubImage() string {
+	if image := os.Getenv("E2E_HTTPSTUB_IMAGE"); image != "" {
+		return image
+	}
+	return defaultHTTPStubImage
+}
+
+// applyFixtures creates all fixtures and waits until they are ready.
+func applyFixtures(ctx context.Context, fixtures *Fixtures, namespace string) error {
+	for _, crdPath := range fixtures.CRDs {
+		if err := applyCRDFixture(ctx, filepath.Join(getTestDataPath(), crdPath)); err != nil {
+			return err
+		}
+	}
+	for _, s := range fixtures.Secrets {
+		secretType := corev1.SecretTypeOpaque
+		if s.Type != "" {
+			secretType = corev1.SecretType(s.Type)
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: s.Name, Namespace: namespace, Labels: s.Labels},
+			Type:       secretType,
+			StringData: s.StringData,
+		}
+		GinkgoWriter.Printf("Creating fixture Secret %s/%s...\n", namespace, s.Name)
+		if err := createOrUpdate(ctx, secret); err != nil {
+			return fmt.Errorf("failed to create fixture Secret %s: %w", s.Name, err)
+		}
+	}
+	for _, c := range fixtures.ConfigMaps {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: c.Name, Namespace: namespace, Labels: c.Labels},
+			Data:       c.Data,
+		}
+		GinkgoWriter.Printf("Creating fixture ConfigMap %s/%s...\n", namespace, c.Name)
+		if err := createOrUpdate(ctx, cm); err != nil {
+			return fmt.Errorf("failed to create fixture ConfigMap %s: %w", c.Name, err)
+		}
+	}
+	for _, stub := range fixtures.HTTPStubs {
+		if err := applyHTTPStubFixture(ctx, stub, namespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createOrUpdate creates obj, or updates it in place when it already exists.
+func createOrUpdate(ctx context.Context, obj client.Object) error {
+	err := k8sClient.Create(ctx, obj)
+	if err == nil || !errors.IsAlreadyExists(err) {
+		return err
+	}
+	existing := &unstructured.Unstructured{}
+	gvk, err := k8sClient.GroupVersionKindFor(obj)
+	if err != nil {
+		return err
+	}
+	existing.SetGroupVersionKind(gvk)
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		return err
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return k8sClient.Update(ctx, obj)
+}
+
+// applyCRDFixture applies every CustomResourceDefinition in the file and waits for it to be established.
+func applyCRDFixture(ctx context.Context, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read CRD fixture %s: %w", path, err)
+	}
+	for _, doc := range strings.Split(string(content), "\n---") {
+		doc = strings.TrimSpace(doc)
+		if doc == "" {
+			continue
+		}
+		crd := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(doc), crd); err != nil {
+			return fmt.Errorf("failed to parse CRD fixture %s: %w", path, err)
+		}
+		if crd.GetKind() != "CustomResourceDefinition" {
+			return fmt.Errorf("CRD fixture %s contains %s %q, only CustomResourceDefinitions are allowed", path, crd.GetKind(), crd.GetName())
+		}
+
+		GinkgoWriter.Printf("Applying fixture CRD %s...\n", crd.GetName())
+		if err := createOrUpdate(ctx, crd); err != nil {
+			return fmt.Errorf("failed to apply CRD %s: %w", crd.GetName(), err)
+		}
+
+		Eventually(func(g Gomega) {
+			current := &unstructured.Unstructured{}
+			current.SetGroupVersionKind(crd.GroupVersionKind())
+			g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crd.GetName()}, current)).To(Succeed())
+			conditions, _, _ := unstructured.NestedSlice(current.Object, "status", "conditions")
+			established := false
+			for _, c := range conditions {
+				cond, _ := c.(map[string]interface{})
+				if cond["type"] == "Established" && cond["status"] == "True" {
+					established = true
+				}
+			}
+			g.Expect(established).To(BeTrue(), "CRD %s should be established", crd.GetName())
+		}, FixtureReadyTimeout, 2*time.Second).Should(Succeed())
+	}
+	return nil
+}
+
+// applyHTTPStubFixture deploys an httpstub Deployment, its config and Service, and waits until it serves.
+func applyHTTPStubFixture(ctx context.Context, stub HTTPStubFixture, namespace string) error {
+	config, err := json.Marshal(stub.config())
+	if err != nil {
+		return fmt.Errorf("failed to encode HTTP stub %s config: %w", stub.Name, err)
+	}
+	labels := map[string]string{"e2e.oam.dev/http-stub": stub.Name}
+	replicas := int32(1)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: stub.Name + "-httpstub", Namespace: namespace, Labels: labels},
+		Data:       map[string]string{"config.json": string(config)},
+	}
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: stub.Name, Namespace: namespace, Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:            "httpstub",
+						Image:           getHTTPStubImage(),
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Args: []string{
+							"--listen=:" + strconv.Itoa(httpStubContainerPort),
+							"--config=" + httpStubConfigDir + "/config.json",
+						},
+						Ports: []corev1.ContainerPort{{ContainerPort: httpStubContainerPort}},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{
+								Path: httpstub.HealthzPath,
+								Port: intstr.FromInt(httpStubContainerPort),
+							}},
+							PeriodSeconds: 2,
+						},
+						VolumeMounts: []corev1.VolumeMount{{Name: "config", MountPath: httpStubConfigDir}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "config",
+						VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: cm.Name},
+						}},
+					}},
+				},
+			},
+		},
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: stub.Name, Namespace: namespace, Labels: labels},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports: []corev1.ServicePort{{
+				Name:       "http",
+				Port:       80,
+				TargetPort: intstr.FromInt(httpStubContainerPort),
+			}},
+		},
+	}
+
+	GinkgoWriter.Printf("Deploying fixture HTTP stub %s/%s...\n", namespace, stub.Name)
+	for _, obj := range []client.Object{cm, deploy, svc} {
+		if err := createOrUpdate(ctx, obj); err != nil {
+			return fmt.Errorf("failed to create HTTP stub %s: %w", stub.Name, err)
+		}
+	}
+
+	Eventually(func(g Gomega) {
+		current := &appsv1.Deployment{}
+		g.Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: stub.Name}, current)).To(Succeed())
+		g.Expect(current.Status.ReadyReplicas).To(BeNumerically(">=", 1), "HTTP stub %s should be ready", stub.Name)
+	}, FixtureReadyTimeout, 2*time.Second).Should(Succeed())
+	return nil
+}
+
+// --------------------------------------------------------------------------
+// HTTP request expectations (what workflow steps sent to the stubs)
+// --------------------------------------------------------------------------
+
+// HTTPRequestExpectation asserts that an HTTP stub fixture received matching requests.
+type HTTPRequestExpectation struct {
+	Stub         string            `yaml:"stub" json:"stub"`
+	Method       string            `yaml:"method,omitempty" json:"method,omitempty"`
+	Path         string            `yaml:"path,omitempty" json:"path,omitempty"`
+	Headers      map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`           // values must be contained in the header
+	BodyContains []string          `yaml:"bodyContains,omitempty" json:"bodyContains,omitempty"` // all must be contained in the body
+	Count        int               `yaml:"count,omitempty" json:"count,omitempty"`               // minimum matching requests, defaults to 1
+}
+
+// matches reports whether req satisfies every criterion of the expectation.
+func (e HTTPRequestExpectation) matches(req httpstub.RecordedRequest) bool {
+	if e.Method != "" && !strings.EqualFold(e.Method, req.Method) {
+		return false
+	}
+	if e.Path != "" && e.Path != req.Path {
+		return false
+	}
+	for k, v := range e.Headers {
+		if !strings.Contains(req.Headers.Get(k), v) {
+			return false
+		}
+	}
+	for _, sub := range e.BodyContains {
+		if !strings.Contains(req.Body, sub) {
+			return false
+		}
+	}
+	return true
+}
+
+// getHTTPStubRequests fetches the requests recorded by an in-cluster stub through the API server service proxy.
+func getHTTPStubRequests(ctx context.Context, namespace, stub string) ([]httpstub.RecordedRequest, error) {
+	data, err := k8sClientset.CoreV1().Services(namespace).ProxyGet("http", stub, "80", httpstub.RequestsPath, nil).DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var requests []httpstub.RecordedRequest
+	if err := json.Unmarshal(data, &requests); err != nil {
+		return nil, fmt.Errorf("failed to decode requests of stub %s: %w", stub, err)
+	}
+	return requests, nil
+}
+
+// validateHTTPRequestExpectations checks that each stub received the expected requests.
+func validateHTTPRequestExpectations(ctx context.Context, namespace string, expectations []HTTPRequestExpectation) {
+	for _, exp := range expectations {
+		count := exp.Count
+		if count == 0 {
+			count = 1
+		}
+		GinkgoWriter.Printf("Validating requests received by HTTP stub %s (method=%q path=%q)...\n", exp.Stub, exp.Method, exp.Path)
+		Eventually(func(g Gomega) {
+			requests, err := getHTTPStubRequests(ctx, namespace, exp.Stub)
+			g.Expect(err).NotTo(HaveOccurred(), "Failed to fetch requests recorded by HTTP stub %s", exp.Stub)
+			matched := 0
+			for _, req := range requests {
+				if exp.matches(req) {
+					matched++
+				}
+			}
+			g.Expect(matched).To(BeNumerically(">=", count),
+				"HTTP stub %s should have received %d matching request(s), got %d. Recorded: %+v", exp.Stub, count, matched, requests)
+		}, 30*time.Second, 2*time.Second).Should(Succeed())
+	}
+}
@@ -35,9 +35,9 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
@@ -54,7 +54,8 @@ const (
 )
 
 var (
-	k8sClient client.Client
+	k8sClient    client.Client
+	k8sClientset kubernetes.Interface
 )
 
 // initK8sClient initializes the Kubernetes controller-runtime client once.
@@ -76,13 +77,20 @@ func initK8sClient() error {
 		return fmt.Errorf("failed to create k8s client: %w", err)
 	}
 
+	// Clientset for subresources such as the service proxy used to query HTTP stubs
+	k8sClientset, err = kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create k8s clientset: %w", err)
+	}
+
 	return nil
 }
 
-// readAllAppsFromFile reads ALL Applications from a multi-doc YAML file.
+// readAllAppsFromFile reads ALL Applications from a multi-doc YAML file,
+// rendering ${NAME} placeholders from vars (nil leaves them untouched).
 // Some test files (shared-resource, depends-on-app) contain multiple Applications.
-func readAllAppsFromFile(filename string) ([]*v1beta1.Application, error) {
-	bs, err := os.ReadFile(filename)
+func readAllAppsFromFile(filename string, vars map[string]string) ([]*v1beta1.Application, error) {
+	bs, err := readTemplatedFile(filename, vars)
 	if err != nil {
 		return nil, err
 	}
@@ -111,38 +119,6 @@ func readAllAppsFromFile(filename string) ([]*v1beta1.Application, error) {
 	return apps, nil
 }
 
-// updateAppNamespaceReferences updates namespace references inside Application components
-// This is needed for ref-objects type components that reference resources in specific namespaces
-func updateAppNamespaceReferences(app *v1beta1.Application, newNamespace string) {
-	for i := range app.Spec.Components {
-		comp := &app.Spec.Components[i]
-		if comp.Type == "ref-objects" && comp.Properties != nil {
-			// Parse properties as map
-			var props map[string]interface{}
-			if err := json.Unmarshal(comp.Properties.Raw, &props); err != nil {
-				continue
-			}
-
-			// Update namespace in objects array
-			if objects, ok := props["objects"].([]interface{}); ok {
-				for _, obj := range objects {
-					if objMap, ok := obj.(map[string]interface{}); ok {
-						// Update namespace field to the new namespace
-						if _, hasNs := objMap["namespace"]; hasNs {
-							objMap["namespace"] = newNamespace
-						}
-					}
-				}
-			}
-
-			// Marshal back to properties
-			if newProps, err := json.Marshal(props); err == nil {
-				comp.Properties = &runtime.RawExtension{Raw: newProps}
-			}
-		}
-	}
-}
-
 // getProjectRoot finds the project root by looking for go.mod.
 func getProjectRoot() string {
 	dir, err := os.Getwd()
@@ -214,8 +190,8 @@ func sanitizeForNamespace(name string) string {
 
 // applyPrerequisiteResources applies non-Application resources from a multi-doc YAML file
 // This is needed for files like ref-objects.yaml that reference existing Deployment/Service
-func applyPrerequisiteResources(ctx context.Context, filePath, namespace string) error {
-	content, err := os.ReadFile(filePath)
+func applyPrerequisiteResources(ctx context.Context, filePath, namespace string, vars map[string]string) error {
+	content, err := readTemplatedFile(filePath, vars)
 	if err != nil {
 		return err
 	}
@@ -357,7 +333,7 @@ func getAppFailureDiagnostics(ctx context.Context, appName, namespace string) st
 // --------------------------------------------------------------------------
 
 // skipWorkflowStepTests lists test files that require external infrastructure
-// (cloud providers, terraform, Prometheus, container registries, webhook endpoints)
+// (cloud providers, terraform, container registries)
 // and cannot run in a standard CI environment.
 var skipWorkflowStepTests = map[string]string{
 	"deploy-cloud-resource.yaml":    "requires alibaba-rds component and multi-cluster setup",
@@ -366,7 +342,6 @@ var skipWorkflowStepTests = map[string]string{
 	"apply-terraform-config.yaml":   "requires Alibaba Cloud credentials and terraform provider",
 	"apply-terraform-provider.yaml": "requires Alibaba Cloud credentials",
 	"build-push-image.yaml":         "requires external container registry (ttl.sh) and GitHub access",
-	"check-metrics.yaml":            "requires external Prometheus endpoint (demo.promlabs.com)",
 	"restart-workflow.yaml":         "self-restarting workflow cannot be validated with single-shot test framework",
 	"clean-jobs.yaml":               "clean-jobs requires namespace property matching prerequisite Jobs location",
 }
@@ -380,11 +355,16 @@ var skipTraitTests = map[string]string{
 // It creates an isolated namespace, applies all applications from the YAML file,
 // waits for running status, validates expectations, and cleans up.
 func runDefinitionTest(ctx context.Context, file string, skipTests map[string]string) {
+	// Record the machine-readable result for this definition, whatever the outcome
+	result := &definitionResult{}
+	DeferCleanup(recordDefinitionResult, result)
+
 	if reason, ok := skipTests[filepath.Base(file)]; ok {
+		result.SkipReason = reason
 		Skip(fmt.Sprintf("Skipping: %s", reason))
 	}
 
-	apps, err := readAllAppsFromFile(file)
+	apps, err := readAllAppsFromFile(file, nil)
 	Expect(err).NotTo(HaveOccurred(), "Failed to read applications from %s", file)
 
 	// The last application is the "main" one for validation purposes.
@@ -394,12 +374,19 @@ func runDefinitionTest(ctx context.Context, file string, skipTests map[string]st
 	appNameSanitized := sanitizeForNamespace(mainApp.Name)
 	uniqueNs := fmt.Sprintf("e2e-%s", appNameSanitized)
 
+	// Re-read with ${NAMESPACE}/${APP} rendered now that the namespace is known
+	vars := templateVars(uniqueNs, mainApp.Name)
+	apps, err = readAllAppsFromFile(file, vars)
+	Expect(err).NotTo(HaveOccurred(), "Failed to render applications from %s", file)
+	mainApp = apps[len(apps)-1]
+
 	// Set namespace on all apps
 	for _, app := range apps {
 		app.SetNamespace(uniqueNs)
-		updateAppNamespaceReferences(app, uniqueNs)
 	}
 
+	ef := loadExpectations(file, vars)
+
 	// Track test success for cleanup diagnostics
 	testPassed := false
 
@@ -407,7 +394,19 @@ func runDefinitionTest(ctx context.Context, file string, skipTests map[string]st
 	DeferCleanup(func() {
 		if !testPassed {
 			GinkgoWriter.Printf("\nTest did not complete successfully, gathering diagnostics...\n")
-			GinkgoWriter.Printf("%s\n", getAppFailureDiagnostics(ctx, mainApp.Name, uniqueNs))
+			result.Diagnostics = getAppFailureDiagnostics(ctx, mainApp.Name, uniqueNs)
+			GinkgoWriter.Printf("%s\n", result.Diagnostics)
+			// Persist a diagnostics bundle before the namespace is deleted
+			if getArtifactsDir() != "" {
+				if dir, err := writeFailureBundle(ctx, apps, uniqueNs, result.Diagnostics); err != nil {
+					GinkgoWriter.Printf("Failed to write diagnostics bundle: %v\n", err)
+				} else {
+					GinkgoWriter.Printf("Diagnostics bundle written to %s\n", dir)
+				}
+			}
+		}
+		if getReportDir() != "" {
+			result.RenderedResources = collectRenderedResources(ctx, apps, uniqueNs)
 		}
 		// Delete all apps first (to clear finalizers)
 		for _, app := range apps {
@@ -436,16 +435,23 @@ func runDefinitionTest(ctx context.Context, file string, skipTests map[string]st
 	}, 30*time.Second, 2*time.Second).Should(BeTrue(),
 		fmt.Sprintf("Application %s should be fully deleted before test", mainApp.Name))
 
+	// Apply fixtures declared in the companion .expect.yaml (CRDs, secrets, configmaps, HTTP stubs)
+	if ef != nil && ef.Fixtures != nil {
+		GinkgoWriter.Printf("Applying fixtures for %s...\n", filepath.Base(file))
+		Expect(applyFixtures(ctx, ef.Fixtures, uniqueNs)).To(Succeed(), "Failed to apply fixtures")
+	}
+
 	// Apply prerequisite non-Application resources (Deployments, Services, ConfigMaps, etc.)
 	if hasPrerequisiteResources(file) {
 		GinkgoWriter.Printf("Applying prerequisite resources from %s...\n", filepath.Base(file))
-		err = applyPrerequisiteResources(ctx, file, uniqueNs)
+		err = applyPrerequisiteResources(ctx, file, uniqueNs, vars)
 		Expect(err).NotTo(HaveOccurred(), "Failed to apply prerequisite resources")
 		// Wait for prerequisite resources to be ready
-		waitForPrerequisiteResources(ctx, file, uniqueNs)
+		waitForPrerequisiteResources(ctx, file, uniqueNs, vars)
 	}
 
 	// Apply all applications. For multi-app files, dependency apps go first.
+	appliedAt := time.Now()
 	for i, app := range apps {
 		GinkgoWriter.Printf("Applying application %s/%s (%d/%d)...\n", uniqueNs, app.Name, i+1, len(apps))
 		Expect(k8sClient.Create(ctx, app)).Should(Succeed())
@@ -458,12 +464,12 @@ func runDefinitionTest(ctx context.Context, file string, skipTests map[string]st
 			g.Expect(string(currentApp.Status.Phase)).Should(Equal("running"))
 		}, AppRunningTimeout, PollInterval).Should(Succeed())
 	}
+	result.TimeToRunningSeconds = time.Since(appliedAt).Seconds()
 
 	// Layer 1: Auto-derived validation on the main app
 	autoValidate(ctx, mainApp, uniqueNs)
 
 	// Layer 2: Extra expectations from companion .expect.yaml (additive)
-	ef := loadExpectations(file)
 	if ef != nil {
 		if len(ef.Expectations) > 0 {
 			GinkgoWriter.Printf("Validating %d extra resource expectation(s)...\n", len(ef.Expectations))
@@ -473,6 +479,10 @@ func runDefinitionTest(ctx context.Context, file string, skipTests map[string]st
 			GinkgoWriter.Printf("Validating %d extra workflow step expectation(s)...\n", len(ef.WorkflowSteps))
 			validateWorkflowStepExpectations(ctx, mainApp.Name, uniqueNs, ef.WorkflowSteps)
 		}
+		if len(ef.HTTPRequests) > 0 {
+			GinkgoWriter.Printf("Validating %d HTTP request expectation(s)...\n", len(ef.HTTPRequests))
+			validateHTTPRequestExpectations(ctx, uniqueNs, ef.HTTPRequests)
+		}
 	}
 
 	testPassed = true
@@ -481,8 +491,8 @@ func runDefinitionTest(ctx context.Context, file string, skipTests map[string]st
 
 // waitForPrerequisiteResources polls until prerequisite resources from a multi-doc YAML
 // are ready, instead of using a hardcoded sleep.
-func waitForPrerequisiteResources(ctx context.Context, filePath, namespace string) {
-	content, err := os.ReadFile(filePath)
+func waitForPrerequisiteResources(ctx context.Context, filePath, namespace string, vars map[string]string) {
+	content, err := readTemplatedFile(filePath, vars)
 	if err != nil {
 		return
 	}
@@ -521,7 +531,7 @@ func waitForPrerequisiteResources(ctx context.Context, filePath, namespace strin
 // Returns empty strings for types that create varied resources (k8s-objects, ref-objects).
 func componentTypeToGVK(componentType string) (apiVersion, kind string) {
 	switch componentType {
-	case "webservice", "worker":
+	case "webservice", "worker", "multi-container":
 		return "apps/v1", "Deployment"
 	case "daemon":
 		return "apps/v1", "DaemonSet"
@@ -649,16 +659,19 @@ type WorkflowStepExpectation struct {
 
 // ExpectationFile is the top-level structure of a .expect.yaml file.
 type ExpectationFile struct {
+	Fixtures      *Fixtures                 `yaml:"fixtures,omitempty" json:"fixtures,omitempty"`
 	Expectations  []ResourceExpectation     `yaml:"expectations,omitempty" json:"expectations,omitempty"`
 	WorkflowSteps []WorkflowStepExpectation `yaml:"workflowSteps,omitempty" json:"workflowSteps,omitempty"`
+	HTTPRequests  []HTTPRequestExpectation  `yaml:"httpRequests,omitempty" json:"httpRequests,omitempty"`
 }
 
 // loadExpectations looks for a .expect.yaml file in the expectations/ directory
 // that mirrors the applications/ directory structure.
 // For example, given .../builtin-definition-example/applications/components/webservice.yaml,
 // it looks for .../builtin-definition-example/expectations/components/webservice.expect.yaml.
+// ${NAME} placeholders are rendered from vars before parsing.
 // Returns nil if no expectation file exists.
-func loadExpectations(appYAMLPath string) *ExpectationFile {
+func loadExpectations(appYAMLPath string, vars map[string]string) *ExpectationFile {
 	// appYAMLPath: .../builtin-definition-example/applications/<type>/<name>.yaml
 	// expectPath:  .../builtin-definition-example/expectations/<type>/<name>.expect.yaml
 	dir := filepath.Dir(appYAMLPath)                // .../applications/components
@@ -670,7 +683,7 @@ func loadExpectations(appYAMLPath string) *ExpectationFile {
 
 	expectPath := filepath.Join(testDataRoot, "expectations", subdir, nameNoExt+".expect.yaml")
 
-	data, err := os.ReadFile(expectPath)
+	data, err := readTemplatedFile(expectPath, vars)
 	if err != nil {
 		return nil // No expectation file — that's fine
 	}
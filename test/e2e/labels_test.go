@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e_test
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// --------------------------------------------------------------------------
+// Per-test labels
+// --------------------------------------------------------------------------
+//
+// Every generated test carries, besides its suite label ("components", ...):
+//
+//	def:<name>      the definition under test (the test file name)
+//	type:<type>     its definition type: component, trait, policy, workflow-step
+//	uses:<name>     every definition its applications use, including def:<name>
+//	network         sends HTTP requests or needs an ingress controller
+//	multicluster    deploys through topology or cluster-aware policies
+//	terraform       needs the terraform addon or cloud credentials
+//
+// so a subset can be run with e.g. --label-filter="uses:gateway || uses:hpa".
+
+const (
+	labelDefinitionPrefix = "def:"
+	labelTypePrefix       = "type:"
+	labelUsesPrefix       = "uses:"
+
+	labelNetwork      = "network"
+	labelMulticluster = "multicluster"
+	labelTerraform    = "terraform"
+)
+
+// capabilityLabels lists, per definition name, the capability labels of every test using it.
+var capabilityLabels = map[string][]string{
+	// network
+	"webhook":          {labelNetwork},
+	"request":          {labelNetwork},
+	"notification":     {labelNetwork},
+	"check-metrics":    {labelNetwork},
+	"build-push-image": {labelNetwork},
+	"gateway":          {labelNetwork},
+	"pure-ingress":     {labelNetwork},
+
+	// multicluster
+	"topology":       {labelMulticluster},
+	"override":       {labelMulticluster},
+	"replication":    {labelMulticluster},
+	"export-data":    {labelMulticluster},
+	"export-service": {labelMulticluster},
+
+	// terraform
+	"apply-terraform-config":   {labelTerraform},
+	"apply-terraform-provider": {labelTerraform},
+	"alibaba-rds":              {labelTerraform},
+	"generate-jdbc-connection": {labelTerraform},
+	"deploy-cloud-resource":    {labelTerraform, labelMulticluster},
+	"share-cloud-resource":     {labelTerraform, labelMulticluster},
+}
+
+// definitionLabels returns the labels of the test running file for the given definition type.
+// The applications are parsed untemplated; a file that cannot be parsed only gets its
+// def: and type: labels and fails when the test runs.
+func definitionLabels(defType defkit.DefinitionType, file string) Labels {
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	uses := map[string]bool{name: true}
+	if apps, err := readAllAppsFromFile(file, nil); err == nil {
+		for _, names := range usedDefinitions(apps) {
+			for used := range names {
+				uses[used] = true
+			}
+		}
+	}
+
+	set := map[string]bool{
+		labelDefinitionPrefix + name:      true,
+		labelTypePrefix + string(defType): true,
+	}
+	for used := range uses {
+		set[labelUsesPrefix+used] = true
+		for _, capability := range capabilityLabels[used] {
+			set[capability] = true
+		}
+	}
+
+	labels := make([]string, 0, len(set))
+	for label := range set {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return Label(labels...)
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/reporters"
+	ginkgotypes "github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/oam-dev/kubevela/apis/core.oam.dev/v1beta1"
+)
+
+const (
+	// definitionResultEntry is the name of the report entry each definition test attaches.
+	definitionResultEntry = "definition-result"
+	// junitReportFile is the JUnit XML file written to E2E_REPORT_DIR.
+	junitReportFile = "junit.xml"
+	// summaryReportFile is the per-definition JSON summary written to E2E_REPORT_DIR.
+	summaryReportFile = "summary.json"
+)
+
+// --------------------------------------------------------------------------
+// Machine-readable results
+// --------------------------------------------------------------------------
+
+// definitionResult holds the per-definition data gathered while a test runs.
+// It is attached to the spec as a report entry and merged with the spec state
+// (pass/fail/skip, run time) when the suite report is written.
+type definitionResult struct {
+	SkipReason           string                   `json:"skipReason,omitempty"`
+	TimeToRunningSeconds float64                  `json:"timeToRunningSeconds,omitempty"`
+	Diagnostics          string                   `json:"diagnostics,omitempty"`
+	RenderedResources    []map[string]interface{} `json:"renderedResources,omitempty"`
+}
+
+// String keeps the report entry representation short; the full value is only
+// emitted in the JSON summary.
+func (r *definitionResult) String() string {
+	if r.SkipReason != "" {
+		return fmt.Sprintf("skipped: %s", r.SkipReason)
+	}
+	return fmt.Sprintf("time-to-running: %.1fs, rendered resources: %d", r.TimeToRunningSeconds, len(r.RenderedResources))
+}
+
+// definitionSummary is one entry of the JSON summary.
+type definitionSummary struct {
+	Definition      string  `json:"definition"`
+	Type            string  `json:"type"`
+	File            string  `json:"file"`
+	Status          string  `json:"status"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	Attempts        int     `json:"attempts"`
+	FailureMessage  string  `json:"failureMessage,omitempty"`
+	definitionResult
+}
+
+// suiteSummary is the top-level document written to summary.json.
+type suiteSummary struct {
+	Suite           string              `json:"suite"`
+	LabelFilter     string              `json:"labelFilter,omitempty"`
+	StartTime       time.Time           `json:"startTime"`
+	DurationSeconds float64             `json:"durationSeconds"`
+	Succeeded       bool                `json:"succeeded"`
+	Definitions     []definitionSummary `json:"definitions"`
+}
+
+// getReportDir returns the directory machine-readable reports are written to.
+// Reporting is disabled when E2E_REPORT_DIR is not set.
+func getReportDir() string {
+	return os.Getenv("E2E_REPORT_DIR")
+}
+
+// recordDefinitionResult attaches the gathered result to the current spec.
+// It is registered with DeferCleanup so it also runs for skipped and failed tests.
+func recordDefinitionResult(result *definitionResult) {
+	AddReportEntry(definitionResultEntry, result, ReportEntryVisibilityNever)
+}
+
+// collectRenderedResources fetches every resource listed in the applications'
+// status.appliedResources so the report shows what the definitions rendered.
+func collectRenderedResources(ctx context.Context, apps []*v1beta1.Application, namespace string) []map[string]interface{} {
+	var rendered []map[string]interface{}
+	for _, app := range apps {
+		currentApp := &v1beta1.Application{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: app.Name}, currentApp); err != nil {
+			continue
+		}
+		for _, ar := range currentApp.Status.AppliedResources {
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(parseGVK(ar.APIVersion, ar.Kind))
+			resourceNs := ar.Namespace
+			if resourceNs == "" && ar.Kind != "Namespace" {
+				resourceNs = namespace
+			}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: resourceNs, Name: ar.Name}, obj); err != nil {
+				// Cluster-scoped resources are looked up without a namespace
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: ar.Name}, obj); err != nil {
+					continue
+				}
+			}
+			unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+			rendered = append(rendered, obj.Object)
+		}
+	}
+	return rendered
+}
+
+// definitionTypeFromLabels returns the suite label ("components", "traits", ...) of a spec.
+func definitionTypeFromLabels(labels []string) string {
+	for _, l := range labels {
+		for _, s := range suites {
+			if l == s.label {
+				return l
+			}
+		}
+	}
+	return ""
+}
+
+// buildSuiteSummary converts the merged Ginkgo report into the per-definition summary.
+// Only specs that recorded a definition result are included.
+func buildSuiteSummary(report Report) (*suiteSummary, error) {
+	summary := &suiteSummary{
+		Suite:           report.SuiteDescription,
+		LabelFilter:     report.SuiteConfig.LabelFilter,
+		StartTime:       report.StartTime,
+		DurationSeconds: report.RunTime.Seconds(),
+		Succeeded:       report.SuiteSucceeded,
+		Definitions:     []definitionSummary{},
+	}
+
+	for _, spec := range report.SpecReports {
+		if spec.LeafNodeType != ginkgotypes.NodeTypeIt {
+			continue
+		}
+		for _, entry := range spec.ReportEntries {
+			if entry.Name != definitionResultEntry {
+				continue
+			}
+			// Entries from parallel processes arrive as decoded JSON rather than
+			// *definitionResult, so round-trip through JSON in both cases.
+			raw, err := json.Marshal(entry.Value.GetRawValue())
+			if err != nil {
+				return nil, fmt.Errorf("encode result of %q: %w", spec.LeafNodeText, err)
+			}
+			var result definitionResult
+			if err := json.Unmarshal(raw, &result); err != nil {
+				return nil, fmt.Errorf("decode result of %q: %w", spec.LeafNodeText, err)
+			}
+
+			file := strings.TrimPrefix(spec.LeafNodeText, "should run ")
+			summary.Definitions = append(summary.Definitions, definitionSummary{
+				Definition:       strings.TrimSuffix(file, filepath.Ext(file)),
+				Type:             definitionTypeFromLabels(spec.Labels()),
+				File:             file,
+				Status:           spec.State.String(),
+				DurationSeconds:  spec.RunTime.Seconds(),
+				Attempts:         spec.NumAttempts,
+				FailureMessage:   spec.Failure.Message,
+				definitionResult: result,
+			})
+		}
+	}
+	return summary, nil
+}
+
+// writeSuiteSummary writes the per-definition JSON summary to dst.
+func writeSuiteSummary(report Report, dst string) error {
+	summary, err := buildSuiteSummary(report)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal summary: %w", err)
+	}
+	return os.WriteFile(dst, data, 0o644)
+}
+
+// Write the JUnit report and the JSON summary once all parallel processes have finished.
+var _ = ReportAfterSuite("machine-readable e2e report", func(report Report) {
+	dir := getReportDir()
+	if dir == "" {
+		return
+	}
+	Expect(os.MkdirAll(dir, 0o755)).To(Succeed(), "Failed to create report directory %s", dir)
+
+	junitPath := filepath.Join(dir, junitReportFile)
+	Expect(reporters.GenerateJUnitReportWithConfig(report, junitPath, reporters.JunitReportConfig{
+		OmitTimelinesForSpecState: ginkgotypes.SpecStatePassed | ginkgotypes.SpecStateSkipped,
+	})).To(Succeed(), "Failed to write JUnit report")
+
+	summaryPath := filepath.Join(dir, summaryReportFile)
+	Expect(writeSuiteSummary(report, summaryPath)).To(Succeed(), "Failed to write JSON summary")
+
+	fmt.Fprintf(os.Stdout, "E2E reports written to %s and %s\n", junitPath, summaryPath)
+})
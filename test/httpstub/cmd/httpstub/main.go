@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package main runs the httpstub server in-cluster for e2e tests.
+//
+// Usage:
+//
+//	httpstub [--listen :8080] [--config /etc/httpstub/config.json]
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/oam-dev/vela-go-definitions/test/httpstub"
+)
+
+func main() {
+	listen := flag.String("listen", ":8080", "address to listen on")
+	configPath := flag.String("config", "", "path to a JSON httpstub.Config (optional)")
+	flag.Parse()
+
+	var cfg httpstub.Config
+	if *configPath != "" {
+		var err error
+		if cfg, err = httpstub.LoadConfig(*configPath); err != nil {
+			log.Fatalf("failed to load config: %v", err)
+		}
+	}
+
+	log.Printf("httpstub listening on %s with %d route(s)", *listen, len(cfg.Routes))
+	server := &http.Server{
+		Addr:              *listen,
+		Handler:           httpstub.NewServer(cfg),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if err := server.ListenAndServe(); err != nil {
+		log.Print(err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package httpstub is a local stand-in for the HTTP services that network-dependent
+// workflow steps (webhook, request, notification, check-metrics) talk to.
+//
+// A Server records every request it receives and answers with scripted responses,
+// including failures and delays. Routes can mimic the Slack, DingTalk and Lark
+// webhook APIs and the Prometheus instant query API.
+//
+// The server runs in-process (Server.Start) or in-cluster (cmd/httpstub, configured
+// from a JSON file). Recorded requests are served at GET /_stub/requests so e2e
+// tests can assert on what each step sent.
+package httpstub
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// AdminPrefix is the path prefix of the stub's own endpoints. Requests to it are not recorded.
+	AdminPrefix = "/_stub/"
+	// RequestsPath returns (GET) or clears (DELETE) the recorded requests.
+	RequestsPath = AdminPrefix + "requests"
+	// HealthzPath answers 200 once the server is serving.
+	HealthzPath = AdminPrefix + "healthz"
+)
+
+// Config is the scripted behaviour of a Server.
+type Config struct {
+	// Routes are matched in order; the first match answers the request.
+	// Requests that match no route get 200 "ok".
+	Routes []Route `json:"routes,omitempty"`
+}
+
+// Route matches requests and answers them with scripted responses.
+type Route struct {
+	// Method matches the HTTP method; empty matches any method.
+	Method string `json:"method,omitempty"`
+	// Path matches the request path exactly; a trailing "*" matches by prefix.
+	// Empty matches any path, or the mimicked API's path (e.g. /api/v1/query for prometheus).
+	Path string `json:"path,omitempty"`
+	// Mimic answers like the named API when a response does not set its own status and body.
+	Mimic Mimic `json:"mimic,omitempty"`
+	// Value is the sample value returned by the prometheus mimic (default "1").
+	Value string `json:"value,omitempty"`
+	// Responses are served in order; the last one repeats once the script is exhausted.
+	Responses []Response `json:"responses,omitempty"`
+}
+
+// Response is one scripted answer.
+type Response struct {
+	// Status is the HTTP status code. When Status and Body are both empty,
+	// the route's mimic (or 200 "ok") answers after Delay.
+	Status  int               `json:"status,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+	// Delay is waited before answering, e.g. "2s".
+	Delay Duration `json:"delay,omitempty"`
+}
+
+// Duration is a time.Duration that (un)marshals as a Go duration string.
+type Duration time.Duration
+
+// MarshalJSON encodes the duration as a string such as "1.5s".
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON accepts a duration string such as "500ms".
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"2s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// RecordedRequest is a request received by the server.
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	Path    string      `json:"path"`
+	Query   string      `json:"query,omitempty"`
+	Headers http.Header `json:"headers"`
+	Body    string      `json:"body"`
+	Time    time.Time   `json:"time"`
+}
+
+// routeState tracks how far a route's response script has progressed.
+type routeState struct {
+	Route
+	served int
+}
+
+// Server records requests and answers them according to its Config.
+type Server struct {
+	mu       sync.Mutex
+	routes   []*routeState
+	requests []RecordedRequest
+
+	httpServer *http.Server
+}
+
+// NewServer creates a Server with the given scripted behaviour.
+func NewServer(cfg Config) *Server {
+	s := &Server{}
+	for _, r := range cfg.Routes {
+		s.routes = append(s.routes, &routeState{Route: r})
+	}
+	return s
+}
+
+// LoadConfig reads a JSON Config from path.
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Requests returns a copy of the recorded requests, oldest first.
+func (s *Server) Requests() []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]RecordedRequest, len(s.requests))
+	copy(out, s.requests)
+	return out
+}
+
+// Reset clears the recorded requests and restarts every response script.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+	for _, r := range s.routes {
+		r.served = 0
+	}
+}
+
+// Start serves on addr (e.g. "127.0.0.1:0") in the background and returns the base URL.
+func (s *Server) Start(addr string) (string, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", err
+	}
+	s.httpServer = &http.Server{Handler: s, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = s.httpServer.Serve(ln) }()
+	return "http://" + ln.Addr().String(), nil
+}
+
+// Close stops a server started with Start.
+func (s *Server) Close() error {
+	if s.httpServer == nil {
+		return nil
+	}
+	return s.httpServer.Close()
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, AdminPrefix) {
+		s.serveAdmin(w, r)
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	req := RecordedRequest{
+		Method:  r.Method,
+		Path:    r.URL.Path,
+		Query:   r.URL.RawQuery,
+		Headers: r.Header.Clone(),
+		Body:    string(body),
+		Time:    time.Now(),
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	route := s.match(r.Method, r.URL.Path)
+	var resp Response
+	if route != nil && len(route.Responses) > 0 {
+		i := route.served
+		if i >= len(route.Responses) {
+			i = len(route.Responses) - 1
+		}
+		resp = route.Responses[i]
+		route.served++
+	}
+	s.mu.Unlock()
+
+	if resp.Delay > 0 {
+		select {
+		case <-time.After(time.Duration(resp.Delay)):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if resp.Status == 0 && resp.Body == "" {
+		var mimic Mimic
+		var value string
+		if route != nil {
+			mimic, value = route.Mimic, route.Value
+		}
+		resp = mimicResponse(mimic, value, req, resp.Headers)
+	}
+	writeResponse(w, resp)
+}
+
+// match returns the first route matching method and path. Callers hold s.mu.
+func (s *Server) match(method, path string) *routeState {
+	for _, r := range s.routes {
+		if r.Method != "" && !strings.EqualFold(r.Method, method) {
+			continue
+		}
+		routePath := r.Path
+		if routePath == "" {
+			routePath = r.Mimic.defaultPath()
+		}
+		switch {
+		case routePath == "":
+		case strings.HasSuffix(routePath, "*"):
+			if !strings.HasPrefix(path, strings.TrimSuffix(routePath, "*")) {
+				continue
+			}
+		case routePath != path:
+			continue
+		}
+		return r
+	}
+	return nil
+}
+
+// serveAdmin answers the stub's own endpoints.
+func (s *Server) serveAdmin(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == HealthzPath:
+		writeResponse(w, Response{Status: http.StatusOK, Body: "ok"})
+	case r.URL.Path == RequestsPath && r.Method == http.MethodGet:
+		data, err := json.Marshal(s.Requests())
+		if err != nil {
+			writeResponse(w, Response{Status: http.StatusInternalServerError, Body: err.Error()})
+			return
+		}
+		writeResponse(w, Response{Status: http.StatusOK, Body: string(data), Headers: jsonHeaders})
+	case r.URL.Path == RequestsPath && r.Method == http.MethodDelete:
+		s.Reset()
+		writeResponse(w, Response{Status: http.StatusNoContent})
+	default:
+		writeResponse(w, Response{Status: http.StatusNotFound, Body: "unknown stub endpoint"})
+	}
+}
+
+// jsonHeaders is the header set of JSON responses.
+var jsonHeaders = map[string]string{"Content-Type": "application/json"}
+
+// writeResponse writes resp, defaulting the status to 200.
+func writeResponse(w http.ResponseWriter, resp Response) {
+	for k, v := range resp.Headers {
+		w.Header().Set(k, v)
+	}
+	status := resp.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, resp.Body)
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpstub_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/vela-go-definitions/test/httpstub"
+)
+
+// do sends a request and returns the status code and body.
+func do(method, url, contentType, body string) (int, string) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	Expect(err).NotTo(HaveOccurred())
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	Expect(err).NotTo(HaveOccurred())
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	Expect(err).NotTo(HaveOccurred())
+	return resp.StatusCode, string(data)
+}
+
+// start runs a server in-process and stops it when the spec ends.
+func start(cfg httpstub.Config) (*httpstub.Server, string) {
+	server := httpstub.NewServer(cfg)
+	base, err := server.Start("127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	DeferCleanup(server.Close)
+	return server, base
+}
+
+var _ = Describe("Server", func() {
+	Describe("recording", func() {
+		It("should record method, path, query, headers and body", func() {
+			server, base := start(httpstub.Config{})
+
+			status, body := do(http.MethodPost, base+"/hook?x=1", "application/json", `{"a":1}`)
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(body).To(Equal("ok"))
+
+			requests := server.Requests()
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].Method).To(Equal(http.MethodPost))
+			Expect(requests[0].Path).To(Equal("/hook"))
+			Expect(requests[0].Query).To(Equal("x=1"))
+			Expect(requests[0].Headers.Get("Content-Type")).To(Equal("application/json"))
+			Expect(requests[0].Body).To(Equal(`{"a":1}`))
+		})
+
+		It("should serve recorded requests as JSON and not record admin calls", func() {
+			_, base := start(httpstub.Config{})
+			do(http.MethodPut, base+"/a", "", "one")
+
+			status, body := do(http.MethodGet, base+httpstub.RequestsPath, "", "")
+			Expect(status).To(Equal(http.StatusOK))
+			var requests []httpstub.RecordedRequest
+			Expect(json.Unmarshal([]byte(body), &requests)).To(Succeed())
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].Body).To(Equal("one"))
+		})
+
+		It("should clear recorded requests on DELETE", func() {
+			server, base := start(httpstub.Config{})
+			do(http.MethodPost, base+"/a", "", "")
+
+			status, _ := do(http.MethodDelete, base+httpstub.RequestsPath, "", "")
+			Expect(status).To(Equal(http.StatusNoContent))
+			Expect(server.Requests()).To(BeEmpty())
+		})
+	})
+
+	Describe("scripted responses", func() {
+		It("should match routes by method and exact or prefix path", func() {
+			_, base := start(httpstub.Config{Routes: []httpstub.Route{
+				{Method: http.MethodGet, Path: "/exact", Responses: []httpstub.Response{{Status: 201, Body: "exact"}}},
+				{Path: "/prefix/*", Responses: []httpstub.Response{{Status: 202, Body: "prefix"}}},
+			}})
+
+			status, body := do(http.MethodGet, base+"/exact", "", "")
+			Expect(status).To(Equal(201))
+			Expect(body).To(Equal("exact"))
+
+			status, _ = do(http.MethodPost, base+"/exact", "", "")
+			Expect(status).To(Equal(http.StatusOK), "method mismatch falls through to the default")
+
+			status, body = do(http.MethodDelete, base+"/prefix/x/y", "", "")
+			Expect(status).To(Equal(202))
+			Expect(body).To(Equal("prefix"))
+		})
+
+		It("should serve responses in order and repeat the last one", func() {
+			_, base := start(httpstub.Config{Routes: []httpstub.Route{{
+				Responses: []httpstub.Response{
+					{Status: http.StatusServiceUnavailable, Body: "down"},
+					{Status: http.StatusOK, Body: "up"},
+				},
+			}}})
+
+			statuses := []int{}
+			for i := 0; i < 3; i++ {
+				status, _ := do(http.MethodGet, base+"/", "", "")
+				statuses = append(statuses, status)
+			}
+			Expect(statuses).To(Equal([]int{http.StatusServiceUnavailable, http.StatusOK, http.StatusOK}))
+		})
+
+		It("should set scripted headers", func() {
+			_, base := start(httpstub.Config{Routes: []httpstub.Route{{
+				Responses: []httpstub.Response{{Body: "{}", Headers: map[string]string{"X-Stub": "yes"}}},
+			}}})
+			resp, err := http.Get(base + "/")
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.Header.Get("X-Stub")).To(Equal("yes"))
+		})
+
+		It("should delay responses", func() {
+			cfg := httpstub.Config{}
+			Expect(json.Unmarshal([]byte(`{"routes":[{"responses":[{"delay":"200ms"}]}]}`), &cfg)).To(Succeed())
+			_, base := start(cfg)
+
+			begin := time.Now()
+			status, _ := do(http.MethodGet, base+"/", "", "")
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(time.Since(begin)).To(BeNumerically(">=", 200*time.Millisecond))
+		})
+
+		It("should reject invalid delays", func() {
+			cfg := httpstub.Config{}
+			Expect(json.Unmarshal([]byte(`{"routes":[{"responses":[{"delay":"soon"}]}]}`), &cfg)).NotTo(Succeed())
+		})
+	})
+
+	Describe("mimics", func() {
+		It("should answer like a Slack webhook", func() {
+			_, base := start(httpstub.Config{Routes: []httpstub.Route{{Mimic: httpstub.MimicSlack}}})
+
+			status, body := do(http.MethodPost, base+"/services/T/B/X", "application/json", `{"text":"hi"}`)
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(body).To(Equal("ok"))
+
+			status, body = do(http.MethodPost, base+"/services/T/B/X", "application/json", `{}`)
+			Expect(status).To(Equal(http.StatusBadRequest))
+			Expect(body).To(Equal("no_text"))
+
+			status, body = do(http.MethodPost, base+"/services/T/B/X", "text/plain", `not json`)
+			Expect(status).To(Equal(http.StatusBadRequest))
+			Expect(body).To(Equal("invalid_payload"))
+		})
+
+		It("should answer like a DingTalk robot", func() {
+			_, base := start(httpstub.Config{Routes: []httpstub.Route{{Mimic: httpstub.MimicDingTalk}}})
+
+			_, body := do(http.MethodPost, base+"/robot/send", "application/json", `{"msgtype":"text","text":{"content":"hi"}}`)
+			Expect(body).To(MatchJSON(`{"errcode":0,"errmsg":"ok"}`))
+
+			_, body = do(http.MethodPost, base+"/robot/send", "application/json", `{}`)
+			Expect(body).To(ContainSubstring(`"errcode":40035`))
+		})
+
+		It("should answer like a Lark bot", func() {
+			_, base := start(httpstub.Config{Routes: []httpstub.Route{{Mimic: httpstub.MimicLark}}})
+
+			_, body := do(http.MethodPost, base+"/open-apis/bot/v2/hook/x", "application/json", `{"msg_type":"text","content":{"text":"hi"}}`)
+			Expect(body).To(ContainSubstring(`"code":0`))
+
+			_, body = do(http.MethodPost, base+"/open-apis/bot/v2/hook/x", "application/json", `{}`)
+			Expect(body).To(ContainSubstring(`"code":9499`))
+		})
+
+		It("should answer Prometheus instant queries via GET and POST", func() {
+			_, base := start(httpstub.Config{Routes: []httpstub.Route{{Mimic: httpstub.MimicPrometheus, Value: "0.99"}}})
+
+			for _, send := range []func() (int, string){
+				func() (int, string) {
+					return do(http.MethodGet, base+"/api/v1/query?query="+url.QueryEscape(`up{job="x"}`), "", "")
+				},
+				func() (int, string) {
+					return do(http.MethodPost, base+"/api/v1/query", "application/x-www-form-urlencoded", "query="+url.QueryEscape(`up{job="x"}`))
+				},
+			} {
+				status, body := send()
+				Expect(status).To(Equal(http.StatusOK))
+				var result struct {
+					Status string `json:"status"`
+					Data   struct {
+						ResultType string `json:"resultType"`
+						Result     []struct {
+							Metric map[string]string `json:"metric"`
+							Value  []interface{}     `json:"value"`
+						} `json:"result"`
+					} `json:"data"`
+				}
+				Expect(json.Unmarshal([]byte(body), &result)).To(Succeed())
+				Expect(result.Status).To(Equal("success"))
+				Expect(result.Data.ResultType).To(Equal("vector"))
+				Expect(result.Data.Result).To(HaveLen(1))
+				Expect(result.Data.Result[0].Metric["query"]).To(Equal(`up{job="x"}`))
+				Expect(result.Data.Result[0].Value[1]).To(Equal("0.99"))
+			}
+		})
+
+		It("should only route the Prometheus mimic on the query path by default", func() {
+			_, base := start(httpstub.Config{Routes: []httpstub.Route{{Mimic: httpstub.MimicPrometheus}}})
+			_, body := do(http.MethodGet, base+"/-/healthy", "", "")
+			Expect(body).To(Equal("ok"))
+
+			status, _ := do(http.MethodGet, base+"/api/v1/query", "", "")
+			Expect(status).To(Equal(http.StatusBadRequest), "empty query is rejected like Prometheus does")
+		})
+
+		It("should apply delays to mimicked responses", func() {
+			_, base := start(httpstub.Config{Routes: []httpstub.Route{{
+				Mimic:     httpstub.MimicSlack,
+				Responses: []httpstub.Response{{Delay: httpstub.Duration(100 * time.Millisecond)}},
+			}}})
+			begin := time.Now()
+			_, body := do(http.MethodPost, base+"/", "application/json", `{"text":"hi"}`)
+			Expect(body).To(Equal("ok"))
+			Expect(time.Since(begin)).To(BeNumerically(">=", 100*time.Millisecond))
+		})
+	})
+})
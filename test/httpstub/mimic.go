@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpstub
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Mimic names an API whose responses a route imitates.
+type Mimic string
+
+const (
+	// MimicSlack answers like a Slack incoming webhook: "ok", or 400 for invalid payloads.
+	MimicSlack Mimic = "slack"
+	// MimicDingTalk answers like a DingTalk robot webhook: {"errcode":0,"errmsg":"ok"}.
+	MimicDingTalk Mimic = "dingtalk"
+	// MimicLark answers like a Lark (Feishu) bot webhook: {"code":0,"msg":"success"}.
+	MimicLark Mimic = "lark"
+	// MimicPrometheus answers /api/v1/query with a single-sample vector.
+	MimicPrometheus Mimic = "prometheus"
+)
+
+// prometheusQueryPath is the Prometheus instant query endpoint.
+const prometheusQueryPath = "/api/v1/query"
+
+// defaultPath is the path a mimic route matches when it sets none.
+func (m Mimic) defaultPath() string {
+	if m == MimicPrometheus {
+		return prometheusQueryPath
+	}
+	return ""
+}
+
+// mimicResponse builds the answer of the given API to req. Unknown or empty
+// mimics answer 200 "ok".
+func mimicResponse(m Mimic, value string, req RecordedRequest, headers map[string]string) Response {
+	resp := func(status int, body string, contentType string) Response {
+		h := map[string]string{"Content-Type": contentType}
+		for k, v := range headers {
+			h[k] = v
+		}
+		return Response{Status: status, Body: body, Headers: h}
+	}
+
+	switch m {
+	case MimicSlack:
+		var payload map[string]interface{}
+		if err := json.Unmarshal([]byte(req.Body), &payload); err != nil {
+			return resp(http.StatusBadRequest, "invalid_payload", "text/plain")
+		}
+		if payload["text"] == nil && payload["blocks"] == nil && payload["attachments"] == nil {
+			return resp(http.StatusBadRequest, "no_text", "text/plain")
+		}
+		return resp(http.StatusOK, "ok", "text/plain")
+
+	case MimicDingTalk:
+		var payload map[string]interface{}
+		if err := json.Unmarshal([]byte(req.Body), &payload); err != nil || payload["msgtype"] == nil {
+			return resp(http.StatusOK, `{"errcode":40035,"errmsg":"missing msgtype"}`, "application/json")
+		}
+		return resp(http.StatusOK, `{"errcode":0,"errmsg":"ok"}`, "application/json")
+
+	case MimicLark:
+		var payload map[string]interface{}
+		if err := json.Unmarshal([]byte(req.Body), &payload); err != nil || payload["msg_type"] == nil {
+			return resp(http.StatusOK, `{"code":9499,"msg":"Bad Request","data":{}}`, "application/json")
+		}
+		return resp(http.StatusOK, `{"StatusCode":0,"StatusMessage":"success","code":0,"msg":"success","data":{}}`, "application/json")
+
+	case MimicPrometheus:
+		query := prometheusQuery(req)
+		if query == "" {
+			return resp(http.StatusBadRequest,
+				`{"status":"error","errorType":"bad_data","error":"invalid parameter \"query\": empty query"}`, "application/json")
+		}
+		if value == "" {
+			value = "1"
+		}
+		body, _ := json.Marshal(map[string]interface{}{
+			"status": "success",
+			"data": map[string]interface{}{
+				"resultType": "vector",
+				"result": []interface{}{
+					map[string]interface{}{
+						"metric": map[string]string{"__name__": "stub", "query": query},
+						"value":  []interface{}{float64(time.Now().Unix()), value},
+					},
+				},
+			},
+		})
+		return resp(http.StatusOK, string(body), "application/json")
+
+	default:
+		return resp(http.StatusOK, "ok", "text/plain")
+	}
+}
+
+// prometheusQuery extracts the query parameter from a GET query string or a form-encoded POST body.
+func prometheusQuery(req RecordedRequest) string {
+	if values, err := url.ParseQuery(req.Query); err == nil && values.Get("query") != "" {
+		return values.Get("query")
+	}
+	if values, err := url.ParseQuery(req.Body); err == nil {
+		return values.Get("query")
+	}
+	return ""
+}
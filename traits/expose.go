@@ -17,6 +17,8 @@ limitations under the License.
 package traits
 
 import (
+	"github.com/oam-dev/vela-go-definitions/components"
+
 	"github.com/oam-dev/kubevela/pkg/definition/defkit"
 )
 
@@ -35,9 +37,10 @@ func Expose() *defkit.TraitDefinition {
 		defkit.String("name").Optional().Description("Name of the port"),
 		defkit.String("protocol").Default("TCP").Values("TCP", "UDP", "SCTP").Description("Protocol for port. Must be UDP, TCP, or SCTP"),
 		defkit.Int("nodePort").Optional().Description("exposed node port. Only Valid when exposeType is NodePort"),
+		defkit.String("appProtocol").Optional().Description("Application protocol of the port, e.g. http, https or kubernetes.io/h2c"),
 	).Description("Specify portsyou want customer traffic sent to")
 
-	annotations := defkit.Map("annotations").Of(defkit.ParamTypeString).Description("Specify the annotations of the exposed service")
+	annotations := defkit.Map("annotations").Of(defkit.ParamTypeString).Description("Specify the annotations of the exposed service, service.annotations take precedence on the same key")
 	matchLabels := defkit.Map("matchLabels").Of(defkit.ParamTypeString).Optional()
 	serviceType := defkit.String("type").Default("ClusterIP").Values("ClusterIP", "NodePort", "LoadBalancer", "ExternalName").Description(`Specify what kind of Service you want. options: "ClusterIP","NodePort","LoadBalancer","ExternalName"`)
 
@@ -72,13 +75,17 @@ if service.spec.type == "LoadBalancer" {
 if service.spec.type != "LoadBalancer" {
 	isHealth: true
 }`).
-		Params(port, ports, annotations, matchLabels, serviceType).
+		Params(port, ports, annotations, matchLabels, serviceType, components.ServiceOptionsParam()).
 		Template(func(tpl *defkit.Template) {
 			tpl.SetRawOutputsBlock(`outputs: service: {
 	apiVersion: "v1"
 	kind:       "Service"
-	metadata: name:        context.name
-	metadata: annotations: parameter.annotations
+	metadata: name: context.name
+	// service.annotations take precedence over annotations on the same key
+	metadata: annotations: {
+		for k, v in parameter.annotations if parameter.service.annotations[k] == _|_ {(k): v}
+		if parameter.service.annotations != _|_ {parameter.service.annotations}
+	}
 	spec: {
 		if parameter["matchLabels"] == _|_ {
 			selector: "app.oam.dev/component": context.name
@@ -117,10 +124,14 @@ if service.spec.type != "LoadBalancer" {
 				if v.protocol != _|_ {
 					protocol: v.protocol
 				}
+				if v.appProtocol != _|_ {
+					appProtocol: v.appProtocol
+				}
 			},
 			]
 		}
 		type: parameter.type
+` + components.ServiceOptionsCUE("\t\t", "parameter.type") + `
 	}
 }`)
 		})
@@ -17,6 +17,7 @@ limitations under the License.
 package traits_test
 
 import (
+	"cuelang.org/go/cue"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -49,7 +50,7 @@ var _ = Describe("Expose Trait", func() {
 		// Output resource
 		Expect(cue).To(ContainSubstring(`outputs: service:`))
 		Expect(cue).To(ContainSubstring(`kind:       "Service"`))
-		Expect(cue).To(ContainSubstring(`metadata: name:        context.name`))
+		Expect(cue).To(ContainSubstring(`metadata: name: context.name`))
 
 		// Dual-path port handling (legacy vs modern)
 		Expect(cue).To(ContainSubstring(`if parameter["port"] != _|_`))
@@ -64,4 +65,48 @@ var _ = Describe("Expose Trait", func() {
 		Expect(cue).To(ContainSubstring(`matchLabels?: [string]:`))
 		Expect(cue).To(ContainSubstring(`*"ClusterIP"`))
 	})
+
+	It("should pass the Service options and the port appProtocol through", func() {
+		cue := traits.Expose().ToCue()
+
+		Expect(cue).To(ContainSubstring(`service?: {`))
+		Expect(cue).To(ContainSubstring(`clusterIP?: string`))
+		Expect(cue).To(ContainSubstring(`sessionAffinity?: "None" | "ClientIP"`))
+		Expect(cue).To(ContainSubstring(`externalTrafficPolicy?: "Cluster" | "Local"`))
+		Expect(cue).To(ContainSubstring(`loadBalancerSourceRanges?: [...string]`))
+		Expect(cue).To(ContainSubstring(`ipFamilyPolicy?: "SingleStack" | "PreferDualStack" | "RequireDualStack"`))
+		Expect(cue).To(ContainSubstring(`ipFamilies?: [..."IPv4" | "IPv6"]`))
+		Expect(cue).To(ContainSubstring(`appProtocol: v.appProtocol`))
+		Expect(cue).To(ContainSubstring(`parameter.service.externalTrafficPolicy][0]`))
+		Expect(cue).To(ContainSubstring(`ipFamilies: parameter.service.ipFamilies`))
+	})
+
+	It("should add the Service annotations to the top-level annotations", func() {
+		cue := traits.Expose().ToCue()
+
+		Expect(cue).To(ContainSubstring("annotations?: [string]: string"))
+		Expect(cue).To(ContainSubstring("if parameter.service.annotations != _|_ {parameter.service.annotations}"))
+	})
+
+	Describe("rendering", func() {
+		ctx := `{name: "api"}`
+
+		It("should let service.annotations take precedence over annotations", func() {
+			v := render(traits.Expose(), ctx, `{ports: [{port: 80}], annotations: {team: "a", tier: "web"}, service: annotations: tier: "edge"}`)
+			Expect(lookup(v, "outputs.service.metadata.annotations.team")).To(Equal("a"))
+			Expect(lookup(v, "outputs.service.metadata.annotations.tier")).To(Equal("edge"))
+		})
+
+		It("should check the Service options against the type", func() {
+			v := render(traits.Expose(), ctx, `{ports: [{port: 80}], type: "LoadBalancer", service: externalTrafficPolicy: "Local"}`)
+			Expect(lookup(v, "outputs.service.spec.externalTrafficPolicy")).To(Equal("Local"))
+			v = render(traits.Expose(), ctx, `{ports: [{port: 80}], service: clusterIP: "None"}`)
+			Expect(lookup(v, "outputs.service.spec.clusterIP")).To(Equal("None"))
+
+			err := renderTemplate(traits.Expose(), ctx, `{ports: [{port: 80}], service: externalTrafficPolicy: "Local"}`).Validate(cue.Concrete(true))
+			Expect(err).To(MatchError(ContainSubstring("service.externalTrafficPolicy is only valid for a NodePort or LoadBalancer Service")))
+			err = renderTemplate(traits.Expose(), ctx, `{ports: [{port: 80}], type: "NodePort", service: clusterIP: "None"}`).Validate(cue.Concrete(true))
+			Expect(err).To(MatchError(ContainSubstring("service.clusterIP None is only valid for a ClusterIP Service")))
+		})
+	})
 })
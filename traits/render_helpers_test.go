@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The KubeVela Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package traits_test
+
+import (
+	"regexp"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	. "github.com/onsi/gomega"
+
+	"github.com/oam-dev/kubevela/pkg/definition/defkit"
+)
+
+// renderTemplate evaluates the template of the generated definition of def,
+// with its imports, in the context ctx with the parameters params, both CUE
+// structs. It is not validated, so that invalid parameters can be checked for
+// the errors they render.
+func renderTemplate(def *defkit.TraitDefinition, ctx, params string) cue.Value {
+	definition := def.ToCue()
+	header := regexp.MustCompile(`(?m)^"?` + regexp.QuoteMeta(def.GetName()) + `"?: \{`).FindStringIndex(definition)
+	Expect(header).NotTo(BeNil())
+	src := definition[:header[0]] +
+		definition[strings.Index(definition, "\ntemplate: {"):] +
+		"\ncontext: " + ctx + "\ntemplate: parameter: " + params
+	return cuecontext.New().CompileString(src).LookupPath(cue.ParsePath("template"))
+}
+
+// render evaluates the template of def like renderTemplate, and checks that
+// it is concrete.
+func render(def *defkit.TraitDefinition, ctx, params string) cue.Value {
+	v := renderTemplate(def, ctx, params)
+	Expect(v.Validate(cue.Concrete(true))).To(Succeed())
+	return v
+}
+
+// lookup returns the string at path in v, or its default.
+func lookup(v cue.Value, path string) string {
+	f := v.LookupPath(cue.ParsePath(path))
+	Expect(f.Validate(cue.Concrete(true))).To(Succeed())
+	f, _ = f.Default()
+	s, err := f.String()
+	Expect(err).NotTo(HaveOccurred())
+	return s
+}
@@ -0,0 +1,185 @@
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+"batch-pipeline": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Describes a DAG of batch steps run by Argo Workflows as a Workflow or a WorkflowTemplate."
+	attributes: {
+		workload: type: "autodetects.core.oam.dev"
+		status: {
+			customStatus: #"""
+				_st: {
+					phase:    *"Pending" | string
+					progress: *"-" | string
+					message:  *"" | string
+					...
+				}
+				if context.output.status != _|_ {
+					_st: context.output.status
+				}
+				message: *"" | string
+				if context.output.kind == "WorkflowTemplate" {
+					message: "Steps:\(len(context.output.spec.templates)-1)"
+				}
+				if context.output.kind == "Workflow" && _st.message == "" {
+					message: "Phase:\(_st.phase), progress:\(_st.progress)"
+				}
+				if context.output.kind == "Workflow" && _st.message != "" {
+					message: "Phase:\(_st.phase), progress:\(_st.progress), message:\(_st.message)"
+				}
+				"""#
+			healthPolicy: #"""
+				_st: {
+					phase:    *"Pending" | string
+					progress: *"-" | string
+					message:  *"" | string
+					...
+				}
+				if context.output.status != _|_ {
+					_st: context.output.status
+				}
+				isHealth: context.output.kind == "WorkflowTemplate" || _st.phase == "Succeeded"
+				"""#
+		}
+	}
+}
+template: {
+	_stepNames: {for s in parameter.steps {(s.name): _}}
+
+	_spec: {
+		entrypoint: "main"
+		if parameter["serviceAccountName"] != _|_ {serviceAccountName: parameter.serviceAccountName}
+		if parameter["parallelism"] != _|_ {parallelism: parameter.parallelism}
+		if parameter["activeDeadlineSeconds"] != _|_ {activeDeadlineSeconds: parameter.activeDeadlineSeconds}
+		if parameter["podGC"] != _|_ {podGC: strategy: parameter.podGC}
+		if parameter["artifactRepositoryRef"] != _|_ {
+			artifactRepositoryRef: {
+				configMap: parameter.artifactRepositoryRef.configMap
+				if parameter.artifactRepositoryRef.key != _|_ {key: parameter.artifactRepositoryRef.key}
+			}
+		}
+		templates: [{
+			name: "main"
+			dag: tasks: [for i, s in parameter.steps {
+				_deps: {
+					if s.dependencies != _|_ for d in s.dependencies {(d): _}
+					if s.inputArtifacts != _|_ for a in s.inputArtifacts {(strings.Split(a.from, ".")[0]): _}
+				}
+				_unknown: [for d, _ in _deps if _stepNames[d] == _|_ {d}]
+				if len([for j, t in parameter.steps if j < i && t.name == s.name {j}]) > 0 {
+					name: error("steps[\(i)].name \(s.name) is used by another step")
+				}
+				if len(_unknown) > 0 {
+					dependencies: error("steps[\(i)] depends on unknown steps \(strings.Join(_unknown, ", "))")
+				}
+				name:     s.name
+				template: "step-\(s.name)"
+				if len(_deps) > 0 {dependencies: [for d, _ in _deps {d}]}
+				if s.inputArtifacts != _|_ {
+					arguments: artifacts: [for a in s.inputArtifacts {
+						_from: strings.Split(a.from, ".")
+						name:  a.name
+						from:  "{{tasks.\(_from[0]).outputs.artifacts.\(_from[1])}}"
+					}]
+				}
+			}]
+		}, for s in parameter.steps {
+			name: "step-\(s.name)"
+			container: {
+				image: s.image
+				if s.command != _|_ {command: s.command}
+				if s.args != _|_ {args: s.args}
+				if s.env != _|_ {env: s.env}
+				if s.resources != _|_ {resources: s.resources}
+			}
+			if s.retries > 0 {retryStrategy: {limit: s.retries, retryPolicy: s.retryPolicy}}
+			if s.inputArtifacts != _|_ {inputs: artifacts: [for a in s.inputArtifacts {name: a.name, path: a.path}]}
+			if s.outputArtifacts != _|_ {outputs: artifacts: s.outputArtifacts}
+		}]
+	}
+
+	_name: [
+		if parameter.kind == "Workflow" {"\(context.name)-\(strings.SliceRunes(hex.Encode(sha256.Sum256(json.Marshal(_spec))), 0, 10))"},
+		context.name,
+	][0]
+
+	output: {
+		apiVersion: "argoproj.io/v1alpha1"
+		kind:       parameter.kind
+		metadata: name: _name
+		spec: _spec
+	}
+
+	parameter: {
+		// +usage=Run the pipeline once as a Workflow, or store it as a WorkflowTemplate to submit or schedule from Argo
+		kind: *"Workflow" | "WorkflowTemplate"
+		// +usage=Steps of the pipeline
+		steps: [...{
+			// +usage=Name of the step, unique in the pipeline
+			name: string & =~"^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+			// +usage=Image of the step
+			image: string
+			// +usage=Command of the container
+			command?: [...string]
+			// +usage=Arguments of the command
+			args?: [...string]
+			// +usage=Environment variables of the container
+			env?: [...{
+				// +usage=Name of the variable
+				name: string
+				// +usage=Value of the variable
+				value: string
+			}]
+			// +usage=Names of the steps that must succeed before this one
+			dependencies?: [...string]
+			// +usage=Number of times the step is retried
+			retries: *0 | int & >=0
+			// +usage=Failures the step is retried on: failed containers, Argo errors, or both with Always
+			retryPolicy: *"OnFailure" | "Always" | "OnError" | "OnTransientError"
+			// +usage=Artifacts of other steps read by the step
+			inputArtifacts?: [...{
+				// +usage=Name of the artifact in the step
+				name: string
+				// +usage=Path the artifact is written to in the container
+				path: string
+				// +usage=Output artifact read, as <step>.<artifact>
+				from: string & =~"^[a-z0-9]([-a-z0-9]*[a-z0-9])?\\.[-_a-zA-Z0-9]+$"
+			}]
+			// +usage=Artifacts saved by the step to the artifact repository
+			outputArtifacts?: [...{
+				// +usage=Name of the artifact
+				name: string
+				// +usage=Path of the file or directory saved in the container
+				path: string
+			}]
+			// +usage=Resources of the container
+			resources?: {
+				// +usage=Minimum resources, e.g. `cpu: "500m"`
+				requests?: {...}
+				// +usage=Maximum resources, e.g. `memory: "1Gi"`
+				limits?: {...}
+			}
+		}]
+		// +usage=ServiceAccount the steps run as
+		serviceAccountName?: string
+		// +usage=Maximum number of steps running at the same time
+		parallelism?: int & >=1
+		// +usage=Number of seconds after which the pipeline is failed
+		activeDeadlineSeconds?: int & >=1
+		// +usage=When the pods of the steps are deleted
+		podGC?: "OnPodCompletion" | "OnPodSuccess" | "OnWorkflowCompletion" | "OnWorkflowSuccess"
+		// +usage=Artifact repository of the artifacts, defaults to the repository of the namespace
+		artifactRepositoryRef?: {
+			// +usage=ConfigMap of the artifact repositories
+			configMap: *"artifact-repositories" | string
+			// +usage=Key of the repository in the ConfigMap, defaults to its default repository
+			key?: string
+		}
+	}
+}
@@ -0,0 +1,137 @@
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/yaml"
+	"strings"
+)
+
+"config-bundle": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Describes ConfigMaps and Secrets rendered from structured data, named after a hash of their content."
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "v1"
+				kind:       "ConfigMap"
+			}
+			type: "configmaps.v1"
+		}
+		status: {
+			customStatus: #"""
+				_configMaps: *0 | int
+				if parameter["configMaps"] != _|_ {
+					_configMaps: len(parameter.configMaps)
+				}
+				_secrets: *0 | int
+				if parameter["secrets"] != _|_ {
+					_secrets: len(parameter.secrets)
+				}
+				message: "ConfigMaps:\(_configMaps), Secrets:\(_secrets)"
+				"""#
+		}
+	}
+}
+template: {
+	_vars: {
+		appName:     *"" | string
+		appRevision: *"" | string
+		name:        *"" | string
+		namespace:   *"" | string
+		revision:    *"" | string
+		for k in ["appName", "appRevision", "name", "namespace", "revision"] if context[k] != _|_ {
+			(k): "\(context[k])"
+		}
+	}
+
+	_render: {
+		key:   string
+		value: _
+		_encoded: [
+			if (value & string) != _|_ {value},
+			if (value & number) != _|_ || (value & bool) != _|_ {"\(value)"},
+			if strings.HasSuffix(key, ".yaml") || strings.HasSuffix(key, ".yml") {yaml.Marshal(value)},
+			json.Marshal(value),
+		][0]
+		out: strings.Replace(strings.Replace(strings.Replace(strings.Replace(strings.Replace(_encoded,
+			"{{appName}}", _vars.appName, -1),
+			"{{appRevision}}", _vars.appRevision, -1),
+			"{{name}}", _vars.name, -1),
+			"{{namespace}}", _vars.namespace, -1),
+			"{{revision}}", _vars.revision, -1)
+	}
+
+	_bundle: {
+		configMaps: [if parameter["configMaps"] != _|_ for i, c in parameter.configMaps {
+			if len([for j, d in parameter.configMaps if j < i && d.name == c.name {j}]) > 0 {
+				key: error("configMaps[\(i)].name \(c.name) is used by another ConfigMap")
+			}
+			key: c.name
+			_data: {if c.data != _|_ for k, v in c.data {(k): (_render & {key: k, value: v}).out}}
+			_hash: hex.Encode(sha256.Sum256(json.Marshal({data: _data, immutable: c.immutable})))
+			generatedName: "\(c.name)-\(strings.SliceRunes(_hash, 0, 10))"
+			object: {
+				apiVersion: "v1"
+				kind:       "ConfigMap"
+				metadata: name: generatedName
+				data: _data
+				if c.immutable {immutable: true}
+			}
+		}]
+		secrets: [if parameter["secrets"] != _|_ for i, s in parameter.secrets {
+			if len([for j, d in parameter.secrets if j < i && d.name == s.name {j}]) > 0 {
+				key: error("secrets[\(i)].name \(s.name) is used by another Secret")
+			}
+			key: s.name
+			_data: {if s.data != _|_ for k, v in s.data {(k): (_render & {key: k, value: v}).out}}
+			_hash: hex.Encode(sha256.Sum256(json.Marshal({data: _data, immutable: s.immutable, type: s.type})))
+			generatedName: "\(s.name)-\(strings.SliceRunes(_hash, 0, 10))"
+			object: {
+				apiVersion: "v1"
+				kind:       "Secret"
+				metadata: name: generatedName
+				type:       s.type
+				stringData: _data
+				if s.immutable {immutable: true}
+			}
+		}]
+	}
+
+	outputs: {
+		for c in _bundle.configMaps {"configmap-\(c.key)": c.object}
+		for s in _bundle.secrets {"secret-\(s.key)": s.object}
+	}
+
+	output: {
+		apiVersion: "v1"
+		kind:       "ConfigMap"
+		metadata: {
+			name: context.name
+		}
+		data: {for c in _bundle.configMaps {"configmap.\(c.key)": c.generatedName}, for s in _bundle.secrets {"secret.\(s.key)": s.generatedName}}
+	}
+	parameter: {
+		// +usage=ConfigMaps to render
+		configMaps?: [...{
+			// +usage=Name of the object, suffixed with a hash of its content
+			name: string & =~"^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$"
+			// +usage=Data by key, such as a file name. Maps and lists are encoded as YAML under .yaml and .yml keys and as JSON under the others
+			data?: {[string]: string | number | bool | {...} | [...]}
+			// +usage=Forbid changes to the object, a change of the data creates a new object instead
+			immutable: *false | bool
+		}]
+		// +usage=Secrets to render
+		secrets?: [...{
+			// +usage=Name of the object, suffixed with a hash of its content
+			name: string & =~"^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$"
+			// +usage=Data by key, such as a file name. Maps and lists are encoded as YAML under .yaml and .yml keys and as JSON under the others
+			data?: {[string]: string | number | bool | {...} | [...]}
+			// +usage=Forbid changes to the object, a change of the data creates a new object instead
+			immutable: *false | bool
+			// +usage=Type of the Secret, e.g. kubernetes.io/tls
+			type: *"Opaque" | string
+		}]
+	}
+}
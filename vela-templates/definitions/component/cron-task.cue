@@ -1,5 +1,5 @@
 import (
-	"list"
+	"strings"
 )
 
 "cron-task": {
@@ -9,137 +9,170 @@ import (
 	description: "Describes cron jobs that run code or a script to completion."
 	attributes: {
 		workload: type: "autodetects.core.oam.dev"
+		status: {
+			customStatus: #"""
+				status: {
+					lastScheduleTime:   *"" | string
+					lastSuccessfulTime: *"" | string
+				} & {
+					if context.output.status.lastScheduleTime != _|_ {
+						lastScheduleTime: context.output.status.lastScheduleTime
+					}
+					if context.output.status.lastSuccessfulTime != _|_ {
+						lastSuccessfulTime: context.output.status.lastSuccessfulTime
+					}
+				}
+				_active: *0 | int
+				if context.output.status.active != _|_ {
+					_active: len(context.output.status.active)
+				}
+				_lastJob: *"none" | string
+				if _active > 0 {
+					_lastJob: "running"
+				}
+				if _active == 0 && status.lastScheduleTime != "" && status.lastSuccessfulTime >= status.lastScheduleTime {
+					_lastJob: "succeeded"
+				}
+				if _active == 0 && status.lastScheduleTime != "" && status.lastSuccessfulTime < status.lastScheduleTime {
+					_lastJob: "failed"
+				}
+				_schedule: *"never" | string
+				if status.lastScheduleTime != "" {
+					_schedule: status.lastScheduleTime
+				}
+				message: "Last schedule:\(_schedule), last job:\(_lastJob)"
+				"""#
+		}
 	}
 }
 template: {
-	mountsArray: {
-		pvc: *[
-			for v in parameter.volumeMounts.pvc {
-			{
-				mountPath: v.mountPath
-				name: v.name
-				if v.subPath != _|_ {
-					subPath: v.subPath
-				}
+	mountsArray: [
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.pvc != _|_ for v in parameter.volumeMounts.pvc {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
 			}
-			},
-		] | []
-
-		configMap: *[
-			for v in parameter.volumeMounts.configMap {
-			{
-				mountPath: v.mountPath
-				name: v.name
-				if v.subPath != _|_ {
-					subPath: v.subPath
-				}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
 			}
-			},
-		] | []
-
-		secret: *[
-			for v in parameter.volumeMounts.secret {
-			{
-				mountPath: v.mountPath
-				name: v.name
-				if v.subPath != _|_ {
-					subPath: v.subPath
-				}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
 			}
-			},
-		] | []
-
-		emptyDir: *[
-			for v in parameter.volumeMounts.emptyDir {
-			{
-				mountPath: v.mountPath
-				name: v.name
-				if v.subPath != _|_ {
-					subPath: v.subPath
-				}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.configMap != _|_ for v in parameter.volumeMounts.configMap {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
 			}
-			},
-		] | []
-
-		hostPath: *[
-			for v in parameter.volumeMounts.hostPath {
-			{
-				mountPath: v.mountPath
-				name: v.name
-				if v.subPath != _|_ {
-					subPath: v.subPath
-				}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
 			}
-			},
-		] | []
-
-	}
-	volumesArray: {
-		pvc: *[
-			for v in parameter.volumeMounts.pvc {
-			{
-				name: v.name
-				persistentVolumeClaim: {
-					claimName: v.claimName
-				}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
 			}
-			},
-		] | []
-
-		configMap: *[
-			for v in parameter.volumeMounts.configMap {
-			{
-				configMap: {
-					defaultMode: v.defaultMode
-					if v.items != _|_ {
-						items: v.items
-					}
-					name: v.cmName
-				}
-				name: v.name
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.secret != _|_ for v in parameter.volumeMounts.secret {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
 			}
-			},
-		] | []
-
-		secret: *[
-			for v in parameter.volumeMounts.secret {
-			{
-				name: v.name
-				secret: {
-					defaultMode: v.defaultMode
-					if v.items != _|_ {
-						items: v.items
-					}
-					secretName: v.secretName
-				}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
 			}
-			},
-		] | []
-
-		emptyDir: *[
-			for v in parameter.volumeMounts.emptyDir {
-			{
-				emptyDir: {
-					medium: v.medium
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.emptyDir != _|_ for v in parameter.volumeMounts.emptyDir {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.hostPath != _|_ for v in parameter.volumeMounts.hostPath {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
+		}
+		}
+	]
+	volumesList: [
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.pvc != _|_ for v in parameter.volumeMounts.pvc {
+		{
+			name: v.name
+			persistentVolumeClaim: {
+				claimName: v.claimName
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.configMap != _|_ for v in parameter.volumeMounts.configMap {
+		{
+			configMap: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
 				}
-				name: v.name
+				name: v.cmName
 			}
-			},
-		] | []
-
-		hostPath: *[
-			for v in parameter.volumeMounts.hostPath {
-			{
-				hostPath: {
-					path: v.path
+			name: v.name
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.secret != _|_ for v in parameter.volumeMounts.secret {
+		{
+			name: v.name
+			secret: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
 				}
-				name: v.name
+				secretName: v.secretName
 			}
-			},
-		] | []
-
-	}
-	volumesList: list.Concat([volumesArray.pvc, volumesArray.configMap, volumesArray.secret, volumesArray.emptyDir, volumesArray.hostPath])
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.emptyDir != _|_ for v in parameter.volumeMounts.emptyDir {
+		{
+			emptyDir: {
+				medium: v.medium
+			}
+			name: v.name
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.hostPath != _|_ for v in parameter.volumeMounts.hostPath {
+		{
+			hostPath: {
+				path: v.path
+			}
+			name: v.name
+		}
+		}
+	]
 	deDupVolumesArray: [
 		for val in [
 			for i, vi in volumesList {
@@ -180,9 +213,6 @@ template: {
 					}
 				}
 				spec: {
-					parallelism: parameter.count
-					completions: parameter.count
-					backoffLimit: parameter.backoffLimit
 					template: {
 						metadata: {
 							labels: {
@@ -201,28 +231,54 @@ template: {
 							containers: [{
 								name: context.name
 								image: parameter.image
-								if parameter["cpu"] != _|_ {
+								if parameter["env"] != _|_ {
+									env: parameter.env
+								}
+								if context["config"] != _|_ {
+									env: context.config
+								}
+								if parameter["cpu"] != _|_ && !(parameter.limit.cpu != _|_) {
 									resources: {
+										requests: {
+											cpu: parameter.cpu
+										}
 										limits: {
 											cpu: parameter.cpu
 										}
+									}
+								}
+								if parameter["cpu"] != _|_ && parameter.limit.cpu != _|_ {
+									resources: {
 										requests: {
 											cpu: parameter.cpu
 										}
+										limits: {
+											cpu: parameter.limit.cpu
+										}
 									}
 								}
-								if parameter["memory"] != _|_ {
+								if parameter["memory"] != _|_ && !(parameter.limit.memory != _|_) {
 									resources: {
+										requests: {
+											memory: parameter.memory
+										}
 										limits: {
 											memory: parameter.memory
 										}
+									}
+								}
+								if parameter["memory"] != _|_ && parameter.limit.memory != _|_ {
+									resources: {
 										requests: {
 											memory: parameter.memory
 										}
+										limits: {
+											memory: parameter.limit.memory
+										}
 									}
 								}
 								if parameter["volumeMounts"] != _|_ {
-									volumeMounts: mountsArray.pvc + mountsArray.configMap + mountsArray.secret + mountsArray.emptyDir + mountsArray.hostPath
+									volumeMounts: mountsArray
 								}
 								if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
 									volumeMounts: [for v in parameter.volumes {
@@ -232,15 +288,21 @@ template: {
 				}
 			}]
 								}
+								if parameter["args"] != _|_ {
+									args: parameter.args
+								}
 								if parameter["cmd"] != _|_ {
 									command: parameter.cmd
 								}
-								if parameter["env"] != _|_ {
-									env: parameter.env
-								}
 								if parameter["imagePullPolicy"] != _|_ {
 									imagePullPolicy: parameter.imagePullPolicy
 								}
+								if parameter["livenessProbe"] != _|_ {
+									livenessProbe: parameter.livenessProbe
+								}
+								if parameter["readinessProbe"] != _|_ {
+									readinessProbe: parameter.readinessProbe
+								}
 							}]
 							if parameter["volumeMounts"] != _|_ {
 								volumes: deDupVolumesArray
@@ -281,21 +343,43 @@ template: {
 			}]
 							}
 							if parameter["hostAliases"] != _|_ {
-								hostAliases: [for v in parameter.hostAliases {
-				{
-					hostnames: v.hostnames
-					ip: v.ip
-				}
-			}]
+								// +patchKey=ip
+								hostAliases: parameter.hostAliases
 							}
 							if parameter["imagePullSecrets"] != _|_ {
 								imagePullSecrets: [for v in parameter.imagePullSecrets { name: v }]
 							}
 						}
 					}
+					parallelism: parameter.count
+					if parameter["completions"] != _|_ {
+						completions: parameter.completions
+					}
+					if parameter["completions"] == _|_ {
+						completions: parameter.count
+					}
+					completionMode: parameter.completionMode
+					if parameter["backoffLimitPerIndex"] != _|_ {
+						backoffLimitPerIndex: parameter.backoffLimitPerIndex
+					}
+					if parameter["backoffLimitPerIndex"] != _|_ && parameter.completionMode != "Indexed" {
+						backoffLimitPerIndex: error("backoffLimitPerIndex is only valid when completionMode is Indexed")
+					}
+					if parameter["maxFailedIndexes"] != _|_ {
+						maxFailedIndexes: parameter.maxFailedIndexes
+					}
+					if parameter["maxFailedIndexes"] != _|_ && parameter["backoffLimitPerIndex"] == _|_ {
+						maxFailedIndexes: error("maxFailedIndexes requires backoffLimitPerIndex")
+					}
 					if parameter["activeDeadlineSeconds"] != _|_ {
 						activeDeadlineSeconds: parameter.activeDeadlineSeconds
 					}
+					if parameter["backoffLimitPerIndex"] == _|_ {
+						backoffLimit: parameter.backoffLimit
+					}
+					if parameter["podFailurePolicy"] != _|_ {
+						podFailurePolicy: parameter.podFailurePolicy
+					}
 					if parameter["ttlSecondsAfterFinished"] != _|_ {
 						ttlSecondsAfterFinished: parameter.ttlSecondsAfterFinished
 					}
@@ -304,6 +388,13 @@ template: {
 			if parameter["startingDeadlineSeconds"] != _|_ {
 				startingDeadlineSeconds: parameter.startingDeadlineSeconds
 			}
+			if parameter["timeZone"] != _|_ {
+				timeZone: [
+	if parameter.timeZone == "Local" {error("timeZone Local is not supported, use an IANA time zone name")},
+	if strings.HasPrefix(parameter.timeZone, "TZ=") || strings.HasPrefix(parameter.timeZone, "CRON_TZ=") {error("timeZone must be an IANA time zone name without a TZ= or CRON_TZ= prefix")},
+	parameter.timeZone,
+][0]
+			}
 		}
 	}
 	parameter: {
@@ -313,6 +404,8 @@ template: {
 		annotations?: [string]: string
 		// +usage=Specify the schedule in Cron format, see https://en.wikipedia.org/wiki/Cron
 		schedule: string
+		// +usage=Specify the IANA time zone name of the schedule, e.g. Europe/Berlin, defaults to the time zone of the controller manager. The name is only checked against the time zone database by the cluster
+		timeZone?: string
 		// +usage=Specify deadline in seconds for starting the job if it misses scheduled
 		startingDeadlineSeconds?: int
 		// +usage=suspend subsequent executions
@@ -326,6 +419,46 @@ template: {
 		// +usage=Specify number of tasks to run in parallel
 		// +short=c
 		count: *1 | int
+		// +usage=Define the job restart policy, the value can only be Never or OnFailure. By default, it's Never.
+		restart: *"Never" | string
+		// +usage=Specify number of tasks that must succeed, defaults to count
+		completions?: int
+		// +usage=Specify how completions are tracked. Indexed tasks get a completion index from 0 to completions-1 in the JOB_COMPLETION_INDEX env
+		completionMode: *"NonIndexed" | "Indexed"
+		// +usage=The number of retries before marking this job failed, not used when backoffLimitPerIndex is set
+		backoffLimit: *6 | int
+		// +usage=Specify number of retries of each index before it is marked failed. Only valid when completionMode is Indexed
+		backoffLimitPerIndex?: int
+		// +usage=Specify number of failed indexes after which the task fails. Requires backoffLimitPerIndex
+		maxFailedIndexes?: int
+		// +usage=Specify how pod failures are handled, requires restart to be Never
+		podFailurePolicy?: {
+			// +usage=Rules evaluated in order, the first matching rule applies
+			rules: [...{
+				// +usage=Action taken when the rule matches
+				action: "FailJob" | "FailIndex" | "Ignore" | "Count"
+				// +usage=Match pods by the exit codes of their containers
+				onExitCodes?: {
+					// +usage=Only match the exit code of this container
+					containerName?: string
+					// +usage=Relation between the exit code and values
+					operator: "In" | "NotIn"
+					// +usage=Exit codes to match
+					values: [...int]
+				}
+				// +usage=Match pods by their conditions
+				onPodConditions?: [...{
+					// +usage=Pod condition type, e.g. DisruptionTarget
+					type: string
+					// +usage=Pod condition status
+					status: *"True" | string
+				}]
+			}]
+		}
+		// +usage=The duration in seconds relative to the startTime that the job may be continuously active before the system tries to terminate it
+		activeDeadlineSeconds?: int
+		// +usage=Limits the lifetime of a Job that has finished
+		ttlSecondsAfterFinished?: int
 		// +usage=Which image would you like to use for your service
 		// +short=i
 		image: string
@@ -333,10 +466,10 @@ template: {
 		imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
 		// +usage=Specify image pull secrets for your service
 		imagePullSecrets?: [...string]
-		// +usage=Define the job restart policy, the value can only be Never or OnFailure. By default, it's Never.
-		restart: *"Never" | string
 		// +usage=Commands to run in the container
 		cmd?: [...string]
+		// +usage=Arguments to the entrypoint
+		args?: [...string]
 		// +usage=Define arguments by using environment variables
 		env?: [...{
 			// +usage=Environment variable name
@@ -365,6 +498,10 @@ template: {
 		cpu?: string
 		// +usage=Specifies the attributes of the memory resource required for the container.
 		memory?: string
+		limit?: {
+			cpu?: string
+			memory?: string
+		}
 		volumeMounts?: {
 			// +usage=Mount PVC type volume
 			pvc?: [...{
@@ -412,7 +549,9 @@ template: {
 				name: string
 				mountPath: string
 				subPath?: string
+				mountPropagation?: "None" | "HostToContainer" | "Bidirectional"
 				path: string
+				readOnly?: bool
 			}]
 		}
 		// +usage=Deprecated field, use volumeMounts instead.
@@ -446,17 +585,11 @@ template: {
 				medium: *"" | "Memory"
 			}
 		}]
-		// +usage=An optional list of hosts and IPs that will be injected into the pod's hosts file
+		// +usage=Specify the hostAliases to add
 		hostAliases?: [...{
 			ip: string
 			hostnames: [...string]
 		}]
-		// +usage=Limits the lifetime of a Job that has finished
-		ttlSecondsAfterFinished?: int
-		// +usage=The duration in seconds relative to the startTime that the job may be continuously active before the system tries to terminate it
-		activeDeadlineSeconds?: int
-		// +usage=The number of retries before marking this job failed
-		backoffLimit: *6 | int
 		// +usage=Instructions for assessing whether the container is alive.
 		livenessProbe?: #HealthProbe
 		// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
@@ -474,6 +607,8 @@ template: {
 			path: string
 			// +usage=The TCP socket within the container to which the HTTP GET request should be directed.
 			port: int
+			host?: string
+			scheme?: *"HTTP" | string
 			httpHeaders?: [...{
 				name: string
 				value: string
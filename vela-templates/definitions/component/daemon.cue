@@ -17,60 +17,33 @@ daemon: {
 		}
 		status: {
 			customStatus: #"""
-				ready: {
-					replicas: *0 | int
-				} & {
-					if context.output.status.numberReady != _|_ {
-						replicas: context.output.status.numberReady
-					}
-				}
-				desired: {
-					replicas: *0 | int
-				} & {
-					if context.output.status.desiredNumberScheduled != _|_ {
-						replicas: context.output.status.desiredNumberScheduled
-					}
+				message: *"" | string
+				if context.output.status != _|_ {
+					_st: {
+						desiredNumberScheduled: *0 | int
+						numberReady:            *0 | int
+						updatedNumberScheduled: *0 | int
+						observedGeneration:     *0 | int
+						...
+					} & context.output.status
+					_onDelete: [if context.output.spec.updateStrategy != _|_ if context.output.spec.updateStrategy.type == "OnDelete" {true}, false][0]
+					message: "Ready:\(_st.numberReady)/\(_st.desiredNumberScheduled)"
 				}
-				message: "Ready:\(ready.replicas)/\(desired.replicas)"
 				"""#
 			healthPolicy: #"""
-				ready: {
-					replicas: *0 | int
-				} & {
-					if context.output.status.numberReady != _|_ {
-						replicas: context.output.status.numberReady
-					}
-				}
-				desired: {
-					replicas: *0 | int
-				} & {
-					if context.output.status.desiredNumberScheduled != _|_ {
-						replicas: context.output.status.desiredNumberScheduled
-					}
-				}
-				current: {
-					replicas: *0 | int
-				} & {
-					if context.output.status.currentNumberScheduled != _|_ {
-						replicas: context.output.status.currentNumberScheduled
-					}
-				}
-				updated: {
-					replicas: *0 | int
-				} & {
-					if context.output.status.updatedNumberScheduled != _|_ {
-						replicas: context.output.status.updatedNumberScheduled
-					}
-				}
-				generation: {
-					metadata: context.output.metadata.generation
-					observed: *0 | int
-				} & {
-					if context.output.status.observedGeneration != _|_ {
-						observed: context.output.status.observedGeneration
-					}
+				healthy: *false | bool
+				if context.output.status != _|_ {
+					_st: {
+						desiredNumberScheduled: *0 | int
+						numberReady:            *0 | int
+						updatedNumberScheduled: *0 | int
+						observedGeneration:     *0 | int
+						...
+					} & context.output.status
+					_onDelete: [if context.output.spec.updateStrategy != _|_ if context.output.spec.updateStrategy.type == "OnDelete" {true}, false][0]
+					healthy: _st.desiredNumberScheduled == _st.numberReady && (_onDelete || _st.desiredNumberScheduled == _st.updatedNumberScheduled) && _st.observedGeneration >= context.output.metadata.generation
 				}
-				isHealth: (desired.replicas == ready.replicas) && (desired.replicas == updated.replicas) && (desired.replicas == current.replicas) && (generation.observed == generation.metadata || generation.observed > generation.metadata)
+				isHealth: healthy
 				"""#
 		}
 	}
@@ -84,6 +57,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		},
 		if parameter.volumeMounts != _|_ && parameter.volumeMounts.configMap != _|_ for v in parameter.volumeMounts.configMap {
@@ -93,6 +72,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		},
 		if parameter.volumeMounts != _|_ && parameter.volumeMounts.secret != _|_ for v in parameter.volumeMounts.secret {
@@ -102,6 +87,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		},
 		if parameter.volumeMounts != _|_ && parameter.volumeMounts.emptyDir != _|_ for v in parameter.volumeMounts.emptyDir {
@@ -111,6 +102,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		},
 		if parameter.volumeMounts != _|_ && parameter.volumeMounts.hostPath != _|_ for v in parameter.volumeMounts.hostPath {
@@ -120,6 +117,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		}
 	]
@@ -214,51 +217,55 @@ template: {
 					containers: [{
 						name: context.name
 						image: parameter.image
-						if parameter["port"] != _|_ && parameter["ports"] == _|_ {
-							ports: [{
-							containerPort: parameter.port
-						}]
-						}
-						if parameter["ports"] != _|_ {
-							ports: [for v in parameter.ports {
-				{
-					containerPort: v.port
-					if v.name != _|_ {
-						name: v.name
-					}
-					if v.name == _|_ {
-						name: "port-" + strconv.FormatInt(v.port, 10)
-					}
-					protocol: v.protocol
-				}
-			}]
-						}
 						if parameter["env"] != _|_ {
 							env: parameter.env
 						}
 						if context["config"] != _|_ {
 							env: context.config
 						}
-						if parameter["cpu"] != _|_ {
+						if parameter["cpu"] != _|_ && !(parameter.limit.cpu != _|_) {
 							resources: {
+								requests: {
+									cpu: parameter.cpu
+								}
 								limits: {
 									cpu: parameter.cpu
 								}
+							}
+						}
+						if parameter["cpu"] != _|_ && parameter.limit.cpu != _|_ {
+							resources: {
 								requests: {
 									cpu: parameter.cpu
 								}
+								limits: {
+									cpu: parameter.limit.cpu
+								}
 							}
 						}
-						if parameter["memory"] != _|_ {
+						if parameter["memory"] != _|_ && !(parameter.limit.memory != _|_) {
 							resources: {
+								requests: {
+									memory: parameter.memory
+								}
 								limits: {
 									memory: parameter.memory
 								}
+							}
+						}
+						if parameter["memory"] != _|_ && parameter.limit.memory != _|_ {
+							resources: {
 								requests: {
 									memory: parameter.memory
 								}
+								limits: {
+									memory: parameter.limit.memory
+								}
 							}
 						}
+						if parameter["volumeMounts"] != _|_ {
+							volumeMounts: mountsArray
+						}
 						if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
 							volumeMounts: [for v in parameter.volumes {
 				{
@@ -267,8 +274,32 @@ template: {
 				}
 			}]
 						}
-						if parameter["volumeMounts"] != _|_ {
-							volumeMounts: mountsArray
+						if parameter["port"] != _|_ && parameter["ports"] == _|_ {
+							ports: [{
+							containerPort: parameter.port
+						}]
+						}
+						if parameter["ports"] != _|_ {
+							ports: [for v in parameter.ports {
+				{
+					containerPort: v.port
+					if v.name != _|_ {
+						name: v.name
+					}
+					if v.name == _|_ {
+						name: "port-" + strconv.FormatInt(v.port, 10)
+					}
+					protocol: v.protocol
+				}
+			}]
+						}
+						if parameter.privileged {
+							securityContext: {
+								privileged: parameter.privileged
+							}
+						}
+						if parameter["args"] != _|_ {
+							args: parameter.args
 						}
 						if parameter["cmd"] != _|_ {
 							command: parameter.cmd
@@ -283,6 +314,9 @@ template: {
 							readinessProbe: parameter.readinessProbe
 						}
 					}]
+					if parameter["volumeMounts"] != _|_ {
+						volumes: deDupVolumesArray
+					}
 					if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
 						volumes: [for v in parameter.volumes {
 				{
@@ -318,8 +352,19 @@ template: {
 				}
 			}]
 					}
-					if parameter["volumeMounts"] != _|_ {
-						volumes: deDupVolumesArray
+					if parameter["priorityClassName"] != _|_ {
+						priorityClassName: *parameter.priorityClassName | _
+					}
+					if parameter["priorityClassName"] == _|_ && context.namespace == "kube-system" {
+						priorityClassName: *"system-node-critical" | _
+					}
+					hostNetwork: parameter.hostNetwork
+					hostPID: parameter.hostPID
+					if parameter["dnsPolicy"] != _|_ {
+						dnsPolicy: parameter.dnsPolicy
+					}
+					if parameter.hostNetwork && parameter["dnsPolicy"] == _|_ {
+						dnsPolicy: "ClusterFirstWithHostNet"
 					}
 					if parameter["hostAliases"] != _|_ {
 						// +patchKey=ip
@@ -328,8 +373,25 @@ template: {
 					if parameter["imagePullSecrets"] != _|_ {
 						imagePullSecrets: [for v in parameter.imagePullSecrets { name: v }]
 					}
+					if parameter["nodeSelector"] != _|_ {
+						nodeSelector: *parameter.nodeSelector | _
+					}
+					if parameter["tolerations"] != _|_ || parameter["tolerationPreset"] != _|_ {
+						// +patchKey=key
+						tolerations: *[if parameter["tolerations"] != _|_ for t in parameter.tolerations {t}, for t in [if parameter["tolerationPreset"] != _|_ {operator: "Exists", if parameter.tolerationPreset != "All" {effect: parameter.tolerationPreset}}] {t}] | _
+					}
+					if parameter["topologySpreadConstraints"] != _|_ {
+						// +patchKey=topologyKey
+						topologySpreadConstraints: *[for c in parameter.topologySpreadConstraints {
+	c
+	if c.labelSelector == _|_ {labelSelector: matchLabels: "app.oam.dev/component": context.name}
+}] | _
+					}
 				}
 			}
+			if parameter["updateStrategy"] != _|_ {
+				updateStrategy: parameter.updateStrategy
+			}
 		}
 	}
 	exposePorts: [
@@ -374,29 +436,10 @@ template: {
 		imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
 		// +usage=Specify image pull secrets for your service
 		imagePullSecrets?: [...string]
-		// +ignore
-		// +usage=Deprecated field, please use ports instead
-		// +short=p
-		port?: int
-		// +usage=Which ports do you want customer traffic sent to, defaults to 80
-		ports?: [...{
-			// +usage=Number of port to expose on the pod's IP address
-			port: int
-			// +usage=Name of the port
-			name?: string
-			// +usage=Protocol for port. Must be UDP, TCP, or SCTP
-			protocol: *"TCP" | "UDP" | "SCTP"
-			// +usage=Specify if the port should be exposed
-			expose: *false | bool
-		}]
-		// +ignore
-		// +usage=Specify what kind of Service you want. options: "ClusterIP", "NodePort", "LoadBalancer", "ExternalName"
-		exposeType: *"ClusterIP" | "NodePort" | "LoadBalancer" | "ExternalName"
-		// +ignore
-		// +usage=If addRevisionLabel is true, the revision label will be added to the underlying pods
-		addRevisionLabel: *false | bool
 		// +usage=Commands to run in the container
 		cmd?: [...string]
+		// +usage=Arguments to the entrypoint
+		args?: [...string]
 		// +usage=Define arguments by using environment variables
 		env?: [...{
 			// +usage=Environment variable name
@@ -421,15 +464,41 @@ template: {
 				}
 			}
 		}]
+		// +ignore
+		// +usage=Deprecated field, please use ports instead
+		// +short=p
+		port?: int
+		// +usage=Which ports do you want customer traffic sent to, defaults to 80
+		ports?: [...{
+			// +usage=Number of port to expose on the pod's IP address
+			port: int
+			// +usage=Name of the port
+			name?: string
+			// +usage=Protocol for port. Must be UDP, TCP, or SCTP
+			protocol: *"TCP" | "UDP" | "SCTP"
+			// +usage=Specify if the port should be exposed
+			expose: *false | bool
+		}]
+		// +ignore
+		// +usage=Specify what kind of Service you want. options: "ClusterIP", "NodePort", "LoadBalancer", "ExternalName"
+		exposeType: *"ClusterIP" | "NodePort" | "LoadBalancer" | "ExternalName"
+		// +ignore
+		// +usage=If addRevisionLabel is true, the revision label will be added to the underlying pods
+		addRevisionLabel: *false | bool
 		// +usage=Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)
 		cpu?: string
 		// +usage=Specifies the attributes of the memory resource required for the container.
 		memory?: string
+		limit?: {
+			cpu?: string
+			memory?: string
+		}
 		volumeMounts?: {
 			// +usage=Mount PVC type volume
 			pvc?: [...{
 				name: string
 				mountPath: string
+				subPath?: string
 				// +usage=The name of the PVC
 				claimName: string
 			}]
@@ -437,6 +506,7 @@ template: {
 			configMap?: [...{
 				name: string
 				mountPath: string
+				subPath?: string
 				defaultMode: *420 | int
 				cmName: string
 				items?: [...{
@@ -449,6 +519,7 @@ template: {
 			secret?: [...{
 				name: string
 				mountPath: string
+				subPath?: string
 				defaultMode: *420 | int
 				secretName: string
 				items?: [...{
@@ -461,12 +532,14 @@ template: {
 			emptyDir?: [...{
 				name: string
 				mountPath: string
+				subPath?: string
 				medium: *"" | "Memory"
 			}]
 			// +usage=Mount HostPath type volume
 			hostPath?: [...{
 				name: string
 				mountPath: string
+				subPath?: string
 				mountPropagation?: "None" | "HostToContainer" | "Bidirectional"
 				path: string
 				readOnly?: bool
@@ -512,6 +585,59 @@ template: {
 			ip: string
 			hostnames: [...string]
 		}]
+		// +usage=Schedule the pod on the nodes having these labels
+		nodeSelector?: [string]: string
+		// +usage=Specify the taints the pod tolerates
+		tolerations?: [...{
+			// +usage=Key of the taint, empty with operator Exists to tolerate everything
+			key?: string
+			operator: *"Equal" | "Exists"
+			// +usage=Value of the taint, for operator Equal
+			value?: string
+			// +usage=Effect of the taint to tolerate, empty for all the effects
+			effect?: "NoSchedule" | "PreferNoSchedule" | "NoExecute"
+			// +usage=Number of seconds the pod stays bound to a node tainted NoExecute
+			tolerationSeconds?: int
+		}]
+		// +usage=Specify how the pods are spread across the topology domains, e.g. zones or nodes
+		topologySpreadConstraints?: [...{
+			// +usage=Maximum difference of the number of pods between two domains
+			maxSkew: int & >=1
+			// +usage=Node label whose values are the domains, e.g. topology.kubernetes.io/zone
+			topologyKey: string
+			// +usage=Indicate how to deal with a pod if it doesn't satisfy the spread constraint
+			whenUnsatisfiable: *"DoNotSchedule" | "ScheduleAnyway"
+			// +usage=Select the pods to spread, defaults to the pods of the component
+			labelSelector?: {...}
+			// +usage=Indicate a minimum number of eligible domains
+			minDomains?: int
+			// +usage=Pod label keys whose values select the pods to spread, next to labelSelector
+			matchLabelKeys?: [...string]
+		}]
+		// +usage=Specify the PriorityClass of the pod
+		priorityClassName?: string
+		// +usage=Specify how the pods are replaced on updates
+		updateStrategy?: {
+			// +usage="RollingUpdate" replaces the pods node by node, "OnDelete" only replaces the pods deleted by hand
+			type: *"RollingUpdate" | "OnDelete"
+			// +usage=Tune the rolling update, only valid with type RollingUpdate
+			rollingUpdate?: {
+				// +usage=Number or percentage of nodes whose pod is unavailable during the update, e.g. `10%`
+				maxUnavailable?: int | string
+				// +usage=Number or percentage of nodes running the new pod next to the old one during the update, e.g. `1`
+				maxSurge?: int | string
+			}
+		}
+		// +usage=Use the network namespace of the node, e.g. for agents listening on node ports
+		hostNetwork: *false | bool
+		// +usage=Use the PID namespace of the node, e.g. for agents monitoring node processes
+		hostPID: *false | bool
+		// +usage=Specify the DNS policy of the pod, defaults to "ClusterFirstWithHostNet" with hostNetwork so that cluster names still resolve
+		dnsPolicy?: "ClusterFirst" | "ClusterFirstWithHostNet" | "Default" | "None"
+		// +usage=Run the container privileged, with all the capabilities of the node
+		privileged: *false | bool
+		// +usage=Tolerate the taints of every node: "All" tolerates any taint, "NoSchedule" and "NoExecute" the taints of that effect. Added to tolerations
+		tolerationPreset?: "All" | "NoSchedule" | "NoExecute"
 	}
 	#HealthProbe: {
 		// +usage=Instructions for assessing container health by executing a command. Either this attribute or the httpGet attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the httpGet attribute and the tcpSocket attribute.
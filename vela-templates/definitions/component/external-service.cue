@@ -0,0 +1,210 @@
+import (
+	"list"
+	"strings"
+)
+
+"external-service": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Describes a service running outside the cluster, reachable through an ExternalName Service or a Service with IP endpoints."
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "v1"
+				kind:       "Service"
+			}
+			type: "services.v1"
+		}
+		status: {
+			customStatus: #"""
+				_check: *"" | string
+				if context.outputs.healthCheck != _|_ {
+					_generation: *0 | int
+					if context.outputs.healthCheck.metadata.generation != _|_ {
+						_generation: context.outputs.healthCheck.metadata.generation
+					}
+					_probe: {
+						observedGeneration: *0 | int
+						updatedReplicas:    *0 | int
+						readyReplicas:      *0 | int
+						...
+					}
+					if context.outputs.healthCheck.status != _|_ {
+						_probe: context.outputs.healthCheck.status
+					}
+					_check: [
+						if _probe.observedGeneration < _generation || _probe.updatedReplicas == 0 {"pending"},
+						if _probe.readyReplicas > 0 {"passing"},
+						"failing",
+					][0]
+				}
+				_target: *"no endpoints" | string
+				if context.output.spec.type == "ExternalName" {
+					_target: context.output.spec.externalName
+				}
+				if context.outputs.endpoints != _|_ {
+					_target: "\(len(context.outputs.endpoints.endpoints)) addresses"
+				}
+				_suffix: *"" | string
+				if _check != "" {
+					_suffix: ", check:\(_check)"
+				}
+				message: "Host:\(context.output.metadata.name).\(context.output.metadata.namespace).svc, target:\(_target)\(_suffix)"
+				"""#
+			healthPolicy: #"""
+				_check: *"" | string
+				if context.outputs.healthCheck != _|_ {
+					_generation: *0 | int
+					if context.outputs.healthCheck.metadata.generation != _|_ {
+						_generation: context.outputs.healthCheck.metadata.generation
+					}
+					_probe: {
+						observedGeneration: *0 | int
+						updatedReplicas:    *0 | int
+						readyReplicas:      *0 | int
+						...
+					}
+					if context.outputs.healthCheck.status != _|_ {
+						_probe: context.outputs.healthCheck.status
+					}
+					_check: [
+						if _probe.observedGeneration < _generation || _probe.updatedReplicas == 0 {"pending"},
+						if _probe.readyReplicas > 0 {"passing"},
+						"failing",
+					][0]
+				}
+				isHealth: _check == "" || _check == "passing"
+				"""#
+		}
+	}
+}
+template: {
+	output: {
+		apiVersion: "v1"
+		kind:       "Service"
+		spec: {
+			if parameter["externalName"] != _|_ {
+				type: "ExternalName"
+			}
+			if parameter["externalName"] == _|_ && parameter["addresses"] != _|_ {
+				type: "ClusterIP"
+			}
+			if parameter["externalName"] == _|_ && parameter["addresses"] == _|_ {
+				type: error("externalName or addresses is required")
+			}
+			ports: [for p in parameter.ports {
+	name:       [if p.name != _|_ {p.name}, if p.protocol != "TCP" {"port-\(p.port)-\(strings.ToLower(p.protocol))"}, "port-\(p.port)"][0]
+	port:       p.port
+	targetPort: [if p.targetPort != _|_ {p.targetPort}, p.port][0]
+	protocol:   p.protocol
+	if p.appProtocol != _|_ {appProtocol: p.appProtocol}
+}]
+			if parameter["externalName"] != _|_ {
+				externalName: parameter.externalName
+			}
+		}
+	}
+	outputs: {
+		if parameter["externalName"] == _|_ && parameter["addresses"] != _|_ {
+			endpoints: {
+				apiVersion: "discovery.k8s.io/v1"
+				kind:       "EndpointSlice"
+				metadata: {
+					name: context.name
+					labels: {
+						"kubernetes.io/service-name": context.name
+						"endpointslice.kubernetes.io/managed-by": "external-service.oam.dev"
+					}
+				}
+				addressType: [if strings.Contains(parameter.addresses[0], ":") {"IPv6"}, "IPv4"][0]
+				endpoints: [for a in parameter.addresses {addresses: [a], conditions: ready: true}]
+				ports: [for p in parameter.ports {
+	name:     [if p.name != _|_ {p.name}, if p.protocol != "TCP" {"port-\(p.port)-\(strings.ToLower(p.protocol))"}, "port-\(p.port)"][0]
+	port:     [if p.targetPort != _|_ {p.targetPort}, p.port][0]
+	protocol: p.protocol
+	if p.appProtocol != _|_ {appProtocol: p.appProtocol}
+}]
+			}
+		}
+		if parameter["healthCheck"] != _|_ {
+			healthCheck: {
+				apiVersion: "apps/v1"
+				kind:       "Deployment"
+				metadata: {
+					name: "\(context.name)-health-check"
+				}
+				spec: {
+					replicas: 1
+					strategy: {
+						type: "Recreate"
+					}
+					selector: {
+						matchLabels: {
+							"external-service.oam.dev/health-check": context.name
+						}
+					}
+					template: {
+						metadata: {
+							labels: {
+								"external-service.oam.dev/health-check": context.name
+							}
+						}
+						spec: {
+							containers: [{
+	_check: parameter.healthCheck
+	_host:  "\(context.name).\(context.namespace).svc"
+	_port:  [if _check.port != _|_ {_check.port}, parameter.ports[0].port][0]
+	_wait:  "\(_check.timeoutSeconds)"
+	name:   "probe"
+	image:  _check.image
+	command: ["sleep", "2147483647"]
+	readinessProbe: {
+		if _check.type == "tcp" {exec: command: ["nc", "-z", "-w", _wait, _host, "\(_port)"]}
+		if _check.type == "http" {exec: command: ["wget", "-q", "-T", _wait, "-O", "/dev/null", "http://\(_host):\(_port)\(_check.path)"]}
+		periodSeconds:    _check.periodSeconds
+		timeoutSeconds:   _check.timeoutSeconds + 1
+		failureThreshold: 1
+	}
+}]
+						}
+					}
+				}
+			}
+		}
+	}
+	parameter: {
+		// +usage=DNS name of the external service, e.g. `mydb.abc123.eu-west-1.rds.amazonaws.com`. Takes precedence over addresses
+		externalName?: string
+		// +usage=IP addresses of the external service, all of the same family. Either externalName or addresses is required
+		addresses?: list.MinItems(1) & [...string]
+		// +usage=Ports of the external service
+		ports: [...{
+			// +usage=Port of the Service
+			port: int
+			// +usage=Name of the port, defaults to port-<port>
+			name?: string
+			// +usage=Protocol of the port
+			protocol: *"TCP" | "UDP" | "SCTP"
+			// +usage=Port of the addresses, defaults to port. Ignored with externalName
+			targetPort?: int
+			// +usage=Application protocol of the port, e.g. http, https or postgresql
+			appProtocol?: string
+		}]
+		// +usage=Probe the external service through its Service periodically, from the readiness probe of a pod. The component is healthy while the last probe passed
+		healthCheck?: {
+			// +usage=Open a TCP connection, or send an HTTP GET request expecting a successful response
+			type: *"tcp" | "http"
+			// +usage=Port of the Service to probe, defaults to the first port
+			port?: int
+			// +usage=Path of the HTTP request
+			path: *"/" | string
+			// +usage=Number of seconds between the probes
+			periodSeconds: *60 | int & >=1
+			// +usage=Number of seconds after which the probe fails
+			timeoutSeconds: *5 | int & >=1
+			// +usage=Image running the probe, with the nc and wget commands
+			image: *"busybox:1.36" | string
+		}
+	}
+}
@@ -0,0 +1,213 @@
+import (
+	"strings"
+)
+
+"helm-release": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Describes a Helm chart installed by Flux from a Helm repository or an OCI registry."
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "helm.toolkit.fluxcd.io/v2"
+				kind:       "HelmRelease"
+			}
+			type: "helmreleases.helm.toolkit.fluxcd.io"
+		}
+		status: {
+			customStatus: #"""
+				status: {
+					lastAttemptedRevision: *"none" | string
+				} & {
+					if context.output.status.lastAttemptedRevision != _|_ {
+						lastAttemptedRevision: context.output.status.lastAttemptedRevision
+					}
+				}
+				_readyCond: *[] | [...]
+				if context.output.status.conditions != _|_ {
+					_readyCond: [for c in context.output.status.conditions if c.type == "Ready" {c}]
+				}
+				_readyStatus: *"Unknown" | string
+				_readyMessage: *"" | string
+				if len(_readyCond) > 0 {
+					_readyStatus: _readyCond[0].status
+					if _readyCond[0].message != _|_ {
+						_readyMessage: ", \(_readyCond[0].message)"
+					}
+				}
+				message: "Ready:\(_readyStatus), revision:\(status.lastAttemptedRevision)\(_readyMessage)"
+				"""#
+			healthPolicy: #"""
+				ready: {
+					observedGeneration: *0 | int
+				} & {
+					if context.output.status.observedGeneration != _|_ {
+						observedGeneration: context.output.status.observedGeneration
+					}
+				}
+				_readyCond: *[] | [...]
+				if context.output.status.conditions != _|_ {
+					_readyCond: [for c in context.output.status.conditions if c.type == "Ready" {c}]
+				}
+				_readyStatus: *"Unknown" | string
+				_readyMessage: *"" | string
+				if len(_readyCond) > 0 {
+					_readyStatus: _readyCond[0].status
+					if _readyCond[0].message != _|_ {
+						_readyMessage: ", \(_readyCond[0].message)"
+					}
+				}
+				isHealth: _readyStatus == "True" && ready.observedGeneration == context.output.metadata.generation
+				"""#
+		}
+	}
+}
+template: {
+	output: {
+		apiVersion: "helm.toolkit.fluxcd.io/v2"
+		kind:       "HelmRelease"
+		spec: {
+			interval: parameter.interval
+			if !(strings.HasPrefix(parameter.repoURL, "oci://")) {
+				chart: {
+					spec: {
+						sourceRef: {
+							kind: "HelmRepository"
+							name: context.name
+						}
+					}
+				}
+			}
+			if !(strings.HasPrefix(parameter.repoURL, "oci://")) && parameter["chart"] != _|_ {
+				chart: {
+					spec: {
+						chart: parameter.chart
+					}
+				}
+			}
+			if !(strings.HasPrefix(parameter.repoURL, "oci://")) && parameter["chart"] == _|_ {
+				chart: {
+					spec: {
+						chart: error("chart is required when repoURL is not an oci:// chart artifact")
+					}
+				}
+			}
+			if !(strings.HasPrefix(parameter.repoURL, "oci://")) && parameter["version"] != _|_ {
+				chart: {
+					spec: {
+						version: parameter.version
+					}
+				}
+			}
+			install: {
+				createNamespace: parameter.install.createNamespace
+				remediation: {
+					retries: parameter.install.retries
+				}
+			}
+			upgrade: {
+				remediation: {
+					retries: parameter.upgrade.retries
+					strategy: parameter.upgrade.strategy
+				}
+				cleanupOnFail: parameter.upgrade.cleanupOnFail
+			}
+			if parameter["targetNamespace"] != _|_ {
+				targetNamespace: parameter.targetNamespace
+			}
+			if parameter["values"] != _|_ {
+				values: parameter.values
+			}
+			if parameter["valuesFrom"] != _|_ {
+				valuesFrom: parameter.valuesFrom
+			}
+			if strings.HasPrefix(parameter.repoURL, "oci://") {
+				chartRef: {
+					kind: "OCIRepository"
+					name: context.name
+				}
+			}
+		}
+	}
+	outputs: {
+		if !(strings.HasPrefix(parameter.repoURL, "oci://")) {
+			helmRepository: {
+				apiVersion: "source.toolkit.fluxcd.io/v1"
+				kind:       "HelmRepository"
+				metadata: {
+					name: context.name
+				}
+				spec: {
+					url: parameter.repoURL
+					interval: parameter.interval
+				}
+			}
+		}
+		if strings.HasPrefix(parameter.repoURL, "oci://") {
+			ociRepository: {
+				apiVersion: "source.toolkit.fluxcd.io/v1beta2"
+				kind:       "OCIRepository"
+				metadata: {
+					name: context.name
+				}
+				spec: {
+					url: parameter.repoURL
+					interval: parameter.interval
+					layerSelector: {
+						mediaType: "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+						operation: "copy"
+					}
+					if parameter["version"] != _|_ {
+						ref: {
+							semver: parameter.version
+						}
+					}
+				}
+			}
+		}
+	}
+	parameter: {
+		// +usage=URL of the Helm repository, e.g. https://stefanprodan.github.io/podinfo, or of the OCI chart artifact, e.g. oci://ghcr.io/stefanprodan/charts/podinfo
+		repoURL: string
+		// +usage=Name of the chart in the Helm repository, required unless repoURL is an OCI chart artifact
+		chart?: string
+		// +usage=Version or semver range of the chart, e.g. 6.5.x, defaults to the latest version
+		version?: string
+		// +usage=Interval at which the chart source and the release are reconciled
+		interval: *"10m" | string
+		// +usage=Values of the chart
+		values?: {...}
+		// +usage=Read values from ConfigMaps and Secrets, merged in order before values
+		valuesFrom?: [...{
+			// +usage=Kind of the values source
+			kind: "ConfigMap" | "Secret"
+			// +usage=Name of the ConfigMap or Secret in the namespace of the application
+			name: string
+			// +usage=Key holding the values
+			valuesKey: *"values.yaml" | string
+			// +usage=Set the value of the key at this path of the values instead of merging it, e.g. image.tag
+			targetPath?: string
+			// +usage=Ignore the source when it does not exist
+			optional: *false | bool
+		}]
+		// +usage=Namespace the chart is installed into, defaults to the namespace of the application
+		targetNamespace?: string
+		// +usage=Install settings
+		install: {
+			// +usage=Create the targetNamespace if it does not exist
+			createNamespace: *false | bool
+			// +usage=Number of retries after a failed install, -1 retries forever
+			retries: *3 | int
+		}
+		// +usage=Upgrade settings
+		upgrade: {
+			// +usage=Number of retries after a failed upgrade, -1 retries forever
+			retries: *3 | int
+			// +usage=Remediate a failed upgrade by rolling back or by uninstalling the release
+			strategy: *"rollback" | "uninstall"
+			// +usage=Delete the new resources created by a failed upgrade
+			cleanupOnFail: *false | bool
+		}
+	}
+}
@@ -3,24 +3,250 @@
 	annotations: {}
 	labels: {}
 	description: "K8s-objects allow users to specify raw K8s objects in properties"
-	attributes: workload: type: "autodetects.core.oam.dev"
+	attributes: {
+		workload: type: "autodetects.core.oam.dev"
+		status: {
+			customStatus: #"""
+				_objectHealth: [for i, o in parameter.objects if o.healthPolicy != _|_ {
+					_key: *"objects-\(i)" | string
+					if o.outputName != _|_ {
+						_key: o.outputName
+					}
+					_live: {}
+					if i == 0 {
+						_live: context.output
+					}
+					if i > 0 && context.outputs[_key] != _|_ {
+						_live: context.outputs[_key]
+					}
+					healthy: *false | bool
+					if _live.status != _|_ {
+						if o.healthPolicy == "Deployment" {
+							_st: {
+								replicas:           *0 | int
+								readyReplicas:      *0 | int
+								updatedReplicas:    *0 | int
+								observedGeneration: *0 | int
+								...
+							} & _live.status
+							healthy: _live.spec.replicas == _st.readyReplicas && _live.spec.replicas == _st.updatedReplicas && _live.spec.replicas == _st.replicas && _st.observedGeneration >= _live.metadata.generation
+						}
+						if o.healthPolicy == "StatefulSet" {
+							_st: {
+								replicas:           *0 | int
+								readyReplicas:      *0 | int
+								updatedReplicas:    *0 | int
+								observedGeneration: *0 | int
+								...
+							} & _live.status
+							healthy: _live.spec.replicas == _st.readyReplicas && _live.spec.replicas == _st.updatedReplicas && _st.observedGeneration >= _live.metadata.generation
+						}
+						if o.healthPolicy == "DaemonSet" {
+							_st: {
+								desiredNumberScheduled: *0 | int
+								numberReady:            *0 | int
+								updatedNumberScheduled: *0 | int
+								observedGeneration:     *0 | int
+								...
+							} & _live.status
+							_onDelete: [if _live.spec.updateStrategy != _|_ if _live.spec.updateStrategy.type == "OnDelete" {true}, false][0]
+							healthy: _st.desiredNumberScheduled == _st.numberReady && (_onDelete || _st.desiredNumberScheduled == _st.updatedNumberScheduled) && _st.observedGeneration >= _live.metadata.generation
+						}
+						if o.healthPolicy == "Job" {
+							_st: {
+								succeeded: *0 | int
+								...
+							} & _live.status
+							_completions: *1 | int
+							if _live.spec.completions != _|_ {
+								_completions: _live.spec.completions
+							}
+							healthy: _st.succeeded >= _completions
+						}
+						if o.healthPolicy == "CronJob" {
+							_st: {
+								active:           *[] | [...]
+								lastScheduleTime: *"never" | string
+								...
+							} & _live.status
+							healthy: true
+						}
+						if o.healthPolicy == "PersistentVolumeClaim" {
+							_st: {
+								phase: *"Pending" | string
+								...
+							} & _live.status
+							healthy: _st.phase == "Bound"
+						}
+						if o.healthPolicy == "Service" {
+							_st: {
+								loadBalancer: {
+									ingress: *[] | [...]
+									...
+								}
+								...
+							} & _live.status
+							_addresses: [
+								for i in _st.loadBalancer.ingress if i.ip != _|_ {i.ip},
+								for i in _st.loadBalancer.ingress if i.ip == _|_ && i.hostname != _|_ {i.hostname},
+								"pending",
+							]
+							_ingress: [if _live.spec.type == "LoadBalancer" {", ingress:\(_addresses[0])"}, ""][0]
+							healthy: _live.spec.type != "LoadBalancer" || len(_st.loadBalancer.ingress) > 0
+						}
+						if o.healthPolicy == "Ready" {
+							_st: {
+								conditions: *[] | [...]
+								...
+							} & _live.status
+							_ready: [for c in _st.conditions if c.type == "Ready" {c.status}, "Unknown"][0]
+							healthy: _ready == "True"
+						}
+					}
+				}]
+				_healthy: len([for c in _objectHealth if c.healthy {c}])
+				message: *"" | string
+				if len(_objectHealth) > 0 {
+					message: "Healthy objects:\(_healthy)/\(len(_objectHealth))"
+				}
+				"""#
+			healthPolicy: #"""
+				_objectHealth: [for i, o in parameter.objects if o.healthPolicy != _|_ {
+					_key: *"objects-\(i)" | string
+					if o.outputName != _|_ {
+						_key: o.outputName
+					}
+					_live: {}
+					if i == 0 {
+						_live: context.output
+					}
+					if i > 0 && context.outputs[_key] != _|_ {
+						_live: context.outputs[_key]
+					}
+					healthy: *false | bool
+					if _live.status != _|_ {
+						if o.healthPolicy == "Deployment" {
+							_st: {
+								replicas:           *0 | int
+								readyReplicas:      *0 | int
+								updatedReplicas:    *0 | int
+								observedGeneration: *0 | int
+								...
+							} & _live.status
+							healthy: _live.spec.replicas == _st.readyReplicas && _live.spec.replicas == _st.updatedReplicas && _live.spec.replicas == _st.replicas && _st.observedGeneration >= _live.metadata.generation
+						}
+						if o.healthPolicy == "StatefulSet" {
+							_st: {
+								replicas:           *0 | int
+								readyReplicas:      *0 | int
+								updatedReplicas:    *0 | int
+								observedGeneration: *0 | int
+								...
+							} & _live.status
+							healthy: _live.spec.replicas == _st.readyReplicas && _live.spec.replicas == _st.updatedReplicas && _st.observedGeneration >= _live.metadata.generation
+						}
+						if o.healthPolicy == "DaemonSet" {
+							_st: {
+								desiredNumberScheduled: *0 | int
+								numberReady:            *0 | int
+								updatedNumberScheduled: *0 | int
+								observedGeneration:     *0 | int
+								...
+							} & _live.status
+							_onDelete: [if _live.spec.updateStrategy != _|_ if _live.spec.updateStrategy.type == "OnDelete" {true}, false][0]
+							healthy: _st.desiredNumberScheduled == _st.numberReady && (_onDelete || _st.desiredNumberScheduled == _st.updatedNumberScheduled) && _st.observedGeneration >= _live.metadata.generation
+						}
+						if o.healthPolicy == "Job" {
+							_st: {
+								succeeded: *0 | int
+								...
+							} & _live.status
+							_completions: *1 | int
+							if _live.spec.completions != _|_ {
+								_completions: _live.spec.completions
+							}
+							healthy: _st.succeeded >= _completions
+						}
+						if o.healthPolicy == "CronJob" {
+							_st: {
+								active:           *[] | [...]
+								lastScheduleTime: *"never" | string
+								...
+							} & _live.status
+							healthy: true
+						}
+						if o.healthPolicy == "PersistentVolumeClaim" {
+							_st: {
+								phase: *"Pending" | string
+								...
+							} & _live.status
+							healthy: _st.phase == "Bound"
+						}
+						if o.healthPolicy == "Service" {
+							_st: {
+								loadBalancer: {
+									ingress: *[] | [...]
+									...
+								}
+								...
+							} & _live.status
+							_addresses: [
+								for i in _st.loadBalancer.ingress if i.ip != _|_ {i.ip},
+								for i in _st.loadBalancer.ingress if i.ip == _|_ && i.hostname != _|_ {i.hostname},
+								"pending",
+							]
+							_ingress: [if _live.spec.type == "LoadBalancer" {", ingress:\(_addresses[0])"}, ""][0]
+							healthy: _live.spec.type != "LoadBalancer" || len(_st.loadBalancer.ingress) > 0
+						}
+						if o.healthPolicy == "Ready" {
+							_st: {
+								conditions: *[] | [...]
+								...
+							} & _live.status
+							_ready: [for c in _st.conditions if c.type == "Ready" {c.status}, "Unknown"][0]
+							healthy: _ready == "True"
+						}
+					}
+				}]
+				isHealth: len([for c in _objectHealth if !c.healthy {c}]) == 0
+				"""#
+		}
+	}
 }
 template: {
+	objectsList: [for v in parameter.objects {
+		{for k, x in v if k != "healthPolicy" && k != "outputName" {(k): x}}
+	}]
+
 	output: {
-		if len(parameter.objects) > 0 {
-			parameter.objects[0]
+		if len(objectsList) > 0 {
+			objectsList[0]
 		}
 		...
 	}
 
 	outputs: {
-		for i, v in parameter.objects {
-			if i > 0 {
-				"objects-\(i)": v
+		for i, v in parameter.objects if i > 0 {
+			if v.outputName != _|_ {
+				(v.outputName): [
+					if v.outputName =~ "^objects-[0-9]+$" {error("objects[\(i)].outputName \(v.outputName) is reserved for the object at that index")},
+					if len([for j, w in parameter.objects if j > 0 && j < i if w.outputName != _|_ if w.outputName == v.outputName {j}]) > 0 {error("objects[\(i)].outputName \(v.outputName) is used by another object")},
+					objectsList[i],
+				][0]
+			}
+			if v.outputName == _|_ {
+				"objects-\(i)": objectsList[i]
 			}
 		}
 	}
+
 	parameter: {
-		objects: [...{}]
+		// +usage=Specify the Kubernetes objects to apply, the first one is the workload
+		objects: [...{
+			// +usage=Check the health of the object with the rule of its kind, or with the "Ready" condition of any object
+			healthPolicy?: "Deployment" | "StatefulSet" | "DaemonSet" | "Job" | "CronJob" | "PersistentVolumeClaim" | "Service" | "Ready"
+			// +usage=Name the output of the object instead of by its index, so that reordering the objects keeps their outputs. Must be unique and not of the form objects-<index>. Ignored for the first object
+			outputName?: string & =~"^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+		}]
 	}
 }
@@ -0,0 +1,286 @@
+"knative-service": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Describes serverless services run by Knative Serving, scaled on requests and split between revisions."
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "serving.knative.dev/v1"
+				kind:       "Service"
+			}
+			type: "services.serving.knative.dev"
+		}
+		status: {
+			customStatus: #"""
+				status: {
+					url: *"" | string
+				} & {
+					if context.output.status.url != _|_ {
+						url: context.output.status.url
+					}
+				}
+				_readyCond: *[] | [...]
+				if context.output.status.conditions != _|_ {
+					_readyCond: [for c in context.output.status.conditions if c.type == "Ready" {c}]
+				}
+				_readyStatus: *"Unknown" | string
+				_readyMessage: *"" | string
+				if len(_readyCond) > 0 {
+					_readyStatus: _readyCond[0].status
+					if _readyCond[0].message != _|_ {
+						_readyMessage: ", \(_readyCond[0].message)"
+					}
+				}
+				message: "Ready:\(_readyStatus), url:\(status.url)\(_readyMessage)"
+				"""#
+			healthPolicy: #"""
+				ready: {
+					observedGeneration: *0 | int
+				} & {
+					if context.output.status.observedGeneration != _|_ {
+						observedGeneration: context.output.status.observedGeneration
+					}
+				}
+				_readyCond: *[] | [...]
+				if context.output.status.conditions != _|_ {
+					_readyCond: [for c in context.output.status.conditions if c.type == "Ready" {c}]
+				}
+				_readyStatus: *"Unknown" | string
+				_readyMessage: *"" | string
+				if len(_readyCond) > 0 {
+					_readyStatus: _readyCond[0].status
+					if _readyCond[0].message != _|_ {
+						_readyMessage: ", \(_readyCond[0].message)"
+					}
+				}
+				isHealth: _readyStatus == "True" && ready.observedGeneration == context.output.metadata.generation
+				"""#
+		}
+	}
+}
+template: {
+	output: {
+		apiVersion: "serving.knative.dev/v1"
+		kind:       "Service"
+		spec: {
+			template: {
+				metadata: {
+					labels: {
+						if parameter["labels"] != _|_ {
+							parameter.labels
+						}
+						"app.oam.dev/name": context.appName
+						"app.oam.dev/component": context.name
+					}
+					annotations: {
+						if parameter["annotations"] != _|_ {
+							parameter.annotations
+						}
+						if parameter["maxScale"] != _|_ {
+							"autoscaling.knative.dev/max-scale": "\(parameter.maxScale)"
+						}
+						if parameter["minScale"] != _|_ {
+							"autoscaling.knative.dev/min-scale": "\(parameter.minScale)"
+						}
+					}
+					if parameter["revisionSuffix"] != _|_ {
+						name: "\(context.name)-\(parameter.revisionSuffix)"
+					}
+				}
+				spec: {
+					containers: [{
+						name: context.name
+						image: parameter.image
+						if parameter["env"] != _|_ {
+							env: parameter.env
+						}
+						if context["config"] != _|_ {
+							env: context.config
+						}
+						if parameter["cpu"] != _|_ && !(parameter.limit.cpu != _|_) {
+							resources: {
+								requests: {
+									cpu: parameter.cpu
+								}
+								limits: {
+									cpu: parameter.cpu
+								}
+							}
+						}
+						if parameter["cpu"] != _|_ && parameter.limit.cpu != _|_ {
+							resources: {
+								requests: {
+									cpu: parameter.cpu
+								}
+								limits: {
+									cpu: parameter.limit.cpu
+								}
+							}
+						}
+						if parameter["memory"] != _|_ && !(parameter.limit.memory != _|_) {
+							resources: {
+								requests: {
+									memory: parameter.memory
+								}
+								limits: {
+									memory: parameter.memory
+								}
+							}
+						}
+						if parameter["memory"] != _|_ && parameter.limit.memory != _|_ {
+							resources: {
+								requests: {
+									memory: parameter.memory
+								}
+								limits: {
+									memory: parameter.limit.memory
+								}
+							}
+						}
+						ports: [{
+							containerPort: parameter.port
+							name: parameter.portName
+						}]
+						if parameter["args"] != _|_ {
+							args: parameter.args
+						}
+						if parameter["cmd"] != _|_ {
+							command: parameter.cmd
+						}
+						if parameter["imagePullPolicy"] != _|_ {
+							imagePullPolicy: parameter.imagePullPolicy
+						}
+						if parameter["livenessProbe"] != _|_ {
+							livenessProbe: parameter.livenessProbe
+						}
+						if parameter["readinessProbe"] != _|_ {
+							readinessProbe: parameter.readinessProbe
+						}
+					}]
+					if parameter["containerConcurrency"] != _|_ {
+						containerConcurrency: parameter.containerConcurrency
+					}
+					if parameter["imagePullSecrets"] != _|_ {
+						imagePullSecrets: [for v in parameter.imagePullSecrets { name: v }]
+					}
+				}
+			}
+			if parameter["traffic"] != _|_ {
+				traffic: parameter.traffic
+			}
+		}
+	}
+	parameter: {
+		// +usage=Specify the labels of the revisions
+		labels?: [string]: string
+		// +usage=Specify the annotations of the revisions
+		annotations?: [string]: string
+		// +usage=Which image would you like to use for your service
+		// +short=i
+		image: string
+		// +usage=Specify image pull policy for your service
+		imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
+		// +usage=Specify image pull secrets for your service
+		imagePullSecrets?: [...string]
+		// +usage=Commands to run in the container
+		cmd?: [...string]
+		// +usage=Arguments to the entrypoint
+		args?: [...string]
+		// +usage=Define arguments by using environment variables
+		env?: [...{
+			// +usage=Environment variable name
+			name: string
+			// +usage=The value of the environment variable
+			value?: string
+			// +usage=Specifies a source the value of this var should come from
+			valueFrom?: {
+				// +usage=Selects a key of a secret in the pod's namespace
+				secretKeyRef?: {
+					// +usage=The name of the secret in the pod's namespace to select from
+					name: string
+					// +usage=The key of the secret to select from. Must be a valid secret key
+					key: string
+				}
+				// +usage=Selects a key of a config map in the pod's namespace
+				configMapKeyRef?: {
+					// +usage=The name of the config map in the pod's namespace to select from
+					name: string
+					// +usage=The key of the config map to select from. Must be a valid secret key
+					key: string
+				}
+			}
+		}]
+		// +usage=Number of the container port requests are sent to
+		// +short=p
+		port: *8080 | int
+		// +usage=Protocol of the port: "http1" for HTTP/1.1, "h2c" for HTTP/2 over cleartext, e.g. gRPC
+		portName: *"http1" | "h2c"
+		// +usage=Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+		cpu?: string
+		// +usage=Specifies the attributes of the memory resource required for the container.
+		memory?: string
+		limit?: {
+			cpu?: string
+			memory?: string
+		}
+		// +usage=Instructions for assessing whether the container is alive.
+		livenessProbe?: #HealthProbe
+		// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
+		readinessProbe?: #HealthProbe
+		// +usage=Minimum number of replicas of each revision, 0 allows scaling to zero
+		minScale?: int & >=0
+		// +usage=Maximum number of replicas of each revision, 0 means unlimited
+		maxScale?: int & >=0
+		// +usage=Maximum number of concurrent requests per replica, 0 means unlimited
+		containerConcurrency?: int & >=0
+		// +usage=Name the revision `<component>-<revisionSuffix>`, e.g. `v2`. It must change on every update and can be referenced by traffic
+		revisionSuffix?: string
+		// +usage=Specify how requests are split between revisions, defaults to all requests to the latest ready revision
+		traffic?: [...{
+			// +usage=Name of the revision receiving the traffic
+			revisionName?: string
+			// +usage=Send the traffic to the latest ready revision, instead of revisionName
+			latestRevision?: bool
+			// +usage=Percentage of the requests sent to the target
+			percent: int & >=0 & <=100
+			// +usage=Expose the target at its own URL prefixed by the tag
+			tag?: string
+		}]
+	}
+	#HealthProbe: {
+		// +usage=Instructions for assessing container health by executing a command. Either this attribute or the httpGet attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the httpGet attribute and the tcpSocket attribute.
+		exec?: {
+			// +usage=A command to be executed inside the container to assess its health. Each space delimited token of the command is a separate array element. Commands exiting 0 are considered to be successful probes, whilst all other exit codes are considered failures.
+			command: [...string]
+		}
+		// +usage=Instructions for assessing container health by executing an HTTP GET request. Either this attribute or the exec attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the exec attribute and the tcpSocket attribute.
+		httpGet?: {
+			// +usage=The endpoint, relative to the port, to which the HTTP GET request should be directed.
+			path: string
+			// +usage=The TCP socket within the container to which the HTTP GET request should be directed.
+			port: int
+			host?: string
+			scheme?: *"HTTP" | string
+			httpHeaders?: [...{
+				name: string
+				value: string
+			}]
+		}
+		// +usage=Instructions for assessing container health by probing a TCP socket. Either this attribute or the exec attribute or the httpGet attribute MUST be specified. This attribute is mutually exclusive with both the exec attribute and the httpGet attribute.
+		tcpSocket?: {
+			// +usage=The TCP socket within the container that should be probed to assess container health.
+			port: int
+		}
+		// +usage=Number of seconds after the container is started before the first probe is initiated.
+		initialDelaySeconds: *0 | int
+		// +usage=How often, in seconds, to execute the probe.
+		periodSeconds: *10 | int
+		// +usage=Number of seconds after which the probe times out.
+		timeoutSeconds: *1 | int
+		// +usage=Minimum consecutive successes for the probe to be considered successful after having failed.
+		successThreshold: *1 | int
+		// +usage=Number of consecutive failures required to determine the container is not alive (liveness probe) or not ready (readiness probe).
+		failureThreshold: *3 | int
+	}
+}
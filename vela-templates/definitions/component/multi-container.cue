@@ -0,0 +1,531 @@
+import (
+	"list"
+)
+
+"multi-container": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Describes long-running services whose pods run several containers, init containers and native sidecars."
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "apps/v1"
+				kind:       "Deployment"
+			}
+			type: "deployments.apps"
+		}
+		status: {
+			customStatus: #"""
+				ready: {
+					readyReplicas: *0 | int
+				} & {
+					if context.output.status.readyReplicas != _|_ {
+						readyReplicas: context.output.status.readyReplicas
+					}
+				}
+				message: "Ready:\(ready.readyReplicas)/\(context.output.spec.replicas)"
+				"""#
+			healthPolicy: #"""
+				ready: {
+					updatedReplicas:    *0 | int
+					readyReplicas:      *0 | int
+					replicas:           *0 | int
+					observedGeneration: *0 | int
+				} & {
+					if context.output.status.updatedReplicas != _|_ {
+						updatedReplicas: context.output.status.updatedReplicas
+					}
+					if context.output.status.readyReplicas != _|_ {
+						readyReplicas: context.output.status.readyReplicas
+					}
+					if context.output.status.replicas != _|_ {
+						replicas: context.output.status.replicas
+					}
+					if context.output.status.observedGeneration != _|_ {
+						observedGeneration: context.output.status.observedGeneration
+					}
+				}
+				_isHealth: (context.output.spec.replicas == ready.readyReplicas) && (context.output.spec.replicas == ready.updatedReplicas) && (context.output.spec.replicas == ready.replicas) && (ready.observedGeneration == context.output.metadata.generation || ready.observedGeneration > context.output.metadata.generation)
+				isHealth: *_isHealth | bool
+				if context.output.metadata.annotations != _|_ {
+					if context.output.metadata.annotations["app.oam.dev/disable-health-check"] != _|_ {
+						isHealth: true
+					}
+				}
+				"""#
+		}
+	}
+}
+template: {
+	sidecarsArray: [
+		if parameter["sidecars"] != _|_ for v in parameter.sidecars {
+			name: v.name
+			image: v.image
+			if v.imagePullPolicy != _|_ {
+				imagePullPolicy: v.imagePullPolicy
+			}
+			if v.cmd != _|_ {
+				command: v.cmd
+			}
+			if v.args != _|_ {
+				args: v.args
+			}
+			if v.env != _|_ {
+				env: v.env
+			}
+			if v.ports != _|_ {
+				ports: v.ports
+			}
+			if v.volumeMounts != _|_ {
+				volumeMounts: v.volumeMounts
+			}
+			if v.livenessProbe != _|_ {
+				livenessProbe: v.livenessProbe
+			}
+			if v.readinessProbe != _|_ {
+				readinessProbe: v.readinessProbe
+			}
+			if v.cpu != _|_ || v.memory != _|_ {
+				_resources: {if v.cpu != _|_ {cpu: v.cpu}, if v.memory != _|_ {memory: v.memory}}
+				resources: {requests: _resources, limits: _resources}
+			}
+			restartPolicy: "Always"
+		},
+	]
+	initContainersArray: [
+		if parameter["initContainers"] != _|_ for v in parameter.initContainers {
+			name: v.name
+			image: v.image
+			if v.imagePullPolicy != _|_ {
+				imagePullPolicy: v.imagePullPolicy
+			}
+			if v.cmd != _|_ {
+				command: v.cmd
+			}
+			if v.args != _|_ {
+				args: v.args
+			}
+			if v.env != _|_ {
+				env: v.env
+			}
+			if v.ports != _|_ {
+				ports: v.ports
+			}
+			if v.volumeMounts != _|_ {
+				volumeMounts: v.volumeMounts
+			}
+			if v.cpu != _|_ || v.memory != _|_ {
+				_resources: {if v.cpu != _|_ {cpu: v.cpu}, if v.memory != _|_ {memory: v.memory}}
+				resources: {requests: _resources, limits: _resources}
+			}
+		},
+	]
+	volumesList: [
+		if parameter.volumes != _|_ && parameter.volumes.pvc != _|_ for v in parameter.volumes.pvc {
+		{
+			name: v.name
+			persistentVolumeClaim: {
+				claimName: v.claimName
+			}
+		}
+		},
+		if parameter.volumes != _|_ && parameter.volumes.configMap != _|_ for v in parameter.volumes.configMap {
+		{
+			configMap: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
+				}
+				name: v.cmName
+			}
+			name: v.name
+		}
+		},
+		if parameter.volumes != _|_ && parameter.volumes.secret != _|_ for v in parameter.volumes.secret {
+		{
+			name: v.name
+			secret: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
+				}
+				secretName: v.secretName
+			}
+		}
+		},
+		if parameter.volumes != _|_ && parameter.volumes.emptyDir != _|_ for v in parameter.volumes.emptyDir {
+		{
+			emptyDir: {
+				medium: v.medium
+			}
+			name: v.name
+		}
+		},
+		if parameter.volumes != _|_ && parameter.volumes.hostPath != _|_ for v in parameter.volumes.hostPath {
+		{
+			hostPath: {
+				path: v.path
+			}
+			name: v.name
+		}
+		}
+	]
+	deDupVolumesList: [
+		for val in [
+			for i, vi in volumesList {
+				for j, vj in volumesList if j < i && vi.name == vj.name {
+					_ignore: true
+				}
+				vi
+			},
+		] if val._ignore == _|_ {
+			val
+		},
+	]
+	output: {
+		apiVersion: "apps/v1"
+		kind:       "Deployment"
+		spec: {
+			selector: {
+				matchLabels: {
+					"app.oam.dev/component": context.name
+				}
+			}
+			template: {
+				metadata: {
+					labels: {
+						if parameter["labels"] != _|_ {
+							parameter.labels
+						}
+						"app.oam.dev/name": context.appName
+						"app.oam.dev/component": context.name
+					}
+					if parameter["annotations"] != _|_ {
+						annotations: parameter.annotations
+					}
+				}
+				spec: {
+					containers: [
+		for v in parameter.containers {
+			name: v.name
+			image: v.image
+			if v.imagePullPolicy != _|_ {
+				imagePullPolicy: v.imagePullPolicy
+			}
+			if v.cmd != _|_ {
+				command: v.cmd
+			}
+			if v.args != _|_ {
+				args: v.args
+			}
+			if v.env != _|_ {
+				env: v.env
+			}
+			if v.ports != _|_ {
+				ports: v.ports
+			}
+			if v.volumeMounts != _|_ {
+				volumeMounts: v.volumeMounts
+			}
+			if v.livenessProbe != _|_ {
+				livenessProbe: v.livenessProbe
+			}
+			if v.readinessProbe != _|_ {
+				readinessProbe: v.readinessProbe
+			}
+			if v.cpu != _|_ || v.memory != _|_ {
+				_resources: {if v.cpu != _|_ {cpu: v.cpu}, if v.memory != _|_ {memory: v.memory}}
+				resources: {requests: _resources, limits: _resources}
+			}
+		},
+	]
+					if parameter["imagePullSecrets"] != _|_ {
+						imagePullSecrets: [for v in parameter.imagePullSecrets { name: v }]
+					}
+					if parameter["sidecars"] != _|_ || parameter["initContainers"] != _|_ {
+						initContainers: list.Concat([sidecarsArray, initContainersArray])
+					}
+					if parameter["volumes"] != _|_ {
+						volumes: deDupVolumesList
+					}
+				}
+			}
+		}
+	}
+	parameter: {
+		// +usage=Specify the labels in the workload
+		labels?: [string]: string
+		// +usage=Specify the annotations in the workload
+		annotations?: [string]: string
+		// +usage=Specify image pull secrets for your service
+		imagePullSecrets?: [...string]
+		// +usage=Specify the containers of the pod
+		containers: list.MinItems(1) & [...{
+			// +usage=Name of the container, unique in the pod
+			name: string
+			// +usage=Image of the container
+			image: string
+			// +usage=Specify image pull policy of the container
+			imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
+			// +usage=Commands to run in the container
+			cmd?: [...string]
+			// +usage=Arguments to the entrypoint
+			args?: [...string]
+			// +usage=Define arguments by using environment variables
+			env?: [...{
+				// +usage=Environment variable name
+				name: string
+				// +usage=The value of the environment variable
+				value?: string
+				// +usage=Specifies a source the value of this var should come from
+				valueFrom?: {
+					// +usage=Selects a key of a secret in the pod's namespace
+					secretKeyRef?: {
+						// +usage=The name of the secret in the pod's namespace to select from
+						name: string
+						// +usage=The key of the secret to select from. Must be a valid secret key
+						key: string
+					}
+					// +usage=Selects a key of a config map in the pod's namespace
+					configMapKeyRef?: {
+						// +usage=The name of the config map in the pod's namespace to select from
+						name: string
+						// +usage=The key of the config map to select from. Must be a valid secret key
+						key: string
+					}
+				}
+			}]
+			// +usage=Ports the container listens on
+			ports?: [...{
+				// +usage=Number of port to expose on the pod's IP address
+				containerPort: int
+				// +usage=Name of the port
+				name?: string
+				// +usage=Protocol for port. Must be UDP, TCP, or SCTP
+				protocol: *"TCP" | "UDP" | "SCTP"
+			}]
+			// +usage=Number of CPU units for the container, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+			cpu?: string
+			// +usage=Specifies the attributes of the memory resource required for the container.
+			memory?: string
+			// +usage=Mount the shared volumes into the container
+			volumeMounts?: [...{
+				// +usage=Name of a volume in volumes
+				name: string
+				// +usage=Path the volume is mounted at
+				mountPath: string
+				// +usage=Path within the volume to mount
+				subPath?: string
+				// +usage=Mount the volume read-only
+				readOnly?: bool
+			}]
+			// +usage=Instructions for assessing whether the container is alive.
+			livenessProbe?: #HealthProbe
+			// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
+			readinessProbe?: #HealthProbe
+		}]
+		// +usage=Specify the native sidecars, started before the init containers and running next to the containers
+		sidecars?: [...{
+			// +usage=Name of the container, unique in the pod
+			name: string
+			// +usage=Image of the container
+			image: string
+			// +usage=Specify image pull policy of the container
+			imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
+			// +usage=Commands to run in the container
+			cmd?: [...string]
+			// +usage=Arguments to the entrypoint
+			args?: [...string]
+			// +usage=Define arguments by using environment variables
+			env?: [...{
+				// +usage=Environment variable name
+				name: string
+				// +usage=The value of the environment variable
+				value?: string
+				// +usage=Specifies a source the value of this var should come from
+				valueFrom?: {
+					// +usage=Selects a key of a secret in the pod's namespace
+					secretKeyRef?: {
+						// +usage=The name of the secret in the pod's namespace to select from
+						name: string
+						// +usage=The key of the secret to select from. Must be a valid secret key
+						key: string
+					}
+					// +usage=Selects a key of a config map in the pod's namespace
+					configMapKeyRef?: {
+						// +usage=The name of the config map in the pod's namespace to select from
+						name: string
+						// +usage=The key of the config map to select from. Must be a valid secret key
+						key: string
+					}
+				}
+			}]
+			// +usage=Ports the container listens on
+			ports?: [...{
+				// +usage=Number of port to expose on the pod's IP address
+				containerPort: int
+				// +usage=Name of the port
+				name?: string
+				// +usage=Protocol for port. Must be UDP, TCP, or SCTP
+				protocol: *"TCP" | "UDP" | "SCTP"
+			}]
+			// +usage=Number of CPU units for the container, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+			cpu?: string
+			// +usage=Specifies the attributes of the memory resource required for the container.
+			memory?: string
+			// +usage=Mount the shared volumes into the container
+			volumeMounts?: [...{
+				// +usage=Name of a volume in volumes
+				name: string
+				// +usage=Path the volume is mounted at
+				mountPath: string
+				// +usage=Path within the volume to mount
+				subPath?: string
+				// +usage=Mount the volume read-only
+				readOnly?: bool
+			}]
+			// +usage=Instructions for assessing whether the container is alive.
+			livenessProbe?: #HealthProbe
+			// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
+			readinessProbe?: #HealthProbe
+		}]
+		// +usage=Specify the init containers, run to completion in order before the containers start
+		initContainers?: [...{
+			// +usage=Name of the container, unique in the pod
+			name: string
+			// +usage=Image of the container
+			image: string
+			// +usage=Specify image pull policy of the container
+			imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
+			// +usage=Commands to run in the container
+			cmd?: [...string]
+			// +usage=Arguments to the entrypoint
+			args?: [...string]
+			// +usage=Define arguments by using environment variables
+			env?: [...{
+				// +usage=Environment variable name
+				name: string
+				// +usage=The value of the environment variable
+				value?: string
+				// +usage=Specifies a source the value of this var should come from
+				valueFrom?: {
+					// +usage=Selects a key of a secret in the pod's namespace
+					secretKeyRef?: {
+						// +usage=The name of the secret in the pod's namespace to select from
+						name: string
+						// +usage=The key of the secret to select from. Must be a valid secret key
+						key: string
+					}
+					// +usage=Selects a key of a config map in the pod's namespace
+					configMapKeyRef?: {
+						// +usage=The name of the config map in the pod's namespace to select from
+						name: string
+						// +usage=The key of the config map to select from. Must be a valid secret key
+						key: string
+					}
+				}
+			}]
+			// +usage=Ports the container listens on
+			ports?: [...{
+				// +usage=Number of port to expose on the pod's IP address
+				containerPort: int
+				// +usage=Name of the port
+				name?: string
+				// +usage=Protocol for port. Must be UDP, TCP, or SCTP
+				protocol: *"TCP" | "UDP" | "SCTP"
+			}]
+			// +usage=Number of CPU units for the container, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+			cpu?: string
+			// +usage=Specifies the attributes of the memory resource required for the container.
+			memory?: string
+			// +usage=Mount the shared volumes into the container
+			volumeMounts?: [...{
+				// +usage=Name of a volume in volumes
+				name: string
+				// +usage=Path the volume is mounted at
+				mountPath: string
+				// +usage=Path within the volume to mount
+				subPath?: string
+				// +usage=Mount the volume read-only
+				readOnly?: bool
+			}]
+		}]
+		// +usage=Specify the volumes of the pod, mounted by name in the containers
+		volumes?: {
+			// +usage=PVC type volumes
+			pvc?: [...{
+				name: string
+				// +usage=The name of the PVC
+				claimName: string
+			}]
+			// +usage=ConfigMap type volumes
+			configMap?: [...{
+				name: string
+				defaultMode: *420 | int
+				cmName: string
+				items?: [...{
+					key: string
+					path: string
+					mode: *511 | int
+				}]
+			}]
+			// +usage=Secret type volumes
+			secret?: [...{
+				name: string
+				defaultMode: *420 | int
+				secretName: string
+				items?: [...{
+					key: string
+					path: string
+					mode: *511 | int
+				}]
+			}]
+			// +usage=EmptyDir type volumes
+			emptyDir?: [...{
+				name: string
+				medium: *"" | "Memory"
+			}]
+			// +usage=HostPath type volumes
+			hostPath?: [...{
+				name: string
+				path: string
+			}]
+		}
+	}
+	#HealthProbe: {
+		// +usage=Instructions for assessing container health by executing a command. Either this attribute or the httpGet attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the httpGet attribute and the tcpSocket attribute.
+		exec?: {
+			// +usage=A command to be executed inside the container to assess its health. Each space delimited token of the command is a separate array element. Commands exiting 0 are considered to be successful probes, whilst all other exit codes are considered failures.
+			command: [...string]
+		}
+		// +usage=Instructions for assessing container health by executing an HTTP GET request. Either this attribute or the exec attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the exec attribute and the tcpSocket attribute.
+		httpGet?: {
+			// +usage=The endpoint, relative to the port, to which the HTTP GET request should be directed.
+			path: string
+			// +usage=The TCP socket within the container to which the HTTP GET request should be directed.
+			port: int
+			host?: string
+			scheme?: *"HTTP" | string
+			httpHeaders?: [...{
+				name: string
+				value: string
+			}]
+		}
+		// +usage=Instructions for assessing container health by probing a TCP socket. Either this attribute or the exec attribute or the httpGet attribute MUST be specified. This attribute is mutually exclusive with both the exec attribute and the httpGet attribute.
+		tcpSocket?: {
+			// +usage=The TCP socket within the container that should be probed to assess container health.
+			port: int
+		}
+		// +usage=Number of seconds after the container is started before the first probe is initiated.
+		initialDelaySeconds: *0 | int
+		// +usage=How often, in seconds, to execute the probe.
+		periodSeconds: *10 | int
+		// +usage=Number of seconds after which the probe times out.
+		timeoutSeconds: *1 | int
+		// +usage=Minimum consecutive successes for the probe to be considered successful after having failed.
+		successThreshold: *1 | int
+		// +usage=Number of consecutive failures required to determine the container is not alive (liveness probe) or not ready (readiness probe).
+		failureThreshold: *3 | int
+	}
+}
@@ -0,0 +1,176 @@
+"postgres-cluster": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Describes a PostgreSQL cluster run by CloudNativePG, with a bootstrap database and scheduled backups."
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "postgresql.cnpg.io/v1"
+				kind:       "Cluster"
+			}
+			type: "clusters.postgresql.cnpg.io"
+		}
+		status: {
+			customStatus: #"""
+				message: *"" | string
+				if context.output.status != _|_ {
+					_st: {
+						readyInstances: *0 | int
+						phase:          *"Pending" | string
+						conditions:     *[] | [...]
+						...
+					} & context.output.status
+					_ready: [for c in _st.conditions if c.type == "Ready" {c.status}, "Unknown"][0]
+					message: "Ready:\(_st.readyInstances)/\(context.output.spec.instances), phase:\(_st.phase), secret:\(context.output.spec.bootstrap.initdb.secret.name)"
+				}
+				"""#
+			healthPolicy: #"""
+				healthy: *false | bool
+				if context.output.status != _|_ {
+					_st: {
+						readyInstances: *0 | int
+						phase:          *"Pending" | string
+						conditions:     *[] | [...]
+						...
+					} & context.output.status
+					_ready: [for c in _st.conditions if c.type == "Ready" {c.status}, "Unknown"][0]
+					healthy: _ready == "True" && _st.readyInstances == context.output.spec.instances
+				}
+				isHealth: healthy
+				"""#
+		}
+	}
+}
+template: {
+	output: {
+		apiVersion: "postgresql.cnpg.io/v1"
+		kind:       "Cluster"
+		spec: {
+			instances: parameter.instances
+			imageName: "ghcr.io/cloudnative-pg/postgresql:\(parameter.version)"
+			storage: {
+				size: parameter.storage.size
+				if parameter.storage.storageClass != _|_ {
+					storageClass: parameter.storage.storageClass
+				}
+			}
+			bootstrap: {
+				initdb: {
+					database: parameter.database
+					owner: parameter.owner
+					if parameter["credentialsSecret"] != _|_ && parameter["password"] != _|_ {
+						secret: {
+							name: error("set either password or credentialsSecret, not both")
+						}
+					}
+					if parameter["credentialsSecret"] != _|_ && parameter["password"] == _|_ {
+						secret: {
+							name: parameter.credentialsSecret
+						}
+					}
+					if parameter["credentialsSecret"] == _|_ && parameter["password"] != _|_ {
+						secret: {
+							name: "\(context.name)-connection"
+						}
+					}
+					if parameter["credentialsSecret"] == _|_ && parameter["password"] == _|_ {
+						secret: {
+							name: error("password or credentialsSecret is required")
+						}
+					}
+				}
+			}
+			if parameter["backup"] != _|_ {
+				backup: {
+	if parameter.backup.method == "barmanObjectStore" && parameter.backup.barmanObjectStore != _|_ {barmanObjectStore: parameter.backup.barmanObjectStore}
+	if parameter.backup.method == "barmanObjectStore" && parameter.backup.barmanObjectStore == _|_ {barmanObjectStore: error("backup.barmanObjectStore is required with method barmanObjectStore")}
+	if parameter.backup.method == "volumeSnapshot" {volumeSnapshot: {if parameter.backup.volumeSnapshotClass != _|_ {className: parameter.backup.volumeSnapshotClass}}}
+	if parameter.backup.retentionPolicy != _|_ {retentionPolicy: parameter.backup.retentionPolicy}
+}
+			}
+			if parameter["resources"] != _|_ {
+				resources: parameter.resources
+			}
+		}
+	}
+	outputs: {
+		if parameter["password"] != _|_ {
+			connection: {
+				apiVersion: "v1"
+				kind:       "Secret"
+				metadata: {
+					name: "\(context.name)-connection"
+				}
+				type: "kubernetes.io/basic-auth"
+				stringData: {
+					username: parameter.owner
+					password: parameter.password
+					DB_HOST: "\(context.name)-rw.\(context.namespace).svc"
+					DB_PORT: "5432"
+					DB_NAME: parameter.database
+					DB_USER: parameter.owner
+					DB_PASSWORD: parameter.password
+				}
+			}
+		}
+		if parameter["backup"] != _|_ {
+			scheduledBackup: {
+				apiVersion: "postgresql.cnpg.io/v1"
+				kind:       "ScheduledBackup"
+				metadata: {
+					name: context.name
+				}
+				spec: {
+					cluster: {
+						name: context.name
+					}
+					schedule: parameter.backup.schedule
+					method: parameter.backup.method
+					backupOwnerReference: "self"
+				}
+			}
+		}
+	}
+	parameter: {
+		// +usage=Number of PostgreSQL instances, one primary and the others replicas
+		instances: *1 | int & >=1
+		// +usage=PostgreSQL version, the tag of the ghcr.io/cloudnative-pg/postgresql image, e.g. 16.4
+		version: *"16" | string
+		// +usage=Storage of each instance
+		storage: {
+			// +usage=Size of the volume of each instance
+			size: *"1Gi" | string
+			// +usage=Storage class of the volumes, defaults to the cluster default
+			storageClass?: string
+		}
+		// +usage=Name of the database created on bootstrap
+		database: *"app" | string
+		// +usage=Name of the user owning the database
+		owner: *"app" | string
+		// +usage=Password of the owner, kept in plain text in the application and written to the <name>-connection Secret read by the generate-jdbc-connection step. Prefer credentialsSecret unless that step is needed. Either password or credentialsSecret is required
+		password?: string
+		// +usage=Existing kubernetes.io/basic-auth Secret with the username of the owner and its password, used instead of password. No <name>-connection Secret is written, so the generate-jdbc-connection step is not supported
+		credentialsSecret?: string
+		// +usage=Specify the resources of each instance
+		resources?: {
+			// +usage=Minimum resources, e.g. `cpu: "500m"`
+			requests?: {...}
+			// +usage=Maximum resources, e.g. `memory: "1Gi"`
+			limits?: {...}
+		}
+		// +usage=Back the cluster up on a schedule
+		backup?: {
+			// +usage=Schedule of the backups, a cron expression with seconds, e.g. `0 0 2 * * *`
+			schedule: string
+			// +usage=Back up to an object store, or take snapshots of the volumes
+			method: *"barmanObjectStore" | "volumeSnapshot"
+			// +usage=Object store the backups are sent to, e.g. `destinationPath` and `s3Credentials`, required with method barmanObjectStore
+			barmanObjectStore?: {...}
+			// +usage=VolumeSnapshotClass of the snapshots, with method volumeSnapshot
+			volumeSnapshotClass?: string
+			// +usage=How long backups are kept, e.g. `30d`
+			retentionPolicy?: string
+		}
+	}
+}
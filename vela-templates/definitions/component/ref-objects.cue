@@ -9,46 +9,206 @@
 		workload: type: "autodetects.core.oam.dev"
 		status: {
 			customStatus: #"""
-				if context.output.apiVersion == "apps/v1" && context.output.kind == "Deployment" {
-					ready: {
-						readyReplicas: *0 | int
-					} & {
-						if context.output.status.readyReplicas != _|_ {
-							readyReplicas: context.output.status.readyReplicas
+				_rules: [
+					if context.output.apiVersion == "apps/v1" && context.output.kind == "Deployment" {"Deployment"},
+					if context.output.apiVersion == "apps/v1" && context.output.kind == "StatefulSet" {"StatefulSet"},
+					if context.output.apiVersion == "apps/v1" && context.output.kind == "DaemonSet" {"DaemonSet"},
+					if context.output.apiVersion == "batch/v1" && context.output.kind == "Job" {"Job"},
+					if context.output.apiVersion == "batch/v1" && context.output.kind == "CronJob" {"CronJob"},
+					if context.output.apiVersion == "v1" && context.output.kind == "PersistentVolumeClaim" {"PersistentVolumeClaim"},
+					if context.output.apiVersion == "v1" && context.output.kind == "Service" {"Service"},
+					if context.output.status.conditions != _|_ for c in context.output.status.conditions if c.type == "Ready" {"Ready"},
+					"",
+				]
+				_rule: _rules[0]
+				message: *"" | string
+				if context.output.status != _|_ {
+					if _rule == "Deployment" {
+						_st: {
+							replicas:           *0 | int
+							readyReplicas:      *0 | int
+							updatedReplicas:    *0 | int
+							observedGeneration: *0 | int
+							...
+						} & context.output.status
+						message: "Ready:\(_st.readyReplicas)/\(context.output.spec.replicas)"
+					}
+					if _rule == "StatefulSet" {
+						_st: {
+							replicas:           *0 | int
+							readyReplicas:      *0 | int
+							updatedReplicas:    *0 | int
+							observedGeneration: *0 | int
+							...
+						} & context.output.status
+						message: "Ready:\(_st.readyReplicas)/\(context.output.spec.replicas)"
+					}
+					if _rule == "DaemonSet" {
+						_st: {
+							desiredNumberScheduled: *0 | int
+							numberReady:            *0 | int
+							updatedNumberScheduled: *0 | int
+							observedGeneration:     *0 | int
+							...
+						} & context.output.status
+						_onDelete: [if context.output.spec.updateStrategy != _|_ if context.output.spec.updateStrategy.type == "OnDelete" {true}, false][0]
+						message: "Ready:\(_st.numberReady)/\(_st.desiredNumberScheduled)"
+					}
+					if _rule == "Job" {
+						_st: {
+							succeeded: *0 | int
+							...
+						} & context.output.status
+						_completions: *1 | int
+						if context.output.spec.completions != _|_ {
+							_completions: context.output.spec.completions
 						}
+						message: "Succeeded:\(_st.succeeded)/\(_completions)"
+					}
+					if _rule == "CronJob" {
+						_st: {
+							active:           *[] | [...]
+							lastScheduleTime: *"never" | string
+							...
+						} & context.output.status
+						message: "Active:\(len(_st.active)), last schedule:\(_st.lastScheduleTime)"
+					}
+					if _rule == "PersistentVolumeClaim" {
+						_st: {
+							phase: *"Pending" | string
+							...
+						} & context.output.status
+						message: "Phase:\(_st.phase)"
+					}
+					if _rule == "Service" {
+						_st: {
+							loadBalancer: {
+								ingress: *[] | [...]
+								...
+							}
+							...
+						} & context.output.status
+						_addresses: [
+							for i in _st.loadBalancer.ingress if i.ip != _|_ {i.ip},
+							for i in _st.loadBalancer.ingress if i.ip == _|_ && i.hostname != _|_ {i.hostname},
+							"pending",
+						]
+						_ingress: [if context.output.spec.type == "LoadBalancer" {", ingress:\(_addresses[0])"}, ""][0]
+						message: "Type:\(context.output.spec.type)\(_ingress)"
+					}
+					if _rule == "Ready" {
+						_st: {
+							conditions: *[] | [...]
+							...
+						} & context.output.status
+						_ready: [for c in _st.conditions if c.type == "Ready" {c.status}, "Unknown"][0]
+						message: "Ready:\(_ready)"
 					}
-					message: "Ready:\(ready.readyReplicas)/\(context.output.spec.replicas)"
-				}
-				if context.output.apiVersion != "apps/v1" || context.output.kind != "Deployment" {
-					message: ""
 				}
 				"""#
 			healthPolicy: #"""
-				if context.output.apiVersion == "apps/v1" && context.output.kind == "Deployment" {
-					ready: {
-						updatedReplicas:    *0 | int
-						readyReplicas:      *0 | int
-						replicas:           *0 | int
-						observedGeneration: *0 | int
-					} & {
-						if context.output.status.updatedReplicas != _|_ {
-							updatedReplicas: context.output.status.updatedReplicas
-						}
-						if context.output.status.readyReplicas != _|_ {
-							readyReplicas: context.output.status.readyReplicas
-						}
-						if context.output.status.replicas != _|_ {
-							replicas: context.output.status.replicas
-						}
-						if context.output.status.observedGeneration != _|_ {
-							observedGeneration: context.output.status.observedGeneration
+				_rules: [
+					if context.output.apiVersion == "apps/v1" && context.output.kind == "Deployment" {"Deployment"},
+					if context.output.apiVersion == "apps/v1" && context.output.kind == "StatefulSet" {"StatefulSet"},
+					if context.output.apiVersion == "apps/v1" && context.output.kind == "DaemonSet" {"DaemonSet"},
+					if context.output.apiVersion == "batch/v1" && context.output.kind == "Job" {"Job"},
+					if context.output.apiVersion == "batch/v1" && context.output.kind == "CronJob" {"CronJob"},
+					if context.output.apiVersion == "v1" && context.output.kind == "PersistentVolumeClaim" {"PersistentVolumeClaim"},
+					if context.output.apiVersion == "v1" && context.output.kind == "Service" {"Service"},
+					if context.output.status.conditions != _|_ for c in context.output.status.conditions if c.type == "Ready" {"Ready"},
+					"",
+				]
+				_rule: _rules[0]
+				healthy: *false | bool
+				if context.output.status != _|_ {
+					if _rule == "Deployment" {
+						_st: {
+							replicas:           *0 | int
+							readyReplicas:      *0 | int
+							updatedReplicas:    *0 | int
+							observedGeneration: *0 | int
+							...
+						} & context.output.status
+						healthy: context.output.spec.replicas == _st.readyReplicas && context.output.spec.replicas == _st.updatedReplicas && context.output.spec.replicas == _st.replicas && _st.observedGeneration >= context.output.metadata.generation
+					}
+					if _rule == "StatefulSet" {
+						_st: {
+							replicas:           *0 | int
+							readyReplicas:      *0 | int
+							updatedReplicas:    *0 | int
+							observedGeneration: *0 | int
+							...
+						} & context.output.status
+						healthy: context.output.spec.replicas == _st.readyReplicas && context.output.spec.replicas == _st.updatedReplicas && _st.observedGeneration >= context.output.metadata.generation
+					}
+					if _rule == "DaemonSet" {
+						_st: {
+							desiredNumberScheduled: *0 | int
+							numberReady:            *0 | int
+							updatedNumberScheduled: *0 | int
+							observedGeneration:     *0 | int
+							...
+						} & context.output.status
+						_onDelete: [if context.output.spec.updateStrategy != _|_ if context.output.spec.updateStrategy.type == "OnDelete" {true}, false][0]
+						healthy: _st.desiredNumberScheduled == _st.numberReady && (_onDelete || _st.desiredNumberScheduled == _st.updatedNumberScheduled) && _st.observedGeneration >= context.output.metadata.generation
+					}
+					if _rule == "Job" {
+						_st: {
+							succeeded: *0 | int
+							...
+						} & context.output.status
+						_completions: *1 | int
+						if context.output.spec.completions != _|_ {
+							_completions: context.output.spec.completions
 						}
+						healthy: _st.succeeded >= _completions
+					}
+					if _rule == "CronJob" {
+						_st: {
+							active:           *[] | [...]
+							lastScheduleTime: *"never" | string
+							...
+						} & context.output.status
+						healthy: true
+					}
+					if _rule == "PersistentVolumeClaim" {
+						_st: {
+							phase: *"Pending" | string
+							...
+						} & context.output.status
+						healthy: _st.phase == "Bound"
+					}
+					if _rule == "Service" {
+						_st: {
+							loadBalancer: {
+								ingress: *[] | [...]
+								...
+							}
+							...
+						} & context.output.status
+						_addresses: [
+							for i in _st.loadBalancer.ingress if i.ip != _|_ {i.ip},
+							for i in _st.loadBalancer.ingress if i.ip == _|_ && i.hostname != _|_ {i.hostname},
+							"pending",
+						]
+						_ingress: [if context.output.spec.type == "LoadBalancer" {", ingress:\(_addresses[0])"}, ""][0]
+						healthy: context.output.spec.type != "LoadBalancer" || len(_st.loadBalancer.ingress) > 0
+					}
+					if _rule == "Ready" {
+						_st: {
+							conditions: *[] | [...]
+							...
+						} & context.output.status
+						_ready: [for c in _st.conditions if c.type == "Ready" {c.status}, "Unknown"][0]
+						healthy: _ready == "True"
 					}
-					isHealth: (context.output.spec.replicas == ready.readyReplicas) && (context.output.spec.replicas == ready.updatedReplicas) && (context.output.spec.replicas == ready.replicas) && (ready.observedGeneration == context.output.metadata.generation || ready.observedGeneration > context.output.metadata.generation)
 				}
-				if context.output.apiVersion != "apps/v1" || context.output.kind != "Deployment" {
+				if _rule == "" {
 					isHealth: true
 				}
+				if _rule != "" {
+					isHealth: healthy
+				}
 				"""#
 		}
 	}
@@ -0,0 +1,640 @@
+import (
+	"list"
+	"strconv"
+)
+
+"rollout-service": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Describes long-running services progressively delivered by Argo Rollouts with canary steps or a blue-green switch."
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "argoproj.io/v1alpha1"
+				kind:       "Rollout"
+			}
+			type: "rollouts.argoproj.io"
+		}
+		status: {
+			customStatus: #"""
+				status: {
+					phase:         *"Progressing" | string
+					readyReplicas: *0 | int
+					message:       *"" | string
+				} & {
+					if context.output.status.phase != _|_ {
+						phase: context.output.status.phase
+					}
+					if context.output.status.readyReplicas != _|_ {
+						readyReplicas: context.output.status.readyReplicas
+					}
+					if context.output.status.message != _|_ {
+						message: context.output.status.message
+					}
+				}
+				message: "Phase:\(status.phase), ready:\(status.readyReplicas)/\(context.output.spec.replicas) \(status.message)"
+				"""#
+			healthPolicy: #"""
+				ready: {
+					phase:              *"" | string
+					observedGeneration: *"" | string
+				} & {
+					if context.output.status.phase != _|_ {
+						phase: context.output.status.phase
+					}
+					if context.output.status.observedGeneration != _|_ {
+						observedGeneration: context.output.status.observedGeneration
+					}
+				}
+				isHealth: (ready.phase == "Healthy") && (ready.observedGeneration == "\(context.output.metadata.generation)")
+				"""#
+		}
+	}
+}
+template: {
+	mountsArray: [
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.pvc != _|_ for v in parameter.volumeMounts.pvc {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.configMap != _|_ for v in parameter.volumeMounts.configMap {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.secret != _|_ for v in parameter.volumeMounts.secret {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.emptyDir != _|_ for v in parameter.volumeMounts.emptyDir {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.hostPath != _|_ for v in parameter.volumeMounts.hostPath {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
+		}
+		}
+	]
+	volumesList: [
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.pvc != _|_ for v in parameter.volumeMounts.pvc {
+		{
+			name: v.name
+			persistentVolumeClaim: {
+				claimName: v.claimName
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.configMap != _|_ for v in parameter.volumeMounts.configMap {
+		{
+			configMap: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
+				}
+				name: v.cmName
+			}
+			name: v.name
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.secret != _|_ for v in parameter.volumeMounts.secret {
+		{
+			name: v.name
+			secret: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
+				}
+				secretName: v.secretName
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.emptyDir != _|_ for v in parameter.volumeMounts.emptyDir {
+		{
+			emptyDir: {
+				medium: v.medium
+			}
+			name: v.name
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.hostPath != _|_ for v in parameter.volumeMounts.hostPath {
+		{
+			hostPath: {
+				path: v.path
+			}
+			name: v.name
+		}
+		}
+	]
+	deDupVolumesArray: [
+		for val in [
+			for i, vi in volumesList {
+				for j, vj in volumesList if j < i && vi.name == vj.name {
+					_ignore: true
+				}
+				vi
+			},
+		] if val._ignore == _|_ {
+			val
+		},
+	]
+	output: {
+		apiVersion: "argoproj.io/v1alpha1"
+		kind:       "Rollout"
+		spec: {
+			replicas: parameter.replicas
+			selector: {
+				matchLabels: {
+					"app.oam.dev/component": context.name
+				}
+			}
+			template: {
+				metadata: {
+					labels: {
+						if parameter["labels"] != _|_ {
+							parameter.labels
+						}
+						"app.oam.dev/name": context.appName
+						"app.oam.dev/component": context.name
+					}
+					if parameter["annotations"] != _|_ {
+						annotations: parameter.annotations
+					}
+				}
+				spec: {
+					containers: [{
+						name: context.name
+						image: parameter.image
+						if parameter["env"] != _|_ {
+							env: parameter.env
+						}
+						if context["config"] != _|_ {
+							env: context.config
+						}
+						if parameter["cpu"] != _|_ && !(parameter.limit.cpu != _|_) {
+							resources: {
+								requests: {
+									cpu: parameter.cpu
+								}
+								limits: {
+									cpu: parameter.cpu
+								}
+							}
+						}
+						if parameter["cpu"] != _|_ && parameter.limit.cpu != _|_ {
+							resources: {
+								requests: {
+									cpu: parameter.cpu
+								}
+								limits: {
+									cpu: parameter.limit.cpu
+								}
+							}
+						}
+						if parameter["memory"] != _|_ && !(parameter.limit.memory != _|_) {
+							resources: {
+								requests: {
+									memory: parameter.memory
+								}
+								limits: {
+									memory: parameter.memory
+								}
+							}
+						}
+						if parameter["memory"] != _|_ && parameter.limit.memory != _|_ {
+							resources: {
+								requests: {
+									memory: parameter.memory
+								}
+								limits: {
+									memory: parameter.limit.memory
+								}
+							}
+						}
+						if parameter["volumeMounts"] != _|_ {
+							volumeMounts: mountsArray
+						}
+						if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
+							volumeMounts: [for v in parameter.volumes {
+				{
+					mountPath: v.mountPath
+					name: v.name
+				}
+			}]
+						}
+						ports: [for v in parameter.ports {
+				{
+					containerPort: v.port
+					name: *v.name | "port-" + strconv.FormatInt(v.port, 10)
+					protocol: v.protocol
+				}
+			}]
+						if parameter["args"] != _|_ {
+							args: parameter.args
+						}
+						if parameter["cmd"] != _|_ {
+							command: parameter.cmd
+						}
+						if parameter["imagePullPolicy"] != _|_ {
+							imagePullPolicy: parameter.imagePullPolicy
+						}
+						if parameter["livenessProbe"] != _|_ {
+							livenessProbe: parameter.livenessProbe
+						}
+						if parameter["readinessProbe"] != _|_ {
+							readinessProbe: parameter.readinessProbe
+						}
+					}]
+					if parameter["volumeMounts"] != _|_ {
+						volumes: deDupVolumesArray
+					}
+					if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
+						volumes: [for v in parameter.volumes {
+				{
+					name: v.name
+					if v.type == "pvc" {
+						persistentVolumeClaim: {
+				claimName: v.claimName
+			}
+					}
+					if v.type == "configMap" {
+						configMap: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
+				}
+				name: v.cmName
+			}
+					}
+					if v.type == "secret" {
+						secret: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
+				}
+				secretName: v.secretName
+			}
+					}
+					if v.type == "emptyDir" {
+						emptyDir: {
+				medium: v.medium
+			}
+					}
+				}
+			}]
+					}
+					if parameter["hostAliases"] != _|_ {
+						// +patchKey=ip
+						hostAliases: parameter.hostAliases
+					}
+					if parameter["imagePullSecrets"] != _|_ {
+						imagePullSecrets: [for v in parameter.imagePullSecrets { name: v }]
+					}
+				}
+			}
+			strategy: {
+				if parameter.strategy == "canary" && parameter["steps"] != _|_ {
+					canary: {
+						steps: [for i, s in parameter.steps {
+	_kinds: [for k in ["setWeight", "pause", "analysis"] if s[k] != _|_ {k}]
+	if len(_kinds) != 1 {error("steps[\(i)] must set exactly one of setWeight, pause or analysis")}
+	s
+}]
+					}
+				}
+				if parameter.strategy == "canary" && parameter["steps"] == _|_ {
+					canary: {
+						steps: []
+					}
+				}
+				if parameter.strategy == "blueGreen" {
+					blueGreen: [
+	if parameter["steps"] != _|_ {error("steps is only valid with strategy canary")},
+	parameter.blueGreen & {activeService: context.name, previewService: "\(context.name)-preview"},
+][0]
+				}
+			}
+		}
+	}
+	outputs: {
+		rolloutService: {
+			apiVersion: "v1"
+			kind:       "Service"
+			metadata: {
+				name: context.name
+			}
+			spec: {
+				selector: {
+					"app.oam.dev/component": context.name
+				}
+				ports: [for v in parameter.ports {
+				{
+					name: *v.name | "port-" + strconv.FormatInt(*v.port | v.containerPort, 10)
+					port: *v.port | v.containerPort
+					protocol: v.protocol
+					targetPort: *v.port | v.containerPort
+				}
+			}]
+			}
+		}
+		if parameter.strategy == "blueGreen" {
+			rolloutServicePreview: {
+				apiVersion: "v1"
+				kind:       "Service"
+				metadata: {
+					name: "\(context.name)-preview"
+				}
+				spec: {
+					selector: {
+						"app.oam.dev/component": context.name
+					}
+					ports: [for v in parameter.ports {
+				{
+					name: *v.name | "port-" + strconv.FormatInt(*v.port | v.containerPort, 10)
+					port: *v.port | v.containerPort
+					protocol: v.protocol
+					targetPort: *v.port | v.containerPort
+				}
+			}]
+				}
+			}
+		}
+	}
+	parameter: {
+		// +usage=Specify the labels in the workload
+		labels?: [string]: string
+		// +usage=Specify the annotations in the workload
+		annotations?: [string]: string
+		// +usage=Specify the number of replicas
+		replicas: *1 | int & >=0
+		// +usage=Which image would you like to use for your service
+		// +short=i
+		image: string
+		// +usage=Specify image pull policy for your service
+		imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
+		// +usage=Specify image pull secrets for your service
+		imagePullSecrets?: [...string]
+		// +usage=Commands to run in the container
+		cmd?: [...string]
+		// +usage=Arguments to the entrypoint
+		args?: [...string]
+		// +usage=Define arguments by using environment variables
+		env?: [...{
+			// +usage=Environment variable name
+			name: string
+			// +usage=The value of the environment variable
+			value?: string
+			// +usage=Specifies a source the value of this var should come from
+			valueFrom?: {
+				// +usage=Selects a key of a secret in the pod's namespace
+				secretKeyRef?: {
+					// +usage=The name of the secret in the pod's namespace to select from
+					name: string
+					// +usage=The key of the secret to select from. Must be a valid secret key
+					key: string
+				}
+				// +usage=Selects a key of a config map in the pod's namespace
+				configMapKeyRef?: {
+					// +usage=The name of the config map in the pod's namespace to select from
+					name: string
+					// +usage=The key of the config map to select from. Must be a valid secret key
+					key: string
+				}
+			}
+		}]
+		// +usage=Which ports the Services send traffic to
+		ports: list.MinItems(1) & [...{
+			// +usage=Number of port to expose on the pod's IP address
+			port: int
+			// +usage=Name of the port
+			name?: string
+			// +usage=Protocol for port. Must be UDP, TCP, or SCTP
+			protocol: *"TCP" | "UDP" | "SCTP"
+		}]
+		// +usage=Specify the delivery strategy: "canary" shifts traffic by steps, "blueGreen" switches the active Service to the preview once promoted
+		strategy: *"canary" | "blueGreen"
+		// +usage=Canary steps, run in order on every update. Only valid when strategy is canary
+		steps?: [...{
+			// +usage=Percentage of the replicas running the new version. A step sets exactly one of setWeight, pause or analysis
+			setWeight?: int & >=0 & <=100
+			// +usage=Pause the rollout, until promoted when no duration is given
+			pause?: {
+				// +usage=Duration of the pause, e.g. 30s, 5m or 1h
+				duration?: string
+			}
+			// +usage=Run an analysis and abort the rollout if it fails
+			analysis?: {
+				// +usage=AnalysisTemplates to run
+				templates: [...{
+					// +usage=Name of the AnalysisTemplate
+					templateName: string
+				}]
+				// +usage=Arguments of the AnalysisTemplates
+				args?: [...{
+					name: string
+					value: string
+				}]
+			}
+		}]
+		// +usage=Blue-green settings. Only valid when strategy is blueGreen
+		blueGreen: {
+			// +usage=Promote the preview once it is ready, instead of waiting for a manual promotion
+			autoPromotionEnabled: *true | bool
+			// +usage=Wait the given seconds after the preview is ready before promoting it
+			autoPromotionSeconds?: int
+			// +usage=Seconds to keep the previous version running after the switch
+			scaleDownDelaySeconds?: int
+			// +usage=Number of replicas of the preview, defaults to replicas
+			previewReplicaCount?: int
+		}
+		// +usage=Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+		cpu?: string
+		// +usage=Specifies the attributes of the memory resource required for the container.
+		memory?: string
+		limit?: {
+			cpu?: string
+			memory?: string
+		}
+		volumeMounts?: {
+			// +usage=Mount PVC type volume
+			pvc?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				// +usage=The name of the PVC
+				claimName: string
+			}]
+			// +usage=Mount ConfigMap type volume
+			configMap?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				defaultMode: *420 | int
+				cmName: string
+				items?: [...{
+					key: string
+					path: string
+					mode: *511 | int
+				}]
+			}]
+			// +usage=Mount Secret type volume
+			secret?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				defaultMode: *420 | int
+				secretName: string
+				items?: [...{
+					key: string
+					path: string
+					mode: *511 | int
+				}]
+			}]
+			// +usage=Mount EmptyDir type volume
+			emptyDir?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				medium: *"" | "Memory"
+			}]
+			// +usage=Mount HostPath type volume
+			hostPath?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				mountPropagation?: "None" | "HostToContainer" | "Bidirectional"
+				path: string
+				readOnly?: bool
+			}]
+		}
+		// +usage=Deprecated field, use volumeMounts instead.
+		volumes?: [...{
+			name: string
+			mountPath: string
+			// +usage=Specify volume type, options: "pvc","configMap","secret","emptyDir", default to emptyDir
+			type: *"emptyDir" | "pvc" | "configMap" | "secret"
+			if type == "pvc" {
+				claimName: string
+			}
+			if type == "configMap" {
+				defaultMode: *420 | int
+				cmName: string
+				items?: [...{
+					key: string
+					path: string
+					mode: *511 | int
+				}]
+			}
+			if type == "secret" {
+				defaultMode: *420 | int
+				secretName: string
+				items?: [...{
+					key: string
+					path: string
+					mode: *511 | int
+				}]
+			}
+			if type == "emptyDir" {
+				medium: *"" | "Memory"
+			}
+		}]
+		// +usage=Instructions for assessing whether the container is alive.
+		livenessProbe?: #HealthProbe
+		// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
+		readinessProbe?: #HealthProbe
+		// +usage=Specify the hostAliases to add
+		hostAliases?: [...{
+			ip: string
+			hostnames: [...string]
+		}]
+	}
+	#HealthProbe: {
+		// +usage=Instructions for assessing container health by executing a command. Either this attribute or the httpGet attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the httpGet attribute and the tcpSocket attribute.
+		exec?: {
+			// +usage=A command to be executed inside the container to assess its health. Each space delimited token of the command is a separate array element. Commands exiting 0 are considered to be successful probes, whilst all other exit codes are considered failures.
+			command: [...string]
+		}
+		// +usage=Instructions for assessing container health by executing an HTTP GET request. Either this attribute or the exec attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the exec attribute and the tcpSocket attribute.
+		httpGet?: {
+			// +usage=The endpoint, relative to the port, to which the HTTP GET request should be directed.
+			path: string
+			// +usage=The TCP socket within the container to which the HTTP GET request should be directed.
+			port: int
+			host?: string
+			scheme?: *"HTTP" | string
+			httpHeaders?: [...{
+				name: string
+				value: string
+			}]
+		}
+		// +usage=Instructions for assessing container health by probing a TCP socket. Either this attribute or the exec attribute or the httpGet attribute MUST be specified. This attribute is mutually exclusive with both the exec attribute and the httpGet attribute.
+		tcpSocket?: {
+			// +usage=The TCP socket within the container that should be probed to assess container health.
+			port: int
+		}
+		// +usage=Number of seconds after the container is started before the first probe is initiated.
+		initialDelaySeconds: *0 | int
+		// +usage=How often, in seconds, to execute the probe.
+		periodSeconds: *10 | int
+		// +usage=Number of seconds after which the probe times out.
+		timeoutSeconds: *1 | int
+		// +usage=Minimum consecutive successes for the probe to be considered successful after having failed.
+		successThreshold: *1 | int
+		// +usage=Number of consecutive failures required to determine the container is not alive (liveness probe) or not ready (readiness probe).
+		failureThreshold: *3 | int
+	}
+}
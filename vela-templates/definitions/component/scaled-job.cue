@@ -0,0 +1,647 @@
+"scaled-job": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Describes jobs started by KEDA as events such as queue messages wait to be processed."
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "keda.sh/v1alpha1"
+				kind:       "ScaledJob"
+			}
+			type: "scaledjobs.keda.sh"
+		}
+		status: {
+			customStatus: #"""
+				message: *"" | string
+				if context.output.status != _|_ {
+					_st: {
+						conditions: *[] | [...]
+						...
+					} & context.output.status
+					_ready:  [for c in _st.conditions if c.type == "Ready" {c.status}, "Unknown"][0]
+					_active: [for c in _st.conditions if c.type == "Active" {c.status}, "Unknown"][0]
+					message: "Ready:\(_ready), active:\(_active)"
+				}
+				"""#
+			healthPolicy: #"""
+				healthy: *false | bool
+				if context.output.status != _|_ {
+					_st: {
+						conditions: *[] | [...]
+						...
+					} & context.output.status
+					_ready:  [for c in _st.conditions if c.type == "Ready" {c.status}, "Unknown"][0]
+					_active: [for c in _st.conditions if c.type == "Active" {c.status}, "Unknown"][0]
+					healthy: _ready == "True"
+				}
+				isHealth: healthy
+				"""#
+		}
+	}
+}
+template: {
+	mountsArray: [
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.pvc != _|_ for v in parameter.volumeMounts.pvc {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.configMap != _|_ for v in parameter.volumeMounts.configMap {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.secret != _|_ for v in parameter.volumeMounts.secret {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.emptyDir != _|_ for v in parameter.volumeMounts.emptyDir {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.hostPath != _|_ for v in parameter.volumeMounts.hostPath {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
+		}
+		}
+	]
+	volumesList: [
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.pvc != _|_ for v in parameter.volumeMounts.pvc {
+		{
+			name: v.name
+			persistentVolumeClaim: {
+				claimName: v.claimName
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.configMap != _|_ for v in parameter.volumeMounts.configMap {
+		{
+			configMap: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
+				}
+				name: v.cmName
+			}
+			name: v.name
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.secret != _|_ for v in parameter.volumeMounts.secret {
+		{
+			name: v.name
+			secret: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
+				}
+				secretName: v.secretName
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.emptyDir != _|_ for v in parameter.volumeMounts.emptyDir {
+		{
+			emptyDir: {
+				medium: v.medium
+			}
+			name: v.name
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.hostPath != _|_ for v in parameter.volumeMounts.hostPath {
+		{
+			hostPath: {
+				path: v.path
+			}
+			name: v.name
+		}
+		}
+	]
+	deDupVolumesArray: [
+		for val in [
+			for i, vi in volumesList {
+				for j, vj in volumesList if j < i && vi.name == vj.name {
+					_ignore: true
+				}
+				vi
+			},
+		] if val._ignore == _|_ {
+			val
+		},
+	]
+	output: {
+		apiVersion: "keda.sh/v1alpha1"
+		kind:       "ScaledJob"
+		spec: {
+			pollingInterval: parameter.pollingInterval
+			maxReplicaCount: parameter.maxReplicaCount
+			successfulJobsHistoryLimit: parameter.successfulJobsHistoryLimit
+			failedJobsHistoryLimit: parameter.failedJobsHistoryLimit
+			rollout: {
+				strategy: parameter.rolloutStrategy
+			}
+			triggers: [for t in parameter.triggers {
+	_required: {
+		rabbitmq:   ["queueName"]
+		kafka:      ["bootstrapServers", "consumerGroup"]
+		prometheus: ["serverAddress", "query", "threshold"]
+		cron:       ["timezone", "start", "end", "desiredReplicas"]
+	}
+	type: t.type
+	if t.name != _|_ {name: t.name}
+	metadata: {for k, v in t.metadata {(k): "\(v)"}}
+	if _required[t.type] != _|_ {metadata: {for k in _required[t.type] {(k)!: string}}}
+	if t.authenticationRef != _|_ {authenticationRef: t.authenticationRef}
+}]
+			jobTargetRef: {
+				template: {
+					metadata: {
+						labels: {
+							if parameter["labels"] != _|_ {
+								parameter.labels
+							}
+							"app.oam.dev/name": context.appName
+							"app.oam.dev/component": context.name
+						}
+						if parameter["annotations"] != _|_ {
+							annotations: parameter.annotations
+						}
+					}
+					spec: {
+						restartPolicy: parameter.restart
+						containers: [{
+							name: context.name
+							image: parameter.image
+							if parameter["env"] != _|_ {
+								env: parameter.env
+							}
+							if context["config"] != _|_ {
+								env: context.config
+							}
+							if parameter["cpu"] != _|_ && !(parameter.limit.cpu != _|_) {
+								resources: {
+									requests: {
+										cpu: parameter.cpu
+									}
+									limits: {
+										cpu: parameter.cpu
+									}
+								}
+							}
+							if parameter["cpu"] != _|_ && parameter.limit.cpu != _|_ {
+								resources: {
+									requests: {
+										cpu: parameter.cpu
+									}
+									limits: {
+										cpu: parameter.limit.cpu
+									}
+								}
+							}
+							if parameter["memory"] != _|_ && !(parameter.limit.memory != _|_) {
+								resources: {
+									requests: {
+										memory: parameter.memory
+									}
+									limits: {
+										memory: parameter.memory
+									}
+								}
+							}
+							if parameter["memory"] != _|_ && parameter.limit.memory != _|_ {
+								resources: {
+									requests: {
+										memory: parameter.memory
+									}
+									limits: {
+										memory: parameter.limit.memory
+									}
+								}
+							}
+							if parameter["volumeMounts"] != _|_ {
+								volumeMounts: mountsArray
+							}
+							if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
+								volumeMounts: [for v in parameter.volumes {
+				{
+					mountPath: v.mountPath
+					name: v.name
+				}
+			}]
+							}
+							if parameter["args"] != _|_ {
+								args: parameter.args
+							}
+							if parameter["cmd"] != _|_ {
+								command: parameter.cmd
+							}
+							if parameter["imagePullPolicy"] != _|_ {
+								imagePullPolicy: parameter.imagePullPolicy
+							}
+							if parameter["livenessProbe"] != _|_ {
+								livenessProbe: parameter.livenessProbe
+							}
+							if parameter["readinessProbe"] != _|_ {
+								readinessProbe: parameter.readinessProbe
+							}
+						}]
+						if parameter["volumeMounts"] != _|_ {
+							volumes: deDupVolumesArray
+						}
+						if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
+							volumes: [for v in parameter.volumes {
+				{
+					name: v.name
+					if v.type == "pvc" {
+						persistentVolumeClaim: {
+				claimName: v.claimName
+			}
+					}
+					if v.type == "configMap" {
+						configMap: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
+				}
+				name: v.cmName
+			}
+					}
+					if v.type == "secret" {
+						secret: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
+				}
+				secretName: v.secretName
+			}
+					}
+					if v.type == "emptyDir" {
+						emptyDir: {
+				medium: v.medium
+			}
+					}
+				}
+			}]
+						}
+						if parameter["hostAliases"] != _|_ {
+							// +patchKey=ip
+							hostAliases: parameter.hostAliases
+						}
+						if parameter["imagePullSecrets"] != _|_ {
+							imagePullSecrets: [for v in parameter.imagePullSecrets { name: v }]
+						}
+					}
+				}
+				parallelism: parameter.count
+				if parameter["completions"] != _|_ {
+					completions: parameter.completions
+				}
+				if parameter["completions"] == _|_ {
+					completions: parameter.count
+				}
+				completionMode: parameter.completionMode
+				if parameter["backoffLimitPerIndex"] != _|_ {
+					backoffLimitPerIndex: parameter.backoffLimitPerIndex
+				}
+				if parameter["backoffLimitPerIndex"] != _|_ && parameter.completionMode != "Indexed" {
+					backoffLimitPerIndex: error("backoffLimitPerIndex is only valid when completionMode is Indexed")
+				}
+				if parameter["maxFailedIndexes"] != _|_ {
+					maxFailedIndexes: parameter.maxFailedIndexes
+				}
+				if parameter["maxFailedIndexes"] != _|_ && parameter["backoffLimitPerIndex"] == _|_ {
+					maxFailedIndexes: error("maxFailedIndexes requires backoffLimitPerIndex")
+				}
+				if parameter["activeDeadlineSeconds"] != _|_ {
+					activeDeadlineSeconds: parameter.activeDeadlineSeconds
+				}
+				if parameter["backoffLimitPerIndex"] == _|_ {
+					backoffLimit: parameter.backoffLimit
+				}
+				if parameter["podFailurePolicy"] != _|_ {
+					podFailurePolicy: parameter.podFailurePolicy
+				}
+				if parameter["ttlSecondsAfterFinished"] != _|_ {
+					ttlSecondsAfterFinished: parameter.ttlSecondsAfterFinished
+				}
+			}
+			if parameter["minReplicaCount"] != _|_ {
+				minReplicaCount: parameter.minReplicaCount
+			}
+			if parameter["scalingStrategy"] != _|_ {
+				scalingStrategy: parameter.scalingStrategy
+			}
+		}
+	}
+	parameter: {
+		// +usage=Specify the labels in the workload
+		labels?: [string]: string
+		// +usage=Specify the annotations in the workload
+		annotations?: [string]: string
+		// +usage=Events scaling the jobs, the number of jobs follows the trigger asking for the most by default
+		triggers: [...{
+			// +usage=Type of the KEDA scaler, e.g. rabbitmq, kafka, prometheus or cron
+			type: string
+			// +usage=Name of the trigger
+			name?: string
+			// +usage=Metadata of the scaler, e.g. `queueName` and `value` for rabbitmq
+			metadata: {[string]: string | number | bool}
+			// +usage=TriggerAuthentication with the credentials of the scaler
+			authenticationRef?: {
+				// +usage=Name of the TriggerAuthentication
+				name: string
+				// +usage=Kind of the authentication
+				kind: *"TriggerAuthentication" | "ClusterTriggerAuthentication"
+			}
+		}]
+		// +usage=Number of seconds between two checks of the triggers
+		pollingInterval: *30 | int & >=1
+		// +usage=Number of jobs kept running without events
+		minReplicaCount?: int & >=0
+		// +usage=Maximum number of jobs running at the same time
+		maxReplicaCount: *100 | int & >=1
+		// +usage=The number of successful finished jobs to retain
+		successfulJobsHistoryLimit: *100 | int & >=0
+		// +usage=The number of failed finished jobs to retain
+		failedJobsHistoryLimit: *100 | int & >=0
+		// +usage=Specify how the number of jobs to start is computed from the triggers
+		scalingStrategy?: {
+			// +usage=Deduct the running jobs from the queue length with "default", as configured with "custom", or deduct only the pending jobs with "accurate"; "eager" fills up to maxReplicaCount
+			strategy: *"default" | "custom" | "accurate" | "eager"
+			// +usage=Number deducted from the queue length, with strategy "custom"
+			customScalingQueueLengthDeduction?: int
+			// +usage=Share of the running jobs deducted from the queue length, e.g. "0.5", with strategy "custom"
+			customScalingRunningJobPercentage?: string
+			// +usage=Pod conditions a job is pending until, e.g. Ready
+			pendingPodConditions?: [...string]
+			// +usage=Combine the triggers by their maximum, minimum, average or sum
+			multipleScalersCalculation?: "max" | "min" | "avg" | "sum"
+		}
+		// +usage=Delete the running jobs when the ScaledJob changes with "default", or let them finish with "gradual"
+		rolloutStrategy: *"default" | "gradual"
+		// +usage=Specify number of tasks to run in parallel
+		// +short=c
+		count: *1 | int
+		// +usage=Define the job restart policy, the value can only be Never or OnFailure. By default, it's Never.
+		restart: *"Never" | string
+		// +usage=Specify number of tasks that must succeed, defaults to count
+		completions?: int
+		// +usage=Specify how completions are tracked. Indexed tasks get a completion index from 0 to completions-1 in the JOB_COMPLETION_INDEX env
+		completionMode: *"NonIndexed" | "Indexed"
+		// +usage=The number of retries before marking this job failed, not used when backoffLimitPerIndex is set
+		backoffLimit: *6 | int
+		// +usage=Specify number of retries of each index before it is marked failed. Only valid when completionMode is Indexed
+		backoffLimitPerIndex?: int
+		// +usage=Specify number of failed indexes after which the task fails. Requires backoffLimitPerIndex
+		maxFailedIndexes?: int
+		// +usage=Specify how pod failures are handled, requires restart to be Never
+		podFailurePolicy?: {
+			// +usage=Rules evaluated in order, the first matching rule applies
+			rules: [...{
+				// +usage=Action taken when the rule matches
+				action: "FailJob" | "FailIndex" | "Ignore" | "Count"
+				// +usage=Match pods by the exit codes of their containers
+				onExitCodes?: {
+					// +usage=Only match the exit code of this container
+					containerName?: string
+					// +usage=Relation between the exit code and values
+					operator: "In" | "NotIn"
+					// +usage=Exit codes to match
+					values: [...int]
+				}
+				// +usage=Match pods by their conditions
+				onPodConditions?: [...{
+					// +usage=Pod condition type, e.g. DisruptionTarget
+					type: string
+					// +usage=Pod condition status
+					status: *"True" | string
+				}]
+			}]
+		}
+		// +usage=The duration in seconds relative to the startTime that the job may be continuously active before the system tries to terminate it
+		activeDeadlineSeconds?: int
+		// +usage=Limits the lifetime of a Job that has finished
+		ttlSecondsAfterFinished?: int
+		// +usage=Which image would you like to use for your service
+		// +short=i
+		image: string
+		// +usage=Specify image pull policy for your service
+		imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
+		// +usage=Specify image pull secrets for your service
+		imagePullSecrets?: [...string]
+		// +usage=Commands to run in the container
+		cmd?: [...string]
+		// +usage=Arguments to the entrypoint
+		args?: [...string]
+		// +usage=Define arguments by using environment variables
+		env?: [...{
+			// +usage=Environment variable name
+			name: string
+			// +usage=The value of the environment variable
+			value?: string
+			// +usage=Specifies a source the value of this var should come from
+			valueFrom?: {
+				// +usage=Selects a key of a secret in the pod's namespace
+				secretKeyRef?: {
+					// +usage=The name of the secret in the pod's namespace to select from
+					name: string
+					// +usage=The key of the secret to select from. Must be a valid secret key
+					key: string
+				}
+				// +usage=Selects a key of a config map in the pod's namespace
+				configMapKeyRef?: {
+					// +usage=The name of the config map in the pod's namespace to select from
+					name: string
+					// +usage=The key of the config map to select from. Must be a valid secret key
+					key: string
+				}
+			}
+		}]
+		// +usage=Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+		cpu?: string
+		// +usage=Specifies the attributes of the memory resource required for the container.
+		memory?: string
+		limit?: {
+			cpu?: string
+			memory?: string
+		}
+		volumeMounts?: {
+			// +usage=Mount PVC type volume
+			pvc?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				// +usage=The name of the PVC
+				claimName: string
+			}]
+			// +usage=Mount ConfigMap type volume
+			configMap?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				defaultMode: *420 | int
+				cmName: string
+				items?: [...{
+					key: string
+					path: string
+					mode: *511 | int
+				}]
+			}]
+			// +usage=Mount Secret type volume
+			secret?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				defaultMode: *420 | int
+				secretName: string
+				items?: [...{
+					key: string
+					path: string
+					mode: *511 | int
+				}]
+			}]
+			// +usage=Mount EmptyDir type volume
+			emptyDir?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				medium: *"" | "Memory"
+			}]
+			// +usage=Mount HostPath type volume
+			hostPath?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				mountPropagation?: "None" | "HostToContainer" | "Bidirectional"
+				path: string
+				readOnly?: bool
+			}]
+		}
+		// +usage=Deprecated field, use volumeMounts instead.
+		volumes?: [...{
+			name: string
+			mountPath: string
+			// +usage=Specify volume type, options: "pvc","configMap","secret","emptyDir", default to emptyDir
+			type: *"emptyDir" | "pvc" | "configMap" | "secret"
+			if type == "pvc" {
+				claimName: string
+			}
+			if type == "configMap" {
+				defaultMode: *420 | int
+				cmName: string
+				items?: [...{
+					key: string
+					path: string
+					mode: *511 | int
+				}]
+			}
+			if type == "secret" {
+				defaultMode: *420 | int
+				secretName: string
+				items?: [...{
+					key: string
+					path: string
+					mode: *511 | int
+				}]
+			}
+			if type == "emptyDir" {
+				medium: *"" | "Memory"
+			}
+		}]
+		// +usage=Instructions for assessing whether the container is alive.
+		livenessProbe?: #HealthProbe
+		// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
+		readinessProbe?: #HealthProbe
+		// +usage=Specify the hostAliases to add
+		hostAliases?: [...{
+			ip: string
+			hostnames: [...string]
+		}]
+	}
+	#HealthProbe: {
+		// +usage=Instructions for assessing container health by executing a command. Either this attribute or the httpGet attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the httpGet attribute and the tcpSocket attribute.
+		exec?: {
+			// +usage=A command to be executed inside the container to assess its health. Each space delimited token of the command is a separate array element. Commands exiting 0 are considered to be successful probes, whilst all other exit codes are considered failures.
+			command: [...string]
+		}
+		// +usage=Instructions for assessing container health by executing an HTTP GET request. Either this attribute or the exec attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the exec attribute and the tcpSocket attribute.
+		httpGet?: {
+			// +usage=The endpoint, relative to the port, to which the HTTP GET request should be directed.
+			path: string
+			// +usage=The TCP socket within the container to which the HTTP GET request should be directed.
+			port: int
+			host?: string
+			scheme?: *"HTTP" | string
+			httpHeaders?: [...{
+				name: string
+				value: string
+			}]
+		}
+		// +usage=Instructions for assessing container health by probing a TCP socket. Either this attribute or the exec attribute or the httpGet attribute MUST be specified. This attribute is mutually exclusive with both the exec attribute and the httpGet attribute.
+		tcpSocket?: {
+			// +usage=The TCP socket within the container that should be probed to assess container health.
+			port: int
+		}
+		// +usage=Number of seconds after the container is started before the first probe is initiated.
+		initialDelaySeconds: *0 | int
+		// +usage=How often, in seconds, to execute the probe.
+		periodSeconds: *10 | int
+		// +usage=Number of seconds after which the probe times out.
+		timeoutSeconds: *1 | int
+		// +usage=Minimum consecutive successes for the probe to be considered successful after having failed.
+		successThreshold: *1 | int
+		// +usage=Number of consecutive failures required to determine the container is not alive (liveness probe) or not ready (readiness probe).
+		failureThreshold: *3 | int
+	}
+}
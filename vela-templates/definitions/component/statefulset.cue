@@ -1,6 +1,7 @@
 import (
 	"strings"
 	"strconv"
+	"list"
 )
 
 statefulset: {
@@ -33,6 +34,9 @@ statefulset: {
 					readyReplicas:      *0 | int
 					replicas:           *0 | int
 					observedGeneration: *0 | int
+					currentRevision:    *"" | string
+					updateRevision:     *"" | string
+					partition:          *0 | int
 				} & {
 					if context.output.status.updatedReplicas != _|_ {
 						updatedReplicas: context.output.status.updatedReplicas
@@ -46,8 +50,17 @@ statefulset: {
 					if context.output.status.observedGeneration != _|_ {
 						observedGeneration: context.output.status.observedGeneration
 					}
+					if context.output.status.currentRevision != _|_ {
+						currentRevision: context.output.status.currentRevision
+					}
+					if context.output.status.updateRevision != _|_ {
+						updateRevision: context.output.status.updateRevision
+					}
+					if context.output.spec.updateStrategy.rollingUpdate.partition != _|_ {
+						partition: context.output.spec.updateStrategy.rollingUpdate.partition
+					}
 				}
-				_isHealth: (context.output.spec.replicas == ready.readyReplicas) && (context.output.spec.replicas == ready.updatedReplicas) && (context.output.spec.replicas == ready.replicas) && (ready.observedGeneration == context.output.metadata.generation || ready.observedGeneration > context.output.metadata.generation)
+				_isHealth: (context.output.spec.replicas == ready.readyReplicas) && (context.output.spec.replicas == ready.updatedReplicas || (ready.partition > 0 && ready.updatedReplicas >= context.output.spec.replicas - ready.partition)) && (context.output.spec.replicas == ready.replicas) && (ready.observedGeneration == context.output.metadata.generation || ready.observedGeneration > context.output.metadata.generation) && (ready.currentRevision == ready.updateRevision || ready.partition > 0)
 				isHealth: *_isHealth | bool
 				if context.output.metadata.annotations != _|_ {
 					if context.output.metadata.annotations["app.oam.dev/disable-health-check"] != _|_ {
@@ -59,6 +72,12 @@ statefulset: {
 	}
 }
 template: {
+	claimMountsArray: [
+		if parameter["volumeClaimTemplates"] != _|_ for v in parameter.volumeClaimTemplates {
+			mountPath: v.mountPath
+			name: v.name
+		},
+	]
 	mountsArray: [
 		if parameter.volumeMounts != _|_ && parameter.volumeMounts.pvc != _|_ for v in parameter.volumeMounts.pvc {
 		{
@@ -67,6 +86,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		},
 		if parameter.volumeMounts != _|_ && parameter.volumeMounts.configMap != _|_ for v in parameter.volumeMounts.configMap {
@@ -76,6 +101,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		},
 		if parameter.volumeMounts != _|_ && parameter.volumeMounts.secret != _|_ for v in parameter.volumeMounts.secret {
@@ -85,6 +116,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		},
 		if parameter.volumeMounts != _|_ && parameter.volumeMounts.emptyDir != _|_ for v in parameter.volumeMounts.emptyDir {
@@ -94,6 +131,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		},
 		if parameter.volumeMounts != _|_ && parameter.volumeMounts.hostPath != _|_ for v in parameter.volumeMounts.hostPath {
@@ -103,6 +146,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		}
 	]
@@ -168,6 +217,12 @@ template: {
 			val
 		},
 	]
+	legacyMountsArray: [
+		if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ for v in parameter.volumes {
+			mountPath: v.mountPath
+			name: v.name
+		},
+	]
 	output: {
 		apiVersion: "apps/v1"
 		kind:       "StatefulSet"
@@ -197,6 +252,52 @@ template: {
 					containers: [{
 						name: context.name
 						image: parameter.image
+						if parameter["env"] != _|_ {
+							env: parameter.env
+						}
+						if context["config"] != _|_ {
+							env: context.config
+						}
+						if parameter["cpu"] != _|_ && !(parameter.limit.cpu != _|_) {
+							resources: {
+								requests: {
+									cpu: parameter.cpu
+								}
+								limits: {
+									cpu: parameter.cpu
+								}
+							}
+						}
+						if parameter["cpu"] != _|_ && parameter.limit.cpu != _|_ {
+							resources: {
+								requests: {
+									cpu: parameter.cpu
+								}
+								limits: {
+									cpu: parameter.limit.cpu
+								}
+							}
+						}
+						if parameter["memory"] != _|_ && !(parameter.limit.memory != _|_) {
+							resources: {
+								requests: {
+									memory: parameter.memory
+								}
+								limits: {
+									memory: parameter.memory
+								}
+							}
+						}
+						if parameter["memory"] != _|_ && parameter.limit.memory != _|_ {
+							resources: {
+								requests: {
+									memory: parameter.memory
+								}
+								limits: {
+									memory: parameter.limit.memory
+								}
+							}
+						}
 						if parameter["port"] != _|_ && parameter["ports"] == _|_ {
 							ports: [{
 							containerPort: parameter.port
@@ -234,43 +335,6 @@ template: {
 		},
 	]
 						}
-						if parameter["env"] != _|_ {
-							env: parameter.env
-						}
-						if context["config"] != _|_ {
-							env: context.config
-						}
-						if parameter["cpu"] != _|_ {
-							resources: {
-								limits: {
-									cpu: parameter.cpu
-								}
-								requests: {
-									cpu: parameter.cpu
-								}
-							}
-						}
-						if parameter["memory"] != _|_ {
-							resources: {
-								limits: {
-									memory: parameter.memory
-								}
-								requests: {
-									memory: parameter.memory
-								}
-							}
-						}
-						if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
-							volumeMounts: [for v in parameter.volumes {
-				{
-					mountPath: v.mountPath
-					name: v.name
-				}
-			}]
-						}
-						if parameter["volumeMounts"] != _|_ {
-							volumeMounts: mountsArray
-						}
 						if parameter["args"] != _|_ {
 							args: parameter.args
 						}
@@ -286,7 +350,13 @@ template: {
 						if parameter["readinessProbe"] != _|_ {
 							readinessProbe: parameter.readinessProbe
 						}
+						if parameter["volumeMounts"] != _|_ || parameter["volumes"] != _|_ || parameter["volumeClaimTemplates"] != _|_ {
+							volumeMounts: list.Concat([mountsArray, legacyMountsArray, claimMountsArray])
+						}
 					}]
+					if parameter["volumeMounts"] != _|_ {
+						volumes: deDupVolumesArray
+					}
 					if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
 						volumes: [for v in parameter.volumes {
 				{
@@ -322,9 +392,6 @@ template: {
 				}
 			}]
 					}
-					if parameter["volumeMounts"] != _|_ {
-						volumes: deDupVolumesArray
-					}
 					if parameter["hostAliases"] != _|_ {
 						// +patchKey=ip
 						hostAliases: parameter.hostAliases
@@ -334,6 +401,36 @@ template: {
 					}
 				}
 			}
+			podManagementPolicy: parameter.podManagementPolicy
+			if parameter["persistentVolumeClaimRetentionPolicy"] != _|_ {
+				persistentVolumeClaimRetentionPolicy: parameter.persistentVolumeClaimRetentionPolicy
+			}
+			if parameter["serviceName"] != _|_ {
+				serviceName: parameter.serviceName
+			}
+			if parameter["updateStrategy"] != _|_ {
+				updateStrategy: {type: parameter.updateStrategy.type, if parameter.updateStrategy.type == "RollingUpdate" if parameter.updateStrategy.partition != _|_ {rollingUpdate: partition: parameter.updateStrategy.partition}}
+			}
+			if parameter["volumeClaimTemplates"] != _|_ {
+				volumeClaimTemplates: [for v in parameter.volumeClaimTemplates {
+				{
+					metadata: {
+				name: v.name
+			}
+					spec: {
+				accessModes: v.accessModes
+				resources: {
+				requests: {
+				storage: v.size
+			}
+			}
+				if v.storageClass != _|_ {
+					storageClassName: v.storageClass
+				}
+			}
+				}
+			}]
+			}
 		}
 	}
 	exposePorts: [
@@ -372,15 +469,36 @@ template: {
 			if v.protocol != _|_ {
 				protocol: v.protocol
 			}
+			if v.appProtocol != _|_ {
+				appProtocol: v.appProtocol
+			}
 		},
 	]
 	outputs: {
+		if parameter["serviceName"] != _|_ {
+			statefulsetHeadless: {
+				apiVersion: "v1"
+				kind:       "Service"
+				metadata: {
+					name: parameter.serviceName
+				}
+				spec: {
+					clusterIP: "None"
+					selector: {
+						"app.oam.dev/component": context.name
+					}
+				}
+			}
+		}
 		if len(exposePorts) != 0 {
 			statefulsetsExpose: {
 				apiVersion: "v1"
 				kind:       "Service"
 				metadata: {
 					name: context.name
+					if parameter.service.annotations != _|_ {
+						annotations: parameter.service.annotations
+					}
 				}
 				spec: {
 					selector: {
@@ -388,6 +506,24 @@ template: {
 					}
 					ports: exposePorts
 					type: parameter.exposeType
+					if parameter.service.clusterIP != _|_ {
+						clusterIP: [if parameter.service.clusterIP == "None" if parameter.exposeType != "ClusterIP" {error("service.clusterIP None is only valid for a ClusterIP Service")}, parameter.service.clusterIP][0]
+					}
+					if parameter.service.externalTrafficPolicy != _|_ {
+						externalTrafficPolicy: [if parameter.exposeType != "NodePort" if parameter.exposeType != "LoadBalancer" {error("service.externalTrafficPolicy is only valid for a NodePort or LoadBalancer Service")}, parameter.service.externalTrafficPolicy][0]
+					}
+					if parameter.service.ipFamilies != _|_ {
+						ipFamilies: parameter.service.ipFamilies
+					}
+					if parameter.service.ipFamilyPolicy != _|_ {
+						ipFamilyPolicy: parameter.service.ipFamilyPolicy
+					}
+					if parameter.service.loadBalancerSourceRanges != _|_ {
+						loadBalancerSourceRanges: parameter.service.loadBalancerSourceRanges
+					}
+					if parameter.service.sessionAffinity != _|_ {
+						sessionAffinity: parameter.service.sessionAffinity
+					}
 				}
 			}
 		}
@@ -404,31 +540,6 @@ template: {
 		imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
 		// +usage=Specify image pull secrets for your service
 		imagePullSecrets?: [...string]
-		// +ignore
-		// +usage=Deprecated field, please use ports instead
-		// +short=p
-		port?: int
-		// +usage=Which ports do you want customer traffic sent to, defaults to 80
-		ports?: [...{
-			// +usage=Number of port to expose on the pod's IP address
-			port: int
-			// +usage=Number of container port to connect to, defaults to port
-			containerPort?: int
-			// +usage=Name of the port
-			name?: string
-			// +usage=Protocol for port. Must be UDP, TCP, or SCTP
-			protocol: *"TCP" | "UDP" | "SCTP"
-			// +usage=Specify if the port should be exposed
-			expose: *false | bool
-			// +usage=exposed node port. Only Valid when exposeType is NodePort
-			nodePort?: int
-		}]
-		// +ignore
-		// +usage=Specify what kind of Service you want. options: "ClusterIP", "NodePort", "LoadBalancer"
-		exposeType: *"ClusterIP" | "NodePort" | "LoadBalancer"
-		// +ignore
-		// +usage=If addRevisionLabel is true, the revision label will be added to the underlying pods
-		addRevisionLabel: *false | bool
 		// +usage=Commands to run in the container
 		cmd?: [...string]
 		// +usage=Arguments to the entrypoint
@@ -457,10 +568,58 @@ template: {
 				}
 			}
 		}]
+		// +ignore
+		// +usage=Deprecated field, please use ports instead
+		// +short=p
+		port?: int
+		// +usage=Which ports do you want customer traffic sent to, defaults to 80
+		ports?: [...{
+			// +usage=Number of port to expose on the pod's IP address
+			port: int
+			// +usage=Number of container port to connect to, defaults to port
+			containerPort?: int
+			// +usage=Name of the port
+			name?: string
+			// +usage=Protocol for port. Must be UDP, TCP, or SCTP
+			protocol: *"TCP" | "UDP" | "SCTP"
+			// +usage=Specify if the port should be exposed
+			expose: *false | bool
+			// +usage=exposed node port. Only Valid when exposeType is NodePort
+			nodePort?: int
+			// +usage=Application protocol of the port, e.g. http, https or kubernetes.io/h2c
+			appProtocol?: string
+		}]
+		// +ignore
+		// +usage=Specify what kind of Service you want. options: "ClusterIP", "NodePort", "LoadBalancer"
+		exposeType: *"ClusterIP" | "NodePort" | "LoadBalancer"
+		// +usage=Specify the options of the Service exposing the ports
+		service?: {
+			// +usage=Annotations of the Service, e.g. to configure a cloud load balancer
+			annotations?: [string]: string
+			// +usage=Set to "None" for a headless Service, only valid for a ClusterIP Service
+			clusterIP?: string
+			// +usage=Set to "ClientIP" to send the requests of a client to the same pod
+			sessionAffinity?: "None" | "ClientIP"
+			// +usage=Set to "Local" to keep the client source IP, only valid for a NodePort or LoadBalancer Service
+			externalTrafficPolicy?: "Cluster" | "Local"
+			// +usage=Client CIDRs allowed to reach a LoadBalancer Service
+			loadBalancerSourceRanges?: [...string]
+			// +usage=Specify the dual-stack behavior of the Service
+			ipFamilyPolicy?: "SingleStack" | "PreferDualStack" | "RequireDualStack"
+			// +usage=IP families of the Service, in order of preference
+			ipFamilies?: [..."IPv4" | "IPv6"]
+		}
+		// +ignore
+		// +usage=If addRevisionLabel is true, the revision label will be added to the underlying pods
+		addRevisionLabel: *false | bool
 		// +usage=Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)
 		cpu?: string
 		// +usage=Specifies the attributes of the memory resource required for the container.
 		memory?: string
+		limit?: {
+			cpu?: string
+			memory?: string
+		}
 		volumeMounts?: {
 			// +usage=Mount PVC type volume
 			pvc?: [...{
@@ -508,7 +667,9 @@ template: {
 				name: string
 				mountPath: string
 				subPath?: string
+				mountPropagation?: "None" | "HostToContainer" | "Bidirectional"
 				path: string
+				readOnly?: bool
 			}]
 		}
 		// +usage=Deprecated field, use volumeMounts instead.
@@ -551,6 +712,37 @@ template: {
 			ip: string
 			hostnames: [...string]
 		}]
+		// +usage=Name of the headless Service governing the pods, created with the component. Cannot be changed once the StatefulSet exists
+		serviceName?: string
+		// +usage=Specify how pods are created and deleted during scaling
+		podManagementPolicy: *"OrderedReady" | "Parallel"
+		// +usage=Specify the strategy used to replace pods on an update
+		updateStrategy?: {
+			// +usage=Type of the update strategy
+			type: *"RollingUpdate" | "OnDelete"
+			// +usage=Only pods with an ordinal greater than or equal to partition are updated. Only valid when type is RollingUpdate
+			partition?: int
+		}
+		// +usage=Specify what happens to the claims created from volumeClaimTemplates
+		persistentVolumeClaimRetentionPolicy?: {
+			// +usage=What happens to the claims when the StatefulSet is deleted
+			whenDeleted: *"Retain" | "Delete"
+			// +usage=What happens to the claims when the StatefulSet is scaled down
+			whenScaled: *"Retain" | "Delete"
+		}
+		// +usage=Claims each pod gets its own persistent volume from, mounted in the container
+		volumeClaimTemplates?: [...{
+			// +usage=Name of the claim template and of the volume
+			name: string
+			// +usage=Path the volume is mounted at in the container
+			mountPath: string
+			// +usage=Storage class of the claims, defaults to the cluster default
+			storageClass?: string
+			// +usage=Requested storage size of each claim
+			size: *"1Gi" | string
+			// +usage=Access modes of the claims
+			accessModes: *["ReadWriteOnce"] | [...string]
+		}]
 	}
 	#HealthProbe: {
 		// +usage=Instructions for assessing container health by executing a command. Either this attribute or the httpGet attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the httpGet attribute and the tcpSocket attribute.
@@ -0,0 +1,308 @@
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+"static-site": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Describes a static website served by nginx from inline files, a ConfigMap, a Secret or a git repository."
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "apps/v1"
+				kind:       "Deployment"
+			}
+			type: "deployments.apps"
+		}
+		status: {
+			customStatus: #"""
+				ready: {
+					readyReplicas: *0 | int
+				} & {
+					if context.output.status.readyReplicas != _|_ {
+						readyReplicas: context.output.status.readyReplicas
+					}
+				}
+				message: "Ready:\(ready.readyReplicas)/\(context.output.spec.replicas)"
+				"""#
+			healthPolicy: #"""
+				ready: {
+					updatedReplicas:    *0 | int
+					readyReplicas:      *0 | int
+					replicas:           *0 | int
+					observedGeneration: *0 | int
+				} & {
+					if context.output.status.updatedReplicas != _|_ {
+						updatedReplicas: context.output.status.updatedReplicas
+					}
+					if context.output.status.readyReplicas != _|_ {
+						readyReplicas: context.output.status.readyReplicas
+					}
+					if context.output.status.replicas != _|_ {
+						replicas: context.output.status.replicas
+					}
+					if context.output.status.observedGeneration != _|_ {
+						observedGeneration: context.output.status.observedGeneration
+					}
+				}
+				_isHealth: (context.output.spec.replicas == ready.readyReplicas) && (context.output.spec.replicas == ready.updatedReplicas) && (context.output.spec.replicas == ready.replicas) && (ready.observedGeneration == context.output.metadata.generation || ready.observedGeneration > context.output.metadata.generation)
+				isHealth: *_isHealth | bool
+				if context.output.metadata.annotations != _|_ {
+					if context.output.metadata.annotations["app.oam.dev/disable-health-check"] != _|_ {
+						isHealth: true
+					}
+				}
+				"""#
+		}
+	}
+}
+template: {
+	_sources: [for k in ["files", "configMap", "secret", "git"] if parameter[k] != _|_ {k}]
+	_source: [
+		if len(_sources) == 0 {error("one of files, configMap, secret or git is required")},
+		if len(_sources) > 1 {error("only one of files, configMap, secret or git can be set, got \(strings.Join(_sources, ", "))")},
+		_sources[0],
+	][0]
+
+	_root: [
+		if _source == "git" if parameter.git.path != _|_ {"/usr/share/nginx/html/current/\(parameter.git.path)"},
+		if _source == "git" {"/usr/share/nginx/html/current"},
+		"/usr/share/nginx/html",
+	][0]
+
+	_content: {
+		for k in ["files", "configMap", "secret", "git", "spa", "gzip", "cacheMaxAge"] if parameter[k] != _|_ {
+			(k): parameter[k]
+		}
+	}
+	_contentHash: hex.Encode(sha256.Sum256(json.Marshal(_content)))
+
+	_nginxConf: strings.Join([
+		"server {",
+		"    listen 8080;",
+		"    root \(_root);",
+		"    index index.html;",
+		if parameter.gzip {"    gzip on;\n    gzip_vary on;\n    gzip_min_length 256;\n    gzip_types text/css text/plain application/javascript application/json image/svg+xml;"},
+		"    location / {",
+		if parameter.spa {"        try_files $uri $uri/ /index.html;"},
+		if !parameter.spa {"        try_files $uri $uri/ =404;"},
+		"        add_header Cache-Control \"no-cache\";",
+		"    }",
+		"    location ~* \\.(?:css|js|mjs|map|json|png|jpe?g|gif|svg|ico|webp|avif|woff2?|ttf)$ {",
+		"        try_files $uri =404;",
+		"        add_header Cache-Control \"public, max-age=\(parameter.cacheMaxAge)\";",
+		"    }",
+		"}",
+		"",
+	], "\n")
+
+	_volumes: [
+		{name: "nginx-conf", configMap: name: "\(context.name)-nginx"},
+		if _source == "files" {name: "content", configMap: name: "\(context.name)-content"},
+		if _source == "configMap" {name: "content", configMap: name: parameter.configMap},
+		if _source == "secret" {name: "content", secret: secretName: parameter.secret},
+		if _source == "git" {name: "content", emptyDir: {}},
+	]
+
+	_volumeMounts: [
+		{name: "nginx-conf", mountPath: "/etc/nginx/conf.d"},
+		{name: "content", mountPath: "/usr/share/nginx/html"},
+	]
+
+	_initContainers: [if _source == "git" {
+		name:  "git-sync"
+		image: parameter.git.image
+		args: ["--repo=\(parameter.git.repo)", "--ref=\(parameter.git.ref)", "--root=/usr/share/nginx/html", "--link=current", "--depth=1", "--one-time"]
+		if parameter.git.credentialsSecret != _|_ {
+			env: [
+				{name: "GITSYNC_USERNAME", valueFrom: secretKeyRef: {name: parameter.git.credentialsSecret, key: "username"}},
+				{name: "GITSYNC_PASSWORD", valueFrom: secretKeyRef: {name: parameter.git.credentialsSecret, key: "password"}},
+			]
+		}
+		volumeMounts: [{name: "content", mountPath: "/usr/share/nginx/html"}]
+	}]
+
+	output: {
+		apiVersion: "apps/v1"
+		kind:       "Deployment"
+		spec: {
+			replicas: parameter.replicas
+			selector: {
+				matchLabels: {
+					"app.oam.dev/component": context.name
+				}
+			}
+			template: {
+				metadata: {
+					labels: {
+						"app.oam.dev/name": context.appName
+						"app.oam.dev/component": context.name
+					}
+					annotations: {
+						"static-site.oam.dev/content-hash": _contentHash
+					}
+				}
+				spec: {
+					containers: [{
+						name: context.name
+						image: parameter.image
+						ports: [{name: "http", containerPort: 8080}]
+						readinessProbe: {
+							tcpSocket: {
+								port: 8080
+							}
+						}
+						volumeMounts: _volumeMounts
+						if parameter["cpu"] != _|_ && !(parameter.limit.cpu != _|_) {
+							resources: {
+								requests: {
+									cpu: parameter.cpu
+								}
+								limits: {
+									cpu: parameter.cpu
+								}
+							}
+						}
+						if parameter["cpu"] != _|_ && parameter.limit.cpu != _|_ {
+							resources: {
+								requests: {
+									cpu: parameter.cpu
+								}
+								limits: {
+									cpu: parameter.limit.cpu
+								}
+							}
+						}
+						if parameter["memory"] != _|_ && !(parameter.limit.memory != _|_) {
+							resources: {
+								requests: {
+									memory: parameter.memory
+								}
+								limits: {
+									memory: parameter.memory
+								}
+							}
+						}
+						if parameter["memory"] != _|_ && parameter.limit.memory != _|_ {
+							resources: {
+								requests: {
+									memory: parameter.memory
+								}
+								limits: {
+									memory: parameter.limit.memory
+								}
+							}
+						}
+					}]
+					initContainers: _initContainers
+					volumes: _volumes
+				}
+			}
+		}
+	}
+	outputs: {
+		if parameter["files"] != _|_ {
+			content: {
+				apiVersion: "v1"
+				kind:       "ConfigMap"
+				metadata: {
+					name: "\(context.name)-content"
+				}
+				data: parameter.files
+			}
+		}
+		if parameter["ingress"] != _|_ {
+			ingress: {
+				apiVersion: "networking.k8s.io/v1"
+				kind:       "Ingress"
+				metadata: {
+					name: context.name
+				}
+				spec: {
+					rules: [{host: parameter.ingress.host, http: paths: [{path: "/", pathType: "Prefix", backend: service: {name: context.name, port: number: 80}}]}]
+					if parameter.ingress.className != _|_ {
+						ingressClassName: parameter.ingress.className
+					}
+					if parameter.ingress.tlsSecretName != _|_ {
+						tls: [{hosts: [parameter.ingress.host], secretName: parameter.ingress.tlsSecretName}]
+					}
+				}
+			}
+		}
+		nginxConfig: {
+			apiVersion: "v1"
+			kind:       "ConfigMap"
+			metadata: {
+				name: "\(context.name)-nginx"
+			}
+			data: {
+				"default.conf": _nginxConf
+			}
+		}
+		service: {
+			apiVersion: "v1"
+			kind:       "Service"
+			metadata: {
+				name: context.name
+			}
+			spec: {
+				selector: {
+					"app.oam.dev/component": context.name
+				}
+				ports: [{name: "http", port: 80, targetPort: 8080}]
+			}
+		}
+	}
+	parameter: {
+		// +usage=Image of nginx serving the site
+		image: *"nginx:1.27-alpine" | string
+		// +usage=Number of replicas
+		replicas: *1 | int & >=0
+		// +usage=Content of the files of the site by file name, e.g. `index.html`. Subdirectories are not supported
+		files?: [string]: string
+		// +usage=Serve the files of this ConfigMap
+		configMap?: string
+		// +usage=Serve the files of this Secret
+		secret?: string
+		// +usage=Serve the files of a git repository, cloned when the pods start
+		git?: {
+			// +usage=URL of the repository
+			repo: string
+			// +usage=Branch, tag or commit to clone
+			ref: *"HEAD" | string
+			// +usage=Directory of the site in the repository, defaults to its root
+			path?: string
+			// +usage=Secret with the username and password keys to clone a private repository
+			credentialsSecret?: string
+			// +usage=Image of git-sync
+			image: *"registry.k8s.io/git-sync/git-sync:v4.4.0" | string
+		}
+		// +usage=Serve index.html for the paths matching no file, for single page applications
+		spa: *true | bool
+		// +usage=Compress text responses with gzip
+		gzip: *true | bool
+		// +usage=Number of seconds browsers cache assets such as scripts, styles, images and fonts. HTML is always revalidated
+		cacheMaxAge: *3600 | int & >=0
+		// +usage=Expose the site on a host through an Ingress
+		ingress?: {
+			// +usage=Host name of the site
+			host: string
+			// +usage=IngressClass of the Ingress, defaults to the cluster default
+			className?: string
+			// +usage=Secret with the TLS certificate of the host, to serve the site over HTTPS
+			tlsSecretName?: string
+		}
+		// +usage=Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)
+		cpu?: string
+		// +usage=Specifies the attributes of the memory resource required for the container.
+		memory?: string
+		limit?: {
+			cpu?: string
+			memory?: string
+		}
+	}
+}
@@ -14,9 +14,11 @@ task: {
 		status: {
 			customStatus: #"""
 				status: {
-					active:    *0 | int
-					failed:    *0 | int
-					succeeded: *0 | int
+					active:           *0 | int
+					failed:           *0 | int
+					succeeded:        *0 | int
+					completedIndexes: *"" | string
+					failedIndexes:    *"" | string
 				} & {
 					if context.output.status.active != _|_ {
 						active: context.output.status.active
@@ -27,20 +29,169 @@ task: {
 					if context.output.status.succeeded != _|_ {
 						succeeded: context.output.status.succeeded
 					}
+					if context.output.status.completedIndexes != _|_ {
+						completedIndexes: context.output.status.completedIndexes
+					}
+					if context.output.status.failedIndexes != _|_ {
+						failedIndexes: context.output.status.failedIndexes
+					}
+				}
+				_indexes: *"" | string
+				if context.output.spec.completionMode == "Indexed" {
+					_indexes: " Completed indexes:\(status.completedIndexes) Failed indexes:\(status.failedIndexes)"
 				}
-				message: "Active/Failed/Succeeded:\(status.active)/\(status.failed)/\(status.succeeded)"
+				message: "Active/Failed/Succeeded:\(status.active)/\(status.failed)/\(status.succeeded)\(_indexes)"
 				"""#
 			healthPolicy: #"""
 				succeeded: *0 | int
 				if context.output.status.succeeded != _|_ {
 					succeeded: context.output.status.succeeded
 				}
-				isHealth: succeeded == context.output.spec.parallelism
+				isHealth: succeeded == context.output.spec.completions
 				"""#
 		}
 	}
 }
 template: {
+	mountsArray: [
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.pvc != _|_ for v in parameter.volumeMounts.pvc {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.configMap != _|_ for v in parameter.volumeMounts.configMap {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.secret != _|_ for v in parameter.volumeMounts.secret {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.emptyDir != _|_ for v in parameter.volumeMounts.emptyDir {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.hostPath != _|_ for v in parameter.volumeMounts.hostPath {
+		{
+			name: v.name
+			mountPath: v.mountPath
+			if v.subPath != _|_ {
+				subPath: v.subPath
+			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
+		}
+		}
+	]
+	volumesList: [
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.pvc != _|_ for v in parameter.volumeMounts.pvc {
+		{
+			name: v.name
+			persistentVolumeClaim: {
+				claimName: v.claimName
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.configMap != _|_ for v in parameter.volumeMounts.configMap {
+		{
+			configMap: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
+				}
+				name: v.cmName
+			}
+			name: v.name
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.secret != _|_ for v in parameter.volumeMounts.secret {
+		{
+			name: v.name
+			secret: {
+				defaultMode: v.defaultMode
+				if v.items != _|_ {
+					items: v.items
+				}
+				secretName: v.secretName
+			}
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.emptyDir != _|_ for v in parameter.volumeMounts.emptyDir {
+		{
+			emptyDir: {
+				medium: v.medium
+			}
+			name: v.name
+		}
+		},
+		if parameter.volumeMounts != _|_ && parameter.volumeMounts.hostPath != _|_ for v in parameter.volumeMounts.hostPath {
+		{
+			hostPath: {
+				path: v.path
+			}
+			name: v.name
+		}
+		}
+	]
+	deDupVolumesArray: [
+		for val in [
+			for i, vi in volumesList {
+				for j, vj in volumesList if j < i && vi.name == vj.name {
+					_ignore: true
+				}
+				vi
+			},
+		] if val._ignore == _|_ {
+			val
+		},
+	]
 	output: {
 		apiVersion: "batch/v1"
 		kind:       "Job"
@@ -48,8 +199,6 @@ template: {
 			name: "\(context.appName)-\(context.name)"
 		}
 		spec: {
-			parallelism: parameter.count
-			completions: parameter.count
 			template: {
 				metadata: {
 					labels: {
@@ -68,36 +217,56 @@ template: {
 					containers: [{
 						name: context.name
 						image: parameter.image
-						if parameter["cpu"] != _|_ {
+						if parameter["env"] != _|_ {
+							env: parameter.env
+						}
+						if context["config"] != _|_ {
+							env: context.config
+						}
+						if parameter["cpu"] != _|_ && !(parameter.limit.cpu != _|_) {
 							resources: {
+								requests: {
+									cpu: parameter.cpu
+								}
 								limits: {
 									cpu: parameter.cpu
 								}
+							}
+						}
+						if parameter["cpu"] != _|_ && parameter.limit.cpu != _|_ {
+							resources: {
 								requests: {
 									cpu: parameter.cpu
 								}
+								limits: {
+									cpu: parameter.limit.cpu
+								}
 							}
 						}
-						if parameter["memory"] != _|_ {
+						if parameter["memory"] != _|_ && !(parameter.limit.memory != _|_) {
 							resources: {
+								requests: {
+									memory: parameter.memory
+								}
 								limits: {
 									memory: parameter.memory
 								}
+							}
+						}
+						if parameter["memory"] != _|_ && parameter.limit.memory != _|_ {
+							resources: {
 								requests: {
 									memory: parameter.memory
 								}
+								limits: {
+									memory: parameter.limit.memory
+								}
 							}
 						}
-						if parameter["cmd"] != _|_ {
-							command: parameter.cmd
+						if parameter["volumeMounts"] != _|_ {
+							volumeMounts: mountsArray
 						}
-						if parameter["env"] != _|_ {
-							env: parameter.env
-						}
-						if parameter["imagePullPolicy"] != _|_ {
-							imagePullPolicy: parameter.imagePullPolicy
-						}
-						if parameter["volumes"] != _|_ {
+						if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
 							volumeMounts: [for v in parameter.volumes {
 				{
 					mountPath: v.mountPath
@@ -105,11 +274,26 @@ template: {
 				}
 			}]
 						}
+						if parameter["args"] != _|_ {
+							args: parameter.args
+						}
+						if parameter["cmd"] != _|_ {
+							command: parameter.cmd
+						}
+						if parameter["imagePullPolicy"] != _|_ {
+							imagePullPolicy: parameter.imagePullPolicy
+						}
+						if parameter["livenessProbe"] != _|_ {
+							livenessProbe: parameter.livenessProbe
+						}
+						if parameter["readinessProbe"] != _|_ {
+							readinessProbe: parameter.readinessProbe
+						}
 					}]
-					if parameter["imagePullSecrets"] != _|_ {
-						imagePullSecrets: [for v in parameter.imagePullSecrets { name: v }]
+					if parameter["volumeMounts"] != _|_ {
+						volumes: deDupVolumesArray
 					}
-					if parameter["volumes"] != _|_ {
+					if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
 						volumes: [for v in parameter.volumes {
 				{
 					name: v.name
@@ -144,8 +328,50 @@ template: {
 				}
 			}]
 					}
+					if parameter["hostAliases"] != _|_ {
+						// +patchKey=ip
+						hostAliases: parameter.hostAliases
+					}
+					if parameter["imagePullSecrets"] != _|_ {
+						imagePullSecrets: [for v in parameter.imagePullSecrets { name: v }]
+					}
 				}
 			}
+			parallelism: parameter.count
+			if parameter["completions"] != _|_ {
+				completions: parameter.completions
+			}
+			if parameter["completions"] == _|_ {
+				completions: parameter.count
+			}
+			completionMode: parameter.completionMode
+			if parameter["backoffLimitPerIndex"] != _|_ {
+				backoffLimitPerIndex: parameter.backoffLimitPerIndex
+			}
+			if parameter["backoffLimitPerIndex"] != _|_ && parameter.completionMode != "Indexed" {
+				backoffLimitPerIndex: error("backoffLimitPerIndex is only valid when completionMode is Indexed")
+			}
+			if parameter["maxFailedIndexes"] != _|_ {
+				maxFailedIndexes: parameter.maxFailedIndexes
+			}
+			if parameter["maxFailedIndexes"] != _|_ && parameter["backoffLimitPerIndex"] == _|_ {
+				maxFailedIndexes: error("maxFailedIndexes requires backoffLimitPerIndex")
+			}
+			if parameter["activeDeadlineSeconds"] != _|_ {
+				activeDeadlineSeconds: parameter.activeDeadlineSeconds
+			}
+			if parameter["backoffLimitPerIndex"] == _|_ {
+				backoffLimit: parameter.backoffLimit
+			}
+			if parameter["podFailurePolicy"] != _|_ {
+				podFailurePolicy: parameter.podFailurePolicy
+			}
+			if parameter["suspend"] != _|_ {
+				suspend: parameter.suspend
+			}
+			if parameter["ttlSecondsAfterFinished"] != _|_ {
+				ttlSecondsAfterFinished: parameter.ttlSecondsAfterFinished
+			}
 		}
 	}
 	parameter: {
@@ -156,6 +382,48 @@ template: {
 		// +usage=Specify number of tasks to run in parallel
 		// +short=c
 		count: *1 | int
+		// +usage=Define the job restart policy, the value can only be Never or OnFailure. By default, it's Never.
+		restart: *"Never" | string
+		// +usage=Specify number of tasks that must succeed, defaults to count
+		completions?: int
+		// +usage=Specify how completions are tracked. Indexed tasks get a completion index from 0 to completions-1 in the JOB_COMPLETION_INDEX env
+		completionMode: *"NonIndexed" | "Indexed"
+		// +usage=The number of retries before marking this job failed, not used when backoffLimitPerIndex is set
+		backoffLimit: *6 | int
+		// +usage=Specify number of retries of each index before it is marked failed. Only valid when completionMode is Indexed
+		backoffLimitPerIndex?: int
+		// +usage=Specify number of failed indexes after which the task fails. Requires backoffLimitPerIndex
+		maxFailedIndexes?: int
+		// +usage=Specify how pod failures are handled, requires restart to be Never
+		podFailurePolicy?: {
+			// +usage=Rules evaluated in order, the first matching rule applies
+			rules: [...{
+				// +usage=Action taken when the rule matches
+				action: "FailJob" | "FailIndex" | "Ignore" | "Count"
+				// +usage=Match pods by the exit codes of their containers
+				onExitCodes?: {
+					// +usage=Only match the exit code of this container
+					containerName?: string
+					// +usage=Relation between the exit code and values
+					operator: "In" | "NotIn"
+					// +usage=Exit codes to match
+					values: [...int]
+				}
+				// +usage=Match pods by their conditions
+				onPodConditions?: [...{
+					// +usage=Pod condition type, e.g. DisruptionTarget
+					type: string
+					// +usage=Pod condition status
+					status: *"True" | string
+				}]
+			}]
+		}
+		// +usage=The duration in seconds relative to the startTime that the job may be continuously active before the system tries to terminate it
+		activeDeadlineSeconds?: int
+		// +usage=Limits the lifetime of a Job that has finished
+		ttlSecondsAfterFinished?: int
+		// +usage=Specify whether the task is suspended, no pods are created while suspended
+		suspend?: bool
 		// +usage=Which image would you like to use for your service
 		// +short=i
 		image: string
@@ -163,10 +431,10 @@ template: {
 		imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
 		// +usage=Specify image pull secrets for your service
 		imagePullSecrets?: [...string]
-		// +usage=Define the job restart policy, the value can only be Never or OnFailure. By default, it's Never.
-		restart: *"Never" | string
 		// +usage=Commands to run in the container
 		cmd?: [...string]
+		// +usage=Arguments to the entrypoint
+		args?: [...string]
 		// +usage=Define arguments by using environment variables
 		env?: [...{
 			// +usage=Environment variable name
@@ -195,7 +463,63 @@ template: {
 		cpu?: string
 		// +usage=Specifies the attributes of the memory resource required for the container.
 		memory?: string
-		// +usage=Declare volumes and volumeMounts
+		limit?: {
+			cpu?: string
+			memory?: string
+		}
+		volumeMounts?: {
+			// +usage=Mount PVC type volume
+			pvc?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				// +usage=The name of the PVC
+				claimName: string
+			}]
+			// +usage=Mount ConfigMap type volume
+			configMap?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				defaultMode: *420 | int
+				cmName: string
+				items?: [...{
+					key: string
+					path: string
+					mode: *511 | int
+				}]
+			}]
+			// +usage=Mount Secret type volume
+			secret?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				defaultMode: *420 | int
+				secretName: string
+				items?: [...{
+					key: string
+					path: string
+					mode: *511 | int
+				}]
+			}]
+			// +usage=Mount EmptyDir type volume
+			emptyDir?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				medium: *"" | "Memory"
+			}]
+			// +usage=Mount HostPath type volume
+			hostPath?: [...{
+				name: string
+				mountPath: string
+				subPath?: string
+				mountPropagation?: "None" | "HostToContainer" | "Bidirectional"
+				path: string
+				readOnly?: bool
+			}]
+		}
+		// +usage=Deprecated field, use volumeMounts instead.
 		volumes?: [...{
 			name: string
 			mountPath: string
@@ -230,6 +554,11 @@ template: {
 		livenessProbe?: #HealthProbe
 		// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
 		readinessProbe?: #HealthProbe
+		// +usage=Specify the hostAliases to add
+		hostAliases?: [...{
+			ip: string
+			hostnames: [...string]
+		}]
 	}
 	#HealthProbe: {
 		// +usage=Instructions for assessing container health by executing a command. Either this attribute or the httpGet attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the httpGet attribute and the tcpSocket attribute.
@@ -243,6 +572,8 @@ template: {
 			path: string
 			// +usage=The TCP socket within the container to which the HTTP GET request should be directed.
 			port: int
+			host?: string
+			scheme?: *"HTTP" | string
 			httpHeaders?: [...{
 				name: string
 				value: string
@@ -0,0 +1,299 @@
+"tenant-namespace": {
+	type: "component"
+	annotations: {}
+	labels: {}
+	description: "Describes a tenant namespace with pod security, quota, limit range, network policy and RBAC guardrails."
+	attributes: {
+		workload: {
+			definition: {
+				apiVersion: "v1"
+				kind:       "Namespace"
+			}
+			type: "namespaces.v1"
+		}
+		status: {
+			customStatus: #"""
+				_phase: *"Pending" | string
+				if context.output.status != _|_ if context.output.status.phase != _|_ {
+					_phase: context.output.status.phase
+				}
+				_quota: [
+					if context.outputs.quota == _|_ {"missing"},
+					if context.outputs.quota.status.hard != _|_ {"enforced"},
+					"pending",
+				][0]
+				_bindings: [for k in ["admins", "editors", "viewers"] if parameter[k] != _|_ {context.outputs["\(k)Binding"] != _|_}]
+				_boundGroups: len([for b in _bindings if b {b}])
+				message: "Phase:\(_phase), quota:\(_quota), bindings:\(_boundGroups)/\(len(_bindings))"
+				"""#
+			healthPolicy: #"""
+				_phase: *"Pending" | string
+				if context.output.status != _|_ if context.output.status.phase != _|_ {
+					_phase: context.output.status.phase
+				}
+				_quota: [
+					if context.outputs.quota == _|_ {"missing"},
+					if context.outputs.quota.status.hard != _|_ {"enforced"},
+					"pending",
+				][0]
+				_bindings: [for k in ["admins", "editors", "viewers"] if parameter[k] != _|_ {context.outputs["\(k)Binding"] != _|_}]
+				_boundGroups: len([for b in _bindings if b {b}])
+				isHealth: _phase == "Active" && _quota == "enforced" && _boundGroups == len(_bindings)
+				"""#
+		}
+	}
+}
+template: {
+	output: {
+		apiVersion: "v1"
+		kind:       "Namespace"
+		metadata: {
+			name: context.name
+			labels: {
+				if parameter["labels"] != _|_ {
+					parameter.labels
+				}
+				"pod-security.kubernetes.io/enforce": parameter.podSecurity.enforce
+				"pod-security.kubernetes.io/enforce-version": parameter.podSecurity.version
+				"pod-security.kubernetes.io/audit": parameter.podSecurity.audit
+				"pod-security.kubernetes.io/audit-version": parameter.podSecurity.version
+				"pod-security.kubernetes.io/warn": parameter.podSecurity.warn
+				"pod-security.kubernetes.io/warn-version": parameter.podSecurity.version
+			}
+			if parameter["annotations"] != _|_ {
+				annotations: parameter.annotations
+			}
+		}
+	}
+	outputs: {
+		if parameter["admins"] != _|_ {
+			adminsBinding: {
+				apiVersion: "rbac.authorization.k8s.io/v1"
+				kind:       "RoleBinding"
+				metadata: {
+					name: "tenant-admins"
+					namespace: context.name
+				}
+				roleRef: {apiGroup: "rbac.authorization.k8s.io", kind: "ClusterRole", name: "admin"}
+				subjects: [for g in parameter.admins {apiGroup: "rbac.authorization.k8s.io", kind: "Group", name: g}]
+			}
+		}
+		if parameter.networkPolicy.defaultDeny == true {
+			defaultDenyPolicy: {
+				apiVersion: "networking.k8s.io/v1"
+				kind:       "NetworkPolicy"
+				metadata: {
+					name: "default-deny"
+					namespace: context.name
+				}
+				spec: {
+					podSelector: {}
+					policyTypes: ["Ingress", "Egress"]
+				}
+			}
+		}
+		if parameter.networkPolicy.allowDNS == true {
+			dnsPolicy: {
+				apiVersion: "networking.k8s.io/v1"
+				kind:       "NetworkPolicy"
+				metadata: {
+					name: "allow-dns"
+					namespace: context.name
+				}
+				spec: {
+					podSelector: {}
+					policyTypes: ["Egress"]
+					egress: [{
+	to: [{namespaceSelector: matchLabels: "kubernetes.io/metadata.name": "kube-system", podSelector: matchLabels: "k8s-app": "kube-dns"}]
+	ports: [{protocol: "UDP", port: 53}, {protocol: "TCP", port: 53}]
+}]
+				}
+			}
+		}
+		if parameter["editors"] != _|_ {
+			editorsBinding: {
+				apiVersion: "rbac.authorization.k8s.io/v1"
+				kind:       "RoleBinding"
+				metadata: {
+					name: "tenant-editors"
+					namespace: context.name
+				}
+				roleRef: {apiGroup: "rbac.authorization.k8s.io", kind: "ClusterRole", name: "edit"}
+				subjects: [for g in parameter.editors {apiGroup: "rbac.authorization.k8s.io", kind: "Group", name: g}]
+			}
+		}
+		if parameter.networkPolicy.allowEgressCIDRs != _|_ {
+			egressCIDRsPolicy: {
+				apiVersion: "networking.k8s.io/v1"
+				kind:       "NetworkPolicy"
+				metadata: {
+					name: "allow-egress-cidrs"
+					namespace: context.name
+				}
+				spec: {
+					podSelector: {}
+					policyTypes: ["Egress"]
+					egress: [{to: [for c in parameter.networkPolicy.allowEgressCIDRs {ipBlock: cidr: c}]}]
+				}
+			}
+		}
+		if parameter.networkPolicy.allowFromNamespaces != _|_ {
+			fromNamespacesPolicy: {
+				apiVersion: "networking.k8s.io/v1"
+				kind:       "NetworkPolicy"
+				metadata: {
+					name: "allow-from-namespaces"
+					namespace: context.name
+				}
+				spec: {
+					podSelector: {}
+					policyTypes: ["Ingress"]
+					ingress: [{from: [{namespaceSelector: matchExpressions: [{key: "kubernetes.io/metadata.name", operator: "In", values: parameter.networkPolicy.allowFromNamespaces}]}]}]
+				}
+			}
+		}
+		limitRange: {
+			apiVersion: "v1"
+			kind:       "LimitRange"
+			metadata: {
+				name: "tenant-limits"
+				namespace: context.name
+			}
+			spec: {
+				limits: [{
+	type:           "Container"
+	defaultRequest: parameter.limitRange.defaultRequest
+	default:        parameter.limitRange.defaultLimit
+	if parameter.limitRange.max != _|_ {max: parameter.limitRange.max}
+}]
+			}
+		}
+		quota: {
+			apiVersion: "v1"
+			kind:       "ResourceQuota"
+			metadata: {
+				name: "tenant-quota"
+				namespace: context.name
+			}
+			spec: {
+				hard: {
+	if parameter.quota.hard != _|_ {parameter.quota.hard}
+	for k, v in {
+		"requests.cpu":    parameter.quota.cpu
+		"requests.memory": parameter.quota.memory
+		if parameter.quota.limitsCpu != _|_ {"limits.cpu": parameter.quota.limitsCpu}
+		if parameter.quota.limitsMemory != _|_ {"limits.memory": parameter.quota.limitsMemory}
+		if parameter.quota.storage != _|_ {"requests.storage": parameter.quota.storage}
+		pods: "\(parameter.quota.pods)"
+		if parameter.quota.services != _|_ {services: "\(parameter.quota.services)"}
+		if parameter.quota.persistentVolumeClaims != _|_ {persistentvolumeclaims: "\(parameter.quota.persistentVolumeClaims)"}
+	} if parameter.quota.hard[k] == _|_ {(k): v}
+}
+			}
+		}
+		if parameter.networkPolicy.allowSameNamespace == true {
+			sameNamespacePolicy: {
+				apiVersion: "networking.k8s.io/v1"
+				kind:       "NetworkPolicy"
+				metadata: {
+					name: "allow-same-namespace"
+					namespace: context.name
+				}
+				spec: {
+					podSelector: {}
+					policyTypes: ["Ingress", "Egress"]
+					ingress: [{from: [{podSelector: {}}]}]
+					egress: [{to: [{podSelector: {}}]}]
+				}
+			}
+		}
+		if parameter["viewers"] != _|_ {
+			viewersBinding: {
+				apiVersion: "rbac.authorization.k8s.io/v1"
+				kind:       "RoleBinding"
+				metadata: {
+					name: "tenant-viewers"
+					namespace: context.name
+				}
+				roleRef: {apiGroup: "rbac.authorization.k8s.io", kind: "ClusterRole", name: "view"}
+				subjects: [for g in parameter.viewers {apiGroup: "rbac.authorization.k8s.io", kind: "Group", name: g}]
+			}
+		}
+	}
+	parameter: {
+		// +usage=Labels of the namespace
+		labels?: [string]: string
+		// +usage=Annotations of the namespace
+		annotations?: [string]: string
+		// +usage=Pod Security Standards applied to the pods of the namespace
+		podSecurity: {
+			// +usage=Reject the pods violating this level
+			enforce: *"baseline" | "privileged" | "restricted"
+			// +usage=Record the pods violating this level in the audit log
+			audit: *"restricted" | "privileged" | "baseline"
+			// +usage=Warn the users creating pods violating this level
+			warn: *"restricted" | "privileged" | "baseline"
+			// +usage=Kubernetes version of the standards, e.g. v1.30
+			version: *"latest" | string
+		}
+		// +usage=ResourceQuota of the namespace
+		quota: {
+			// +usage=Total CPU requests
+			cpu: *"4" | string
+			// +usage=Total memory requests
+			memory: *"8Gi" | string
+			// +usage=Total CPU limits
+			limitsCpu?: string
+			// +usage=Total memory limits
+			limitsMemory?: string
+			// +usage=Total storage requests of the PersistentVolumeClaims
+			storage?: string
+			// +usage=Maximum number of pods
+			pods: *50 | int
+			// +usage=Maximum number of Services
+			services?: int
+			// +usage=Maximum number of PersistentVolumeClaims
+			persistentVolumeClaims?: int
+			// +usage=Other quotas by resource name, e.g. `services.loadbalancers`, taking precedence over the fields above for the same resource
+			hard?: [string]: string
+		}
+		// +usage=Resources of the containers of the namespace
+		limitRange: {
+			// +usage=Requests of the containers without requests
+			defaultRequest: {
+				// +usage=CPU request
+				cpu: *"100m" | string
+				// +usage=Memory request
+				memory: *"128Mi" | string
+			}
+			// +usage=Limits of the containers without limits
+			defaultLimit: {
+				// +usage=CPU limit
+				cpu: *"500m" | string
+				// +usage=Memory limit
+				memory: *"512Mi" | string
+			}
+			// +usage=Maximum limits of a container, e.g. `memory: "4Gi"`
+			max?: {...}
+		}
+		// +usage=NetworkPolicies of the namespace, denying the traffic of its pods not allowed
+		networkPolicy: {
+			// +usage=Deny the ingress and egress traffic of the pods
+			defaultDeny: *true | bool
+			// +usage=Allow the traffic between the pods of the namespace
+			allowSameNamespace: *true | bool
+			// +usage=Allow the DNS queries to kube-dns
+			allowDNS: *true | bool
+			// +usage=Namespaces allowed to reach the pods, e.g. ingress-nginx
+			allowFromNamespaces?: [...string]
+			// +usage=CIDRs the pods are allowed to reach, e.g. of an external database
+			allowEgressCIDRs?: [...string]
+		}
+		// +usage=Groups administrating the namespace, bound to the admin ClusterRole
+		admins?: [...string]
+		// +usage=Groups deploying to the namespace, bound to the edit ClusterRole
+		editors?: [...string]
+		// +usage=Groups reading the namespace, bound to the view ClusterRole
+		viewers?: [...string]
+	}
+}
@@ -67,6 +67,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		},
 		if parameter.volumeMounts != _|_ && parameter.volumeMounts.configMap != _|_ for v in parameter.volumeMounts.configMap {
@@ -76,6 +82,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		},
 		if parameter.volumeMounts != _|_ && parameter.volumeMounts.secret != _|_ for v in parameter.volumeMounts.secret {
@@ -85,6 +97,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		},
 		if parameter.volumeMounts != _|_ && parameter.volumeMounts.emptyDir != _|_ for v in parameter.volumeMounts.emptyDir {
@@ -94,6 +112,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		},
 		if parameter.volumeMounts != _|_ && parameter.volumeMounts.hostPath != _|_ for v in parameter.volumeMounts.hostPath {
@@ -103,6 +127,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		}
 	]
@@ -197,43 +227,6 @@ template: {
 					containers: [{
 						name: context.name
 						image: parameter.image
-						if parameter["port"] != _|_ && parameter["ports"] == _|_ {
-							ports: [{
-							containerPort: parameter.port
-						}]
-						}
-						if parameter["ports"] != _|_ {
-							ports: [
-		for v in parameter.ports {
-			if v.containerPort != _|_ {
-				containerPort: v.containerPort
-			}
-			if v.containerPort == _|_ {
-				containerPort: v.port
-			}
-			protocol: v.protocol
-			if v.name != _|_ {
-				name: v.name
-			}
-			if v.name == _|_ {
-				if v.containerPort != _|_ {
-					_name: "port-" + strconv.FormatInt(v.containerPort, 10)
-					name: *_name | string
-					if v.protocol != "TCP" {
-						name: _name + "-" + strings.ToLower(v.protocol)
-					}
-				}
-				if v.containerPort == _|_ {
-					_name: "port-" + strconv.FormatInt(v.port, 10)
-					name: *_name | string
-					if v.protocol != "TCP" {
-						name: _name + "-" + strings.ToLower(v.protocol)
-					}
-				}
-			}
-		},
-	]
-						}
 						if parameter["env"] != _|_ {
 							env: parameter.env
 						}
@@ -280,6 +273,9 @@ template: {
 								}
 							}
 						}
+						if parameter["volumeMounts"] != _|_ {
+							volumeMounts: mountsArray
+						}
 						if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
 							volumeMounts: [for v in parameter.volumes {
 				{
@@ -288,8 +284,42 @@ template: {
 				}
 			}]
 						}
-						if parameter["volumeMounts"] != _|_ {
-							volumeMounts: mountsArray
+						if parameter["port"] != _|_ && parameter["ports"] == _|_ {
+							ports: [{
+							containerPort: parameter.port
+						}]
+						}
+						if parameter["ports"] != _|_ {
+							ports: [
+		for v in parameter.ports {
+			if v.containerPort != _|_ {
+				containerPort: v.containerPort
+			}
+			if v.containerPort == _|_ {
+				containerPort: v.port
+			}
+			protocol: v.protocol
+			if v.name != _|_ {
+				name: v.name
+			}
+			if v.name == _|_ {
+				if v.containerPort != _|_ {
+					_name: "port-" + strconv.FormatInt(v.containerPort, 10)
+					name: *_name | string
+					if v.protocol != "TCP" {
+						name: _name + "-" + strings.ToLower(v.protocol)
+					}
+				}
+				if v.containerPort == _|_ {
+					_name: "port-" + strconv.FormatInt(v.port, 10)
+					name: *_name | string
+					if v.protocol != "TCP" {
+						name: _name + "-" + strings.ToLower(v.protocol)
+					}
+				}
+			}
+		},
+	]
 						}
 						if parameter["args"] != _|_ {
 							args: parameter.args
@@ -306,7 +336,16 @@ template: {
 						if parameter["readinessProbe"] != _|_ {
 							readinessProbe: parameter.readinessProbe
 						}
+						if parameter["securityContext"] != _|_ {
+							securityContext: *parameter.securityContext | _
+						}
+						if parameter["startupProbe"] != _|_ {
+							startupProbe: *parameter.startupProbe | _
+						}
 					}]
+					if parameter["volumeMounts"] != _|_ {
+						volumes: deDupVolumesArray
+					}
 					if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
 						volumes: [for v in parameter.volumes {
 				{
@@ -342,9 +381,6 @@ template: {
 				}
 			}]
 					}
-					if parameter["volumeMounts"] != _|_ {
-						volumes: deDupVolumesArray
-					}
 					if parameter["hostAliases"] != _|_ {
 						// +patchKey=ip
 						hostAliases: parameter.hostAliases
@@ -352,8 +388,37 @@ template: {
 					if parameter["imagePullSecrets"] != _|_ {
 						imagePullSecrets: [for v in parameter.imagePullSecrets { name: v }]
 					}
+					if parameter["nodeSelector"] != _|_ {
+						nodeSelector: *parameter.nodeSelector | _
+					}
+					if parameter["podSecurityContext"] != _|_ {
+						securityContext: *parameter.podSecurityContext | _
+					}
+					if parameter["priorityClassName"] != _|_ {
+						priorityClassName: *parameter.priorityClassName | _
+					}
+					if parameter["serviceAccountName"] != _|_ {
+						serviceAccountName: *parameter.serviceAccountName | _
+					}
+					if parameter["terminationGracePeriodSeconds"] != _|_ {
+						terminationGracePeriodSeconds: *parameter.terminationGracePeriodSeconds | _
+					}
+					if parameter["tolerations"] != _|_ {
+						// +patchKey=key
+						tolerations: *parameter.tolerations | _
+					}
+					if parameter["topologySpreadConstraints"] != _|_ {
+						// +patchKey=topologyKey
+						topologySpreadConstraints: *[for c in parameter.topologySpreadConstraints {
+	c
+	if c.labelSelector == _|_ {labelSelector: matchLabels: "app.oam.dev/component": context.name}
+}] | _
+					}
 				}
 			}
+			if parameter["strategy"] != _|_ {
+				strategy: parameter.strategy
+			}
 		}
 	}
 	exposePorts: [
@@ -392,6 +457,9 @@ template: {
 			if v.protocol != _|_ {
 				protocol: v.protocol
 			}
+			if v.appProtocol != _|_ {
+				appProtocol: v.appProtocol
+			}
 		},
 	]
 	outputs: {
@@ -401,6 +469,9 @@ template: {
 				kind:       "Service"
 				metadata: {
 					name: context.name
+					if parameter.service.annotations != _|_ {
+						annotations: parameter.service.annotations
+					}
 				}
 				spec: {
 					selector: {
@@ -408,6 +479,24 @@ template: {
 					}
 					ports: exposePorts
 					type: parameter.exposeType
+					if parameter.service.clusterIP != _|_ {
+						clusterIP: [if parameter.service.clusterIP == "None" if parameter.exposeType != "ClusterIP" {error("service.clusterIP None is only valid for a ClusterIP Service")}, parameter.service.clusterIP][0]
+					}
+					if parameter.service.externalTrafficPolicy != _|_ {
+						externalTrafficPolicy: [if parameter.exposeType != "NodePort" if parameter.exposeType != "LoadBalancer" {error("service.externalTrafficPolicy is only valid for a NodePort or LoadBalancer Service")}, parameter.service.externalTrafficPolicy][0]
+					}
+					if parameter.service.ipFamilies != _|_ {
+						ipFamilies: parameter.service.ipFamilies
+					}
+					if parameter.service.ipFamilyPolicy != _|_ {
+						ipFamilyPolicy: parameter.service.ipFamilyPolicy
+					}
+					if parameter.service.loadBalancerSourceRanges != _|_ {
+						loadBalancerSourceRanges: parameter.service.loadBalancerSourceRanges
+					}
+					if parameter.service.sessionAffinity != _|_ {
+						sessionAffinity: parameter.service.sessionAffinity
+					}
 				}
 			}
 		}
@@ -424,31 +513,6 @@ template: {
 		imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
 		// +usage=Specify image pull secrets for your service
 		imagePullSecrets?: [...string]
-		// +ignore
-		// +usage=Deprecated field, please use ports instead
-		// +short=p
-		port?: int
-		// +usage=Which ports do you want customer traffic sent to, defaults to 80
-		ports?: [...{
-			// +usage=Number of port to expose on the pod's IP address
-			port: int
-			// +usage=Number of container port to connect to, defaults to port
-			containerPort?: int
-			// +usage=Name of the port
-			name?: string
-			// +usage=Protocol for port. Must be UDP, TCP, or SCTP
-			protocol: *"TCP" | "UDP" | "SCTP"
-			// +usage=Specify if the port should be exposed
-			expose: *false | bool
-			// +usage=exposed node port. Only Valid when exposeType is NodePort
-			nodePort?: int
-		}]
-		// +ignore
-		// +usage=Specify what kind of Service you want. options: "ClusterIP", "NodePort", "LoadBalancer"
-		exposeType: *"ClusterIP" | "NodePort" | "LoadBalancer"
-		// +ignore
-		// +usage=If addRevisionLabel is true, the revision label will be added to the underlying pods
-		addRevisionLabel: *false | bool
 		// +usage=Commands to run in the container
 		cmd?: [...string]
 		// +usage=Arguments to the entrypoint
@@ -477,6 +541,50 @@ template: {
 				}
 			}
 		}]
+		// +ignore
+		// +usage=Deprecated field, please use ports instead
+		// +short=p
+		port?: int
+		// +usage=Which ports do you want customer traffic sent to, defaults to 80
+		ports?: [...{
+			// +usage=Number of port to expose on the pod's IP address
+			port: int
+			// +usage=Number of container port to connect to, defaults to port
+			containerPort?: int
+			// +usage=Name of the port
+			name?: string
+			// +usage=Protocol for port. Must be UDP, TCP, or SCTP
+			protocol: *"TCP" | "UDP" | "SCTP"
+			// +usage=Specify if the port should be exposed
+			expose: *false | bool
+			// +usage=exposed node port. Only Valid when exposeType is NodePort
+			nodePort?: int
+			// +usage=Application protocol of the port, e.g. http, https or kubernetes.io/h2c
+			appProtocol?: string
+		}]
+		// +ignore
+		// +usage=Specify what kind of Service you want. options: "ClusterIP", "NodePort", "LoadBalancer"
+		exposeType: *"ClusterIP" | "NodePort" | "LoadBalancer"
+		// +usage=Specify the options of the Service exposing the ports
+		service?: {
+			// +usage=Annotations of the Service, e.g. to configure a cloud load balancer
+			annotations?: [string]: string
+			// +usage=Set to "None" for a headless Service, only valid for a ClusterIP Service
+			clusterIP?: string
+			// +usage=Set to "ClientIP" to send the requests of a client to the same pod
+			sessionAffinity?: "None" | "ClientIP"
+			// +usage=Set to "Local" to keep the client source IP, only valid for a NodePort or LoadBalancer Service
+			externalTrafficPolicy?: "Cluster" | "Local"
+			// +usage=Client CIDRs allowed to reach a LoadBalancer Service
+			loadBalancerSourceRanges?: [...string]
+			// +usage=Specify the dual-stack behavior of the Service
+			ipFamilyPolicy?: "SingleStack" | "PreferDualStack" | "RequireDualStack"
+			// +usage=IP families of the Service, in order of preference
+			ipFamilies?: [..."IPv4" | "IPv6"]
+		}
+		// +ignore
+		// +usage=If addRevisionLabel is true, the revision label will be added to the underlying pods
+		addRevisionLabel: *false | bool
 		// +usage=Number of CPU units for the service, like `0.5` (0.5 CPU core), `1` (1 CPU core)
 		cpu?: string
 		// +usage=Specifies the attributes of the memory resource required for the container.
@@ -532,7 +640,9 @@ template: {
 				name: string
 				mountPath: string
 				subPath?: string
+				mountPropagation?: "None" | "HostToContainer" | "Bidirectional"
 				path: string
+				readOnly?: bool
 			}]
 		}
 		// +usage=Deprecated field, use volumeMounts instead.
@@ -570,11 +680,99 @@ template: {
 		livenessProbe?: #HealthProbe
 		// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
 		readinessProbe?: #HealthProbe
+		// +usage=Instructions for assessing whether the container has started, the other probes wait for it to succeed.
+		startupProbe?: #HealthProbe
+		// +usage=Number of seconds the pod is given to terminate gracefully before it is killed
+		terminationGracePeriodSeconds?: int & >=0
+		// +usage=Specify the security context of the container
+		securityContext?: {
+			// +usage=Whether a process can gain more privileges than its parent process
+			allowPrivilegeEscalation?: bool
+			// +usage=Whether the container has a read-only root filesystem
+			readOnlyRootFilesystem?: bool
+			// +usage=Run the container in privileged mode
+			privileged?: bool
+			// +usage=Specify if the container must run as a non-root user
+			runAsNonRoot?: bool
+			// +usage=Specify the UID to run the entrypoint of the container process
+			runAsUser?: int
+			// +usage=Specify the GID to run the entrypoint of the container process
+			runAsGroup?: int
+			// +usage=Specify the capabilities to add and drop
+			capabilities?: {
+				// +usage=Capabilities to add
+				add?: [...string]
+				// +usage=Capabilities to drop, e.g. ALL
+				drop?: [...string]
+			}
+		}
+		// +usage=Specify the security context of the pod, applied to all its containers
+		podSecurityContext?: {
+			// +usage=Specify if the containers must run as a non-root user
+			runAsNonRoot?: bool
+			// +usage=Specify the UID to run the entrypoint of the container processes
+			runAsUser?: int
+			// +usage=Specify the GID to run the entrypoint of the container processes
+			runAsGroup?: int
+			// +usage=Specify the group owning the volumes mounted in the pod
+			fsGroup?: int
+			// +usage=Specify the seccomp profile of the containers
+			seccompProfile?: {
+				type: "RuntimeDefault" | "Unconfined" | "Localhost"
+				// +usage=localhostProfile is required when type is 'Localhost'
+				localhostProfile?: string
+			}
+		}
+		// +usage=Specify the name of the ServiceAccount the pod runs as
+		serviceAccountName?: string
 		// +usage=Specify the hostAliases to add
 		hostAliases?: [...{
 			ip: string
 			hostnames: [...string]
 		}]
+		// +usage=Schedule the pod on the nodes having these labels
+		nodeSelector?: [string]: string
+		// +usage=Specify the taints the pod tolerates
+		tolerations?: [...{
+			// +usage=Key of the taint, empty with operator Exists to tolerate everything
+			key?: string
+			operator: *"Equal" | "Exists"
+			// +usage=Value of the taint, for operator Equal
+			value?: string
+			// +usage=Effect of the taint to tolerate, empty for all the effects
+			effect?: "NoSchedule" | "PreferNoSchedule" | "NoExecute"
+			// +usage=Number of seconds the pod stays bound to a node tainted NoExecute
+			tolerationSeconds?: int
+		}]
+		// +usage=Specify how the pods are spread across the topology domains, e.g. zones or nodes
+		topologySpreadConstraints?: [...{
+			// +usage=Maximum difference of the number of pods between two domains
+			maxSkew: int & >=1
+			// +usage=Node label whose values are the domains, e.g. topology.kubernetes.io/zone
+			topologyKey: string
+			// +usage=Indicate how to deal with a pod if it doesn't satisfy the spread constraint
+			whenUnsatisfiable: *"DoNotSchedule" | "ScheduleAnyway"
+			// +usage=Select the pods to spread, defaults to the pods of the component
+			labelSelector?: {...}
+			// +usage=Indicate a minimum number of eligible domains
+			minDomains?: int
+			// +usage=Pod label keys whose values select the pods to spread, next to labelSelector
+			matchLabelKeys?: [...string]
+		}]
+		// +usage=Specify the PriorityClass of the pod
+		priorityClassName?: string
+		// +usage=Specify how the pods are replaced on updates
+		strategy?: {
+			// +usage="RollingUpdate" replaces the pods progressively, "Recreate" deletes them all before creating the new ones
+			type: *"RollingUpdate" | "Recreate"
+			// +usage=Tune the rolling update, only valid with type RollingUpdate
+			rollingUpdate?: {
+				// +usage=Number or percentage of pods created above the replicas, e.g. `25%`
+				maxSurge?: int | string
+				// +usage=Number or percentage of pods unavailable during the update, e.g. `25%`
+				maxUnavailable?: int | string
+			}
+		}
 	}
 	#HealthProbe: {
 		// +usage=Instructions for assessing container health by executing a command. Either this attribute or the httpGet attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the httpGet attribute and the tcpSocket attribute.
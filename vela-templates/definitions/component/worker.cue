@@ -58,6 +58,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		},
 		if parameter.volumeMounts != _|_ && parameter.volumeMounts.configMap != _|_ for v in parameter.volumeMounts.configMap {
@@ -67,6 +73,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		},
 		if parameter.volumeMounts != _|_ && parameter.volumeMounts.secret != _|_ for v in parameter.volumeMounts.secret {
@@ -76,6 +88,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		},
 		if parameter.volumeMounts != _|_ && parameter.volumeMounts.emptyDir != _|_ for v in parameter.volumeMounts.emptyDir {
@@ -85,6 +103,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		},
 		if parameter.volumeMounts != _|_ && parameter.volumeMounts.hostPath != _|_ for v in parameter.volumeMounts.hostPath {
@@ -94,6 +118,12 @@ template: {
 			if v.subPath != _|_ {
 				subPath: v.subPath
 			}
+			if v.mountPropagation != _|_ {
+				mountPropagation: v.mountPropagation
+			}
+			if v.readOnly != _|_ {
+				readOnly: v.readOnly
+			}
 		}
 		}
 	]
@@ -179,26 +209,55 @@ template: {
 					containers: [{
 						name: context.name
 						image: parameter.image
-						if parameter["cpu"] != _|_ {
+						if parameter["env"] != _|_ {
+							env: parameter.env
+						}
+						if context["config"] != _|_ {
+							env: context.config
+						}
+						if parameter["cpu"] != _|_ && !(parameter.limit.cpu != _|_) {
 							resources: {
+								requests: {
+									cpu: parameter.cpu
+								}
 								limits: {
 									cpu: parameter.cpu
 								}
+							}
+						}
+						if parameter["cpu"] != _|_ && parameter.limit.cpu != _|_ {
+							resources: {
 								requests: {
 									cpu: parameter.cpu
 								}
+								limits: {
+									cpu: parameter.limit.cpu
+								}
 							}
 						}
-						if parameter["memory"] != _|_ {
+						if parameter["memory"] != _|_ && !(parameter.limit.memory != _|_) {
 							resources: {
+								requests: {
+									memory: parameter.memory
+								}
 								limits: {
 									memory: parameter.memory
 								}
+							}
+						}
+						if parameter["memory"] != _|_ && parameter.limit.memory != _|_ {
+							resources: {
 								requests: {
 									memory: parameter.memory
 								}
+								limits: {
+									memory: parameter.limit.memory
+								}
 							}
 						}
+						if parameter["volumeMounts"] != _|_ {
+							volumeMounts: mountsArray
+						}
 						if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
 							volumeMounts: [for v in parameter.volumes {
 				{
@@ -207,15 +266,12 @@ template: {
 				}
 			}]
 						}
-						if parameter["volumeMounts"] != _|_ {
-							volumeMounts: mountsArray
+						if parameter["args"] != _|_ {
+							args: parameter.args
 						}
 						if parameter["cmd"] != _|_ {
 							command: parameter.cmd
 						}
-						if parameter["env"] != _|_ {
-							env: parameter.env
-						}
 						if parameter["imagePullPolicy"] != _|_ {
 							imagePullPolicy: parameter.imagePullPolicy
 						}
@@ -226,6 +282,9 @@ template: {
 							readinessProbe: parameter.readinessProbe
 						}
 					}]
+					if parameter["volumeMounts"] != _|_ {
+						volumes: deDupVolumesArray
+					}
 					if parameter["volumes"] != _|_ && parameter["volumeMounts"] == _|_ {
 						volumes: [for v in parameter.volumes {
 				{
@@ -261,8 +320,9 @@ template: {
 				}
 			}]
 					}
-					if parameter["volumeMounts"] != _|_ {
-						volumes: deDupVolumesArray
+					if parameter["hostAliases"] != _|_ {
+						// +patchKey=ip
+						hostAliases: parameter.hostAliases
 					}
 					if parameter["imagePullSecrets"] != _|_ {
 						imagePullSecrets: [for v in parameter.imagePullSecrets { name: v }]
@@ -276,11 +336,13 @@ template: {
 		// +short=i
 		image: string
 		// +usage=Specify image pull policy for your service
-		imagePullPolicy?: string
+		imagePullPolicy?: "Always" | "Never" | "IfNotPresent"
 		// +usage=Specify image pull secrets for your service
 		imagePullSecrets?: [...string]
 		// +usage=Commands to run in the container
 		cmd?: [...string]
+		// +usage=Arguments to the entrypoint
+		args?: [...string]
 		// +usage=Define arguments by using environment variables
 		env?: [...{
 			// +usage=Environment variable name
@@ -309,11 +371,16 @@ template: {
 		cpu?: string
 		// +usage=Specifies the attributes of the memory resource required for the container.
 		memory?: string
+		limit?: {
+			cpu?: string
+			memory?: string
+		}
 		volumeMounts?: {
 			// +usage=Mount PVC type volume
 			pvc?: [...{
 				name: string
 				mountPath: string
+				subPath?: string
 				// +usage=The name of the PVC
 				claimName: string
 			}]
@@ -321,6 +388,7 @@ template: {
 			configMap?: [...{
 				name: string
 				mountPath: string
+				subPath?: string
 				defaultMode: *420 | int
 				cmName: string
 				items?: [...{
@@ -333,6 +401,7 @@ template: {
 			secret?: [...{
 				name: string
 				mountPath: string
+				subPath?: string
 				defaultMode: *420 | int
 				secretName: string
 				items?: [...{
@@ -345,13 +414,17 @@ template: {
 			emptyDir?: [...{
 				name: string
 				mountPath: string
+				subPath?: string
 				medium: *"" | "Memory"
 			}]
 			// +usage=Mount HostPath type volume
 			hostPath?: [...{
 				name: string
 				mountPath: string
+				subPath?: string
+				mountPropagation?: "None" | "HostToContainer" | "Bidirectional"
 				path: string
+				readOnly?: bool
 			}]
 		}
 		// +usage=Deprecated field, use volumeMounts instead.
@@ -389,6 +462,11 @@ template: {
 		livenessProbe?: #HealthProbe
 		// +usage=Instructions for assessing whether the container is in a suitable state to serve traffic.
 		readinessProbe?: #HealthProbe
+		// +usage=Specify the hostAliases to add
+		hostAliases?: [...{
+			ip: string
+			hostnames: [...string]
+		}]
 	}
 	#HealthProbe: {
 		// +usage=Instructions for assessing container health by executing a command. Either this attribute or the httpGet attribute or the tcpSocket attribute MUST be specified. This attribute is mutually exclusive with both the httpGet attribute and the tcpSocket attribute.
@@ -402,6 +480,8 @@ template: {
 			path: string
 			// +usage=The TCP socket within the container to which the HTTP GET request should be directed.
 			port: int
+			host?: string
+			scheme?: *"HTTP" | string
 			httpHeaders?: [...{
 				name: string
 				value: string
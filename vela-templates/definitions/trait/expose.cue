@@ -49,8 +49,12 @@ template: {
 	outputs: service: {
 		apiVersion: "v1"
 		kind:       "Service"
-		metadata: name:        context.name
-		metadata: annotations: parameter.annotations
+		metadata: name: context.name
+		// service.annotations take precedence over annotations on the same key
+		metadata: annotations: {
+			for k, v in parameter.annotations if parameter.service.annotations[k] == _|_ {(k): v}
+			if parameter.service.annotations != _|_ {parameter.service.annotations}
+		}
 		spec: {
 			if parameter["matchLabels"] == _|_ {
 				selector: "app.oam.dev/component": context.name
@@ -89,10 +93,31 @@ template: {
 					if v.protocol != _|_ {
 						protocol: v.protocol
 					}
+					if v.appProtocol != _|_ {
+						appProtocol: v.appProtocol
+					}
 				},
 				]
 			}
 			type: parameter.type
+			if parameter.service.clusterIP != _|_ {
+				clusterIP: [if parameter.service.clusterIP == "None" if parameter.type != "ClusterIP" {error("service.clusterIP None is only valid for a ClusterIP Service")}, parameter.service.clusterIP][0]
+			}
+			if parameter.service.sessionAffinity != _|_ {
+				sessionAffinity: parameter.service.sessionAffinity
+			}
+			if parameter.service.externalTrafficPolicy != _|_ {
+				externalTrafficPolicy: [if parameter.type != "NodePort" if parameter.type != "LoadBalancer" {error("service.externalTrafficPolicy is only valid for a NodePort or LoadBalancer Service")}, parameter.service.externalTrafficPolicy][0]
+			}
+			if parameter.service.loadBalancerSourceRanges != _|_ {
+				loadBalancerSourceRanges: parameter.service.loadBalancerSourceRanges
+			}
+			if parameter.service.ipFamilyPolicy != _|_ {
+				ipFamilyPolicy: parameter.service.ipFamilyPolicy
+			}
+			if parameter.service.ipFamilies != _|_ {
+				ipFamilies: parameter.service.ipFamilies
+			}
 		}
 	}
 	parameter: {
@@ -108,11 +133,30 @@ template: {
 			protocol: *"TCP" | "UDP" | "SCTP"
 			// +usage=exposed node port. Only Valid when exposeType is NodePort
 			nodePort?: int
+			// +usage=Application protocol of the port, e.g. http, https or kubernetes.io/h2c
+			appProtocol?: string
 		}]
-		// +usage=Specify the annotations of the exposed service
+		// +usage=Specify the annotations of the exposed service, service.annotations take precedence on the same key
 		annotations: [string]:  string
 		matchLabels?: [string]: string
 		// +usage=Specify what kind of Service you want. options: "ClusterIP","NodePort","LoadBalancer","ExternalName"
 		type: *"ClusterIP" | "NodePort" | "LoadBalancer" | "ExternalName"
+		// +usage=Specify the options of the Service exposing the ports
+		service?: {
+			// +usage=Annotations of the Service, e.g. to configure a cloud load balancer
+			annotations?: [string]: string
+			// +usage=Set to "None" for a headless Service, only valid for a ClusterIP Service
+			clusterIP?: string
+			// +usage=Set to "ClientIP" to send the requests of a client to the same pod
+			sessionAffinity?: "None" | "ClientIP"
+			// +usage=Set to "Local" to keep the client source IP, only valid for a NodePort or LoadBalancer Service
+			externalTrafficPolicy?: "Cluster" | "Local"
+			// +usage=Client CIDRs allowed to reach a LoadBalancer Service
+			loadBalancerSourceRanges?: [...string]
+			// +usage=Specify the dual-stack behavior of the Service
+			ipFamilyPolicy?: "SingleStack" | "PreferDualStack" | "RequireDualStack"
+			// +usage=IP families of the Service, in order of preference
+			ipFamilies?: [..."IPv4" | "IPv6"]
+		}
 	}
 }